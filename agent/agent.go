@@ -1221,6 +1221,13 @@ func newConsulConfig(runtimeCfg *config.RuntimeConfig, logger hclog.Logger) (*co
 	if runtimeCfg.ConnectEnabled {
 		cfg.ConnectEnabled = true
 		cfg.ConnectMeshGatewayWANFederationEnabled = runtimeCfg.ConnectMeshGatewayWANFederationEnabled
+		cfg.ConnectSecondaryCARetryMinBackoff = runtimeCfg.ConnectSecondaryCARetryMinBackoff
+		cfg.ConnectSecondaryCARetryMaxBackoff = runtimeCfg.ConnectSecondaryCARetryMaxBackoff
+		cfg.ConnectSecondaryCARetryBackoffMultiplier = runtimeCfg.ConnectSecondaryCARetryBackoffMultiplier
+		cfg.ConnectCAConfigureAttempts = runtimeCfg.ConnectCAConfigureAttempts
+		cfg.ConnectMinLeafCertTTL = runtimeCfg.ConnectMinLeafCertTTL
+		cfg.ConnectCAManualSecondaryInit = runtimeCfg.ConnectCAManualSecondaryInit
+		cfg.ConnectLeafSignCacheTTL = runtimeCfg.ConnectLeafSignCacheTTL
 
 		ca, err := runtimeCfg.ConnectCAConfiguration()
 		if err != nil {
@@ -1228,6 +1235,22 @@ func newConsulConfig(runtimeCfg *config.RuntimeConfig, logger hclog.Logger) (*co
 		}
 
 		cfg.CAConfig = ca
+
+		for _, k := range runtimeCfg.ConnectAllowedCAKeyTypes {
+			keyType, err := structs.ParseCAKeyType(k)
+			if err != nil {
+				return nil, err
+			}
+			cfg.ConnectAllowedCAKeyTypes = append(cfg.ConnectAllowedCAKeyTypes, keyType)
+		}
+
+		for _, k := range runtimeCfg.ConnectMinPrimaryRootKeyTypes {
+			keyType, err := structs.ParseCAKeyType(k)
+			if err != nil {
+				return nil, err
+			}
+			cfg.ConnectMinPrimaryRootKeyTypes = append(cfg.ConnectMinPrimaryRootKeyTypes, keyType)
+		}
 	}
 
 	// copy over auto runtimeCfg settings
@@ -700,6 +700,9 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 			// Consul CA config
 			"private_key":           "PrivateKey",
 			"root_cert":             "RootCert",
+			"ca_bundle":             "CABundle",
+			"external_root_cert":    "ExternalRootCert",
+			"intermediate_cert":     "IntermediateCert",
 			"intermediate_cert_ttl": "IntermediateCertTTL",
 
 			// Vault CA config
@@ -713,18 +716,34 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 			"key_file":              "KeyFile",
 			"tls_server_name":       "TLSServerName",
 			"tls_skip_verify":       "TLSSkipVerify",
+			"auth_method":           "AuthMethod",
+			"mount_path":            "MountPath",
+			"leaf_role":             "LeafRole",
+			"allowed_domains":       "AllowedDomains",
+			"allow_subdomains":      "AllowSubdomains",
+			"allow_any_name":        "AllowAnyName",
+			"max_ttl":               "MaxTTL",
+			// Login params are passed to Vault as-is.
+			"AuthMethod.Params": "",
 
 			// AWS CA config
 			"existing_arn":   "ExistingARN",
 			"delete_on_exit": "DeleteOnExit",
 
+			// Azure Key Vault CA config
+			"vault_url": "VaultURL",
+			"hsm":       "HSM",
+
 			// Common CA config
-			"leaf_cert_ttl":      "LeafCertTTL",
-			"csr_max_per_second": "CSRMaxPerSecond",
-			"csr_max_concurrent": "CSRMaxConcurrent",
-			"private_key_type":   "PrivateKeyType",
-			"private_key_bits":   "PrivateKeyBits",
-			"root_cert_ttl":      "RootCertTTL",
+			"leaf_cert_ttl":                    "LeafCertTTL",
+			"csr_max_per_second":               "CSRMaxPerSecond",
+			"csr_max_concurrent":               "CSRMaxConcurrent",
+			"private_key_type":                 "PrivateKeyType",
+			"private_key_bits":                 "PrivateKeyBits",
+			"root_cert_ttl":                    "RootCertTTL",
+			"certificate_time_drift_buffer":    "CertificateTimeDriftBuffer",
+			"leaf_not_before_backdate":         "LeafNotBeforeBackdate",
+			"intermediate_not_before_backdate": "IntermediateNotBeforeBackdate",
 		})
 	}
 
@@ -960,40 +979,49 @@ func (b *builder) build() (rt RuntimeConfig, err error) {
 				c.Cache.EntryFetchMaxBurst, cache.DefaultEntryFetchMaxBurst,
 			),
 		},
-		CAFile:                                 stringVal(c.CAFile),
-		CAPath:                                 stringVal(c.CAPath),
-		CertFile:                               stringVal(c.CertFile),
-		CheckUpdateInterval:                    b.durationVal("check_update_interval", c.CheckUpdateInterval),
-		CheckOutputMaxSize:                     intValWithDefault(c.CheckOutputMaxSize, 4096),
-		Checks:                                 checks,
-		ClientAddrs:                            clientAddrs,
-		ConfigEntryBootstrap:                   configEntries,
-		AutoEncryptTLS:                         boolVal(c.AutoEncrypt.TLS),
-		AutoEncryptDNSSAN:                      autoEncryptDNSSAN,
-		AutoEncryptIPSAN:                       autoEncryptIPSAN,
-		AutoEncryptAllowTLS:                    autoEncryptAllowTLS,
-		AutoConfig:                             autoConfig,
-		ConnectEnabled:                         connectEnabled,
-		ConnectCAProvider:                      connectCAProvider,
-		ConnectCAConfig:                        connectCAConfig,
-		ConnectMeshGatewayWANFederationEnabled: connectMeshGatewayWANFederationEnabled,
-		ConnectSidecarMinPort:                  sidecarMinPort,
-		ConnectSidecarMaxPort:                  sidecarMaxPort,
-		ConnectTestCALeafRootChangeSpread:      b.durationVal("connect.test_ca_leaf_root_change_spread", c.Connect.TestCALeafRootChangeSpread),
-		ExposeMinPort:                          exposeMinPort,
-		ExposeMaxPort:                          exposeMaxPort,
-		DataDir:                                dataDir,
-		Datacenter:                             datacenter,
-		DefaultQueryTime:                       b.durationVal("default_query_time", c.DefaultQueryTime),
-		DevMode:                                boolVal(b.opts.DevMode),
-		DisableAnonymousSignature:              boolVal(c.DisableAnonymousSignature),
-		DisableCoordinates:                     boolVal(c.DisableCoordinates),
-		DisableHostNodeID:                      boolVal(c.DisableHostNodeID),
-		DisableHTTPUnprintableCharFilter:       boolVal(c.DisableHTTPUnprintableCharFilter),
-		DisableKeyringFile:                     boolVal(c.DisableKeyringFile),
-		DisableRemoteExec:                      boolVal(c.DisableRemoteExec),
-		DisableUpdateCheck:                     boolVal(c.DisableUpdateCheck),
-		DiscardCheckOutput:                     boolVal(c.DiscardCheckOutput),
+		CAFile:                                   stringVal(c.CAFile),
+		CAPath:                                   stringVal(c.CAPath),
+		CertFile:                                 stringVal(c.CertFile),
+		CheckUpdateInterval:                      b.durationVal("check_update_interval", c.CheckUpdateInterval),
+		CheckOutputMaxSize:                       intValWithDefault(c.CheckOutputMaxSize, 4096),
+		Checks:                                   checks,
+		ClientAddrs:                              clientAddrs,
+		ConfigEntryBootstrap:                     configEntries,
+		AutoEncryptTLS:                           boolVal(c.AutoEncrypt.TLS),
+		AutoEncryptDNSSAN:                        autoEncryptDNSSAN,
+		AutoEncryptIPSAN:                         autoEncryptIPSAN,
+		AutoEncryptAllowTLS:                      autoEncryptAllowTLS,
+		AutoConfig:                               autoConfig,
+		ConnectEnabled:                           connectEnabled,
+		ConnectCAProvider:                        connectCAProvider,
+		ConnectCAConfig:                          connectCAConfig,
+		ConnectAllowedCAKeyTypes:                 c.Connect.AllowedCAKeyTypes,
+		ConnectMinPrimaryRootKeyTypes:            c.Connect.MinPrimaryRootKeyTypes,
+		ConnectMeshGatewayWANFederationEnabled:   connectMeshGatewayWANFederationEnabled,
+		ConnectSecondaryCARetryMinBackoff:        b.durationVal("connect.secondary_ca_retry_min_backoff", c.Connect.SecondaryCARetryMinBackoff),
+		ConnectSecondaryCARetryMaxBackoff:        b.durationVal("connect.secondary_ca_retry_max_backoff", c.Connect.SecondaryCARetryMaxBackoff),
+		ConnectSecondaryCARetryBackoffMultiplier: float64Val(c.Connect.SecondaryCARetryBackoffMultiplier),
+		ConnectCAConfigureAttempts:               intVal(c.Connect.CAConfigureAttempts),
+		ConnectMinLeafCertTTL:                    b.durationVal("connect.min_leaf_cert_ttl", c.Connect.MinLeafCertTTL),
+		ConnectCAManualSecondaryInit:             boolVal(c.Connect.CAManualSecondaryInit),
+		ConnectLeafSignCacheTTL:                  b.durationVal("connect.leaf_sign_cache_ttl", c.Connect.LeafSignCacheTTL),
+		ConnectSidecarMinPort:                    sidecarMinPort,
+		ConnectSidecarMaxPort:                    sidecarMaxPort,
+		ConnectTestCALeafRootChangeSpread:        b.durationVal("connect.test_ca_leaf_root_change_spread", c.Connect.TestCALeafRootChangeSpread),
+		ExposeMinPort:                            exposeMinPort,
+		ExposeMaxPort:                            exposeMaxPort,
+		DataDir:                                  dataDir,
+		Datacenter:                               datacenter,
+		DefaultQueryTime:                         b.durationVal("default_query_time", c.DefaultQueryTime),
+		DevMode:                                  boolVal(b.opts.DevMode),
+		DisableAnonymousSignature:                boolVal(c.DisableAnonymousSignature),
+		DisableCoordinates:                       boolVal(c.DisableCoordinates),
+		DisableHostNodeID:                        boolVal(c.DisableHostNodeID),
+		DisableHTTPUnprintableCharFilter:         boolVal(c.DisableHTTPUnprintableCharFilter),
+		DisableKeyringFile:                       boolVal(c.DisableKeyringFile),
+		DisableRemoteExec:                        boolVal(c.DisableRemoteExec),
+		DisableUpdateCheck:                       boolVal(c.DisableUpdateCheck),
+		DiscardCheckOutput:                       boolVal(c.DiscardCheckOutput),
 
 		DiscoveryMaxStale:          b.durationVal("discovery_max_stale", c.DiscoveryMaxStale),
 		EnableAgentTLSForChecks:    boolVal(c.EnableAgentTLSForChecks),
@@ -1406,9 +1434,11 @@ func (b *builder) validate(rt RuntimeConfig) error {
 	// Validate the given Connect CA provider config
 	validCAProviders := map[string]bool{
 		"":                       true,
-		structs.ConsulCAProvider: true,
-		structs.VaultCAProvider:  true,
-		structs.AWSCAProvider:    true,
+		structs.ConsulCAProvider:        true,
+		structs.VaultCAProvider:         true,
+		structs.AWSCAProvider:           true,
+		structs.AzureKeyVaultCAProvider: true,
+		structs.GRPCCAProvider:          true,
 	}
 	if _, ok := validCAProviders[rt.ConnectCAProvider]; !ok {
 		return fmt.Errorf("%s is not a valid CA provider", rt.ConnectCAProvider)
@@ -1426,9 +1456,58 @@ func (b *builder) validate(rt RuntimeConfig) error {
 			if _, err := ca.ParseAWSCAConfig(rt.ConnectCAConfig); err != nil {
 				return err
 			}
+		case structs.AzureKeyVaultCAProvider:
+			if _, err := ca.ParseAzureKeyVaultCAConfig(rt.ConnectCAConfig); err != nil {
+				return err
+			}
+		case structs.GRPCCAProvider:
+			if _, err := ca.ParseGRPCCAConfig(rt.ConnectCAConfig); err != nil {
+				return err
+			}
 		}
 	}
 
+	for _, k := range rt.ConnectAllowedCAKeyTypes {
+		if _, err := structs.ParseCAKeyType(k); err != nil {
+			return fmt.Errorf("connect.allowed_ca_key_types: %v", err)
+		}
+	}
+
+	minKeyTypes := make(map[string]bool)
+	for _, k := range rt.ConnectMinPrimaryRootKeyTypes {
+		keyType, err := structs.ParseCAKeyType(k)
+		if err != nil {
+			return fmt.Errorf("connect.min_primary_root_key_types: %v", err)
+		}
+		if minKeyTypes[keyType.Type] {
+			return fmt.Errorf("connect.min_primary_root_key_types: key type %q is listed more than once", keyType.Type)
+		}
+		minKeyTypes[keyType.Type] = true
+	}
+
+	if rt.ConnectSecondaryCARetryMinBackoff < 0 {
+		return fmt.Errorf("connect.secondary_ca_retry_min_backoff must not be negative")
+	}
+	if rt.ConnectSecondaryCARetryMaxBackoff < 0 {
+		return fmt.Errorf("connect.secondary_ca_retry_max_backoff must not be negative")
+	}
+	if rt.ConnectSecondaryCARetryMinBackoff > 0 && rt.ConnectSecondaryCARetryMaxBackoff > 0 &&
+		rt.ConnectSecondaryCARetryMaxBackoff < rt.ConnectSecondaryCARetryMinBackoff {
+		return fmt.Errorf("connect.secondary_ca_retry_max_backoff must not be less than connect.secondary_ca_retry_min_backoff")
+	}
+	if rt.ConnectSecondaryCARetryBackoffMultiplier != 0 && rt.ConnectSecondaryCARetryBackoffMultiplier < 1 {
+		return fmt.Errorf("connect.secondary_ca_retry_backoff_multiplier must be at least 1")
+	}
+	if rt.ConnectCAConfigureAttempts < 0 {
+		return fmt.Errorf("connect.ca_configure_attempts must not be negative")
+	}
+	if rt.ConnectMinLeafCertTTL < 0 || rt.ConnectMinLeafCertTTL > structs.MaxLeafCertTTL {
+		return fmt.Errorf("connect.min_leaf_cert_ttl must be between 0 and %s", structs.MaxLeafCertTTL)
+	}
+	if rt.ConnectLeafSignCacheTTL < 0 {
+		return fmt.Errorf("connect.leaf_sign_cache_ttl must not be negative")
+	}
+
 	if rt.ServerMode && rt.AutoEncryptTLS {
 		return fmt.Errorf("auto_encrypt.tls can only be used on a client.")
 	}
@@ -607,8 +607,33 @@ type Connect struct {
 	Enabled                         *bool                  `mapstructure:"enabled"`
 	CAProvider                      *string                `mapstructure:"ca_provider"`
 	CAConfig                        map[string]interface{} `mapstructure:"ca_config"`
+	AllowedCAKeyTypes               []string               `mapstructure:"allowed_ca_key_types"`
+	MinPrimaryRootKeyTypes          []string               `mapstructure:"min_primary_root_key_types"`
 	MeshGatewayWANFederationEnabled *bool                  `mapstructure:"enable_mesh_gateway_wan_federation"`
 
+	// SecondaryCARetryMinBackoff, SecondaryCARetryMaxBackoff and
+	// SecondaryCARetryBackoffMultiplier tune how often a secondary datacenter
+	// retries initializing its CA against the primary.
+	SecondaryCARetryMinBackoff        *string  `mapstructure:"secondary_ca_retry_min_backoff"`
+	SecondaryCARetryMaxBackoff        *string  `mapstructure:"secondary_ca_retry_max_backoff"`
+	SecondaryCARetryBackoffMultiplier *float64 `mapstructure:"secondary_ca_retry_backoff_multiplier"`
+
+	// CAConfigureAttempts is how many times a CA configuration update tries
+	// to configure the new provider before rejecting the configuration.
+	CAConfigureAttempts *int `mapstructure:"ca_configure_attempts"`
+
+	// MinLeafCertTTL is the shortest leaf cert lifetime this datacenter
+	// signs when a shorter TTL is requested.
+	MinLeafCertTTL *string `mapstructure:"min_leaf_cert_ttl"`
+
+	// CAManualSecondaryInit leaves a secondary datacenter's CA uninitialized
+	// until it is initialized through the API.
+	CAManualSecondaryInit *bool `mapstructure:"ca_manual_secondary_init"`
+
+	// LeafSignCacheTTL is how long a signed leaf cert is reused for identical
+	// CSRs.
+	LeafSignCacheTTL *string `mapstructure:"leaf_sign_cache_ttl"`
+
 	// TestCALeafRootChangeSpread controls how long after a CA roots change before new leaft certs will be generated.
 	// This is only tuned in tests, generally set to 1ns to make tests deterministic with when to expect updated leaf
 	// certs by. This configuration is not exposed to users (not documented, and agent/config/default.go will override it)
@@ -510,6 +510,53 @@ type RuntimeConfig struct {
 	// ConnectCAConfig is the config to use for the CA provider.
 	ConnectCAConfig map[string]interface{}
 
+	// ConnectAllowedCAKeyTypes restricts the private key types and lengths,
+	// written as "<type>:<bits>", that a CA configuration may use. An empty
+	// list allows all key types.
+	ConnectAllowedCAKeyTypes []string
+
+	// ConnectMinPrimaryRootKeyTypes lists the weakest key, written as
+	// "<type>:<bits>", that a secondary datacenter accepts for each key type
+	// of the primary datacenter's root. Roots with a key type that isn't
+	// listed are rejected. An empty list accepts any root.
+	ConnectMinPrimaryRootKeyTypes []string
+
+	// ConnectSecondaryCARetryMinBackoff is the time a secondary datacenter
+	// waits after its first failed attempt to initialize its CA or replicate
+	// roots from the primary. Zero uses the server default.
+	ConnectSecondaryCARetryMinBackoff time.Duration
+
+	// ConnectSecondaryCARetryMaxBackoff caps the time a secondary datacenter
+	// waits between failed CA initialization attempts. Zero uses the server
+	// default.
+	ConnectSecondaryCARetryMaxBackoff time.Duration
+
+	// ConnectSecondaryCARetryBackoffMultiplier is the factor the wait grows by
+	// after each further failed CA initialization attempt in a secondary
+	// datacenter. Zero uses the server default.
+	ConnectSecondaryCARetryBackoffMultiplier float64
+
+	// ConnectCAConfigureAttempts is how many times a CA configuration update
+	// tries to configure the new provider, backing off in between, before
+	// rejecting the configuration. Zero uses the server default of a single
+	// attempt.
+	ConnectCAConfigureAttempts int
+
+	// ConnectMinLeafCertTTL is the shortest leaf cert lifetime this
+	// datacenter's servers sign when a client requests a shorter TTL. Zero
+	// uses the built-in minimum of one hour.
+	ConnectMinLeafCertTTL time.Duration
+
+	// ConnectCAManualSecondaryInit leaves a secondary datacenter's CA
+	// uninitialized until an operator calls ConnectCA.InitializeSecondary,
+	// instead of pulling the primary's roots automatically.
+	ConnectCAManualSecondaryInit bool
+
+	// ConnectLeafSignCacheTTL is how long the leader hands out the same leaf
+	// cert for identical CSRs instead of signing them again. Zero disables
+	// the cache.
+	ConnectLeafSignCacheTTL time.Duration
+
 	// ConnectMeshGatewayWANFederationEnabled determines if wan federation of
 	// datacenters should exclusively traverse mesh gateways.
 	ConnectMeshGatewayWANFederationEnabled bool
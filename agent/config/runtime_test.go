@@ -3256,6 +3256,298 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 			}
 		},
 	})
+	run(t, testCase{
+		desc: "Connect Azure Key Vault CA provider configuration",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+				"connect": {
+					"enabled": true,
+					"ca_provider": "azure-keyvault",
+					"ca_config": {
+						"vault_url": "https://example.vault.azure.net",
+						"hsm": true,
+						"delete_on_exit": true
+					}
+				}
+			}`},
+		hcl: []string{`
+			  connect {
+					enabled = true
+					ca_provider = "azure-keyvault"
+					ca_config {
+						vault_url = "https://example.vault.azure.net"
+						hsm = true
+						delete_on_exit = true
+					}
+				}
+			`},
+		expected: func(rt *RuntimeConfig) {
+			rt.DataDir = dataDir
+			rt.ConnectEnabled = true
+			rt.ConnectCAProvider = "azure-keyvault"
+			rt.ConnectCAConfig = map[string]interface{}{
+				"VaultURL":     "https://example.vault.azure.net",
+				"HSM":          true,
+				"DeleteOnExit": true,
+			}
+		},
+	})
+	run(t, testCase{
+		desc: "Connect CA NotBefore backdate configuration",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+				"connect": {
+					"enabled": true,
+					"ca_provider": "consul",
+					"ca_config": {
+						"leaf_not_before_backdate": "0s",
+						"intermediate_not_before_backdate": "10m"
+					}
+				}
+			}`},
+		hcl: []string{`
+			  connect {
+					enabled = true
+					ca_provider = "consul"
+					ca_config {
+						leaf_not_before_backdate = "0s"
+						intermediate_not_before_backdate = "10m"
+					}
+				}
+			`},
+		expected: func(rt *RuntimeConfig) {
+			rt.DataDir = dataDir
+			rt.ConnectEnabled = true
+			rt.ConnectCAProvider = "consul"
+			rt.ConnectCAConfig = map[string]interface{}{
+				"LeafNotBeforeBackdate":         "0s",
+				"IntermediateNotBeforeBackdate": "10m",
+			}
+		},
+	})
+	run(t, testCase{
+		desc: "Connect Azure Key Vault CA provider key type validation",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+				"connect": {
+					"enabled": true,
+					"ca_provider": "azure-keyvault",
+					"ca_config": {
+						"vault_url": "https://example.vault.azure.net",
+						"private_key_type": "ec",
+						"private_key_bits": 384
+					}
+				}
+			}`},
+		hcl: []string{`
+			  connect {
+					enabled = true
+					ca_provider = "azure-keyvault"
+					ca_config {
+						vault_url = "https://example.vault.azure.net"
+						private_key_type = "ec"
+						private_key_bits = 384
+					}
+				}
+			`},
+		expectedErr: "Azure Key Vault CA provider only supports P256 EC curve, or RSA 2048/4096",
+	})
+	run(t, testCase{
+		desc: "Connect gRPC CA provider config",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+				"connect": {
+					"enabled": true,
+					"ca_provider": "grpc",
+					"ca_config": {
+						"address": "unix:///var/run/consul-ca.sock",
+						"ca_file": "/etc/consul-ca/ca.pem",
+						"cert_file": "/etc/consul-ca/client.pem",
+						"key_file": "/etc/consul-ca/client-key.pem",
+						"tls_server_name": "ca.example.com"
+					}
+				}
+			}`},
+		hcl: []string{`
+			  connect {
+					enabled = true
+					ca_provider = "grpc"
+					ca_config {
+						address = "unix:///var/run/consul-ca.sock"
+						ca_file = "/etc/consul-ca/ca.pem"
+						cert_file = "/etc/consul-ca/client.pem"
+						key_file = "/etc/consul-ca/client-key.pem"
+						tls_server_name = "ca.example.com"
+					}
+				}
+			`},
+		expected: func(rt *RuntimeConfig) {
+			rt.DataDir = dataDir
+			rt.ConnectEnabled = true
+			rt.ConnectCAProvider = "grpc"
+			rt.ConnectCAConfig = map[string]interface{}{
+				"Address":       "unix:///var/run/consul-ca.sock",
+				"CAFile":        "/etc/consul-ca/ca.pem",
+				"CertFile":      "/etc/consul-ca/client.pem",
+				"KeyFile":       "/etc/consul-ca/client-key.pem",
+				"TLSServerName": "ca.example.com",
+			}
+		},
+	})
+	run(t, testCase{
+		desc: "Connect gRPC CA provider requires address",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+				"connect": {
+					"enabled": true,
+					"ca_provider": "grpc",
+					"ca_config": {
+						"ca_file": "/etc/consul-ca/ca.pem"
+					}
+				}
+			}`},
+		hcl: []string{`
+			  connect {
+					enabled = true
+					ca_provider = "grpc"
+					ca_config {
+						ca_file = "/etc/consul-ca/ca.pem"
+					}
+				}
+			`},
+		expectedErr: "must provide the Address of the external CA provider",
+	})
+	run(t, testCase{
+		desc: "Connect Vault CA provider auth method config",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+				"connect": {
+					"enabled": true,
+					"ca_provider": "vault",
+					"ca_config": {
+						"address": "http://127.0.0.1:8200",
+						"root_pki_path": "pki-root/",
+						"intermediate_pki_path": "pki-intermediate/",
+						"auth_method": {
+							"type": "kubernetes",
+							"mount_path": "kube",
+							"params": {
+								"role": "consul-ca",
+								"jwt_path": "/var/run/secrets/token"
+							}
+						}
+					}
+				}
+			}`},
+		hcl: []string{`
+			  connect {
+					enabled = true
+					ca_provider = "vault"
+					ca_config {
+						address = "http://127.0.0.1:8200"
+						root_pki_path = "pki-root/"
+						intermediate_pki_path = "pki-intermediate/"
+						auth_method {
+							type = "kubernetes"
+							mount_path = "kube"
+							params {
+								role = "consul-ca"
+								jwt_path = "/var/run/secrets/token"
+							}
+						}
+					}
+				}
+			`},
+		expected: func(rt *RuntimeConfig) {
+			rt.DataDir = dataDir
+			rt.ConnectEnabled = true
+			rt.ConnectCAProvider = "vault"
+			rt.ConnectCAConfig = map[string]interface{}{
+				"Address":             "http://127.0.0.1:8200",
+				"RootPKIPath":         "pki-root/",
+				"IntermediatePKIPath": "pki-intermediate/",
+				"AuthMethod": map[string]interface{}{
+					"type":      "kubernetes",
+					"MountPath": "kube",
+					"params": map[string]interface{}{
+						"role":     "consul-ca",
+						"jwt_path": "/var/run/secrets/token",
+					},
+				},
+			}
+		},
+	})
+	run(t, testCase{
+		desc: "Connect Vault CA provider leaf role config",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+				"connect": {
+					"enabled": true,
+					"ca_provider": "vault",
+					"ca_config": {
+						"address": "http://127.0.0.1:8200",
+						"token": "abc",
+						"root_pki_path": "pki-root/",
+						"intermediate_pki_path": "pki-intermediate/",
+						"leaf_role": {
+							"allowed_domains": ["consul", "example.com"],
+							"allow_subdomains": true,
+							"allow_any_name": false,
+							"max_ttl": "96h"
+						}
+					}
+				}
+			}`},
+		hcl: []string{`
+			  connect {
+					enabled = true
+					ca_provider = "vault"
+					ca_config {
+						address = "http://127.0.0.1:8200"
+						token = "abc"
+						root_pki_path = "pki-root/"
+						intermediate_pki_path = "pki-intermediate/"
+						leaf_role {
+							allowed_domains = ["consul", "example.com"]
+							allow_subdomains = true
+							allow_any_name = false
+							max_ttl = "96h"
+						}
+					}
+				}
+			`},
+		expected: func(rt *RuntimeConfig) {
+			rt.DataDir = dataDir
+			rt.ConnectEnabled = true
+			rt.ConnectCAProvider = "vault"
+			rt.ConnectCAConfig = map[string]interface{}{
+				"Address":             "http://127.0.0.1:8200",
+				"Token":               "abc",
+				"RootPKIPath":         "pki-root/",
+				"IntermediatePKIPath": "pki-intermediate/",
+				"LeafRole": map[string]interface{}{
+					"AllowedDomains":  []interface{}{"consul", "example.com"},
+					"AllowSubdomains": true,
+					"AllowAnyName":    false,
+					"MaxTTL":          "96h",
+				},
+			}
+		},
+	})
 	run(t, testCase{
 		desc: "Connect AWS CA provider TTL validation",
 		args: []string{
@@ -3281,6 +3573,141 @@ func TestLoad_IntegrationWithFlags(t *testing.T) {
 			`},
 		expectedErr: "AWS PCA doesn't support certificates that are valid for less than 24 hours",
 	})
+	run(t, testCase{
+		desc: "Connect allowed CA key types validation",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+				"connect": {
+					"enabled": true,
+					"allowed_ca_key_types": ["rsa:1024"]
+				}
+			}`},
+		hcl: []string{`
+			  connect {
+					enabled = true
+					allowed_ca_key_types = ["rsa:1024"]
+				}
+			`},
+		expectedErr: `connect.allowed_ca_key_types: invalid CA key type "rsa:1024": RSA key length must be 2048 or 4096 bits`,
+	})
+	run(t, testCase{
+		desc: "Connect min primary root key types validation",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+				"connect": {
+					"enabled": true,
+					"min_primary_root_key_types": ["rsa:2048", "rsa:4096"]
+				}
+			}`},
+		hcl: []string{`
+			  connect {
+					enabled = true
+					min_primary_root_key_types = ["rsa:2048", "rsa:4096"]
+				}
+			`},
+		expectedErr: `connect.min_primary_root_key_types: key type "rsa" is listed more than once`,
+	})
+	run(t, testCase{
+		desc: "Connect secondary CA retry backoff validation",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+				"connect": {
+					"enabled": true,
+					"secondary_ca_retry_min_backoff": "1m",
+					"secondary_ca_retry_max_backoff": "10s"
+				}
+			}`},
+		hcl: []string{`
+			  connect {
+					enabled = true
+					secondary_ca_retry_min_backoff = "1m"
+					secondary_ca_retry_max_backoff = "10s"
+				}
+			`},
+		expectedErr: "connect.secondary_ca_retry_max_backoff must not be less than connect.secondary_ca_retry_min_backoff",
+	})
+	run(t, testCase{
+		desc: "Connect secondary CA retry backoff multiplier validation",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+				"connect": {
+					"enabled": true,
+					"secondary_ca_retry_backoff_multiplier": 0.5
+				}
+			}`},
+		hcl: []string{`
+			  connect {
+					enabled = true
+					secondary_ca_retry_backoff_multiplier = 0.5
+				}
+			`},
+		expectedErr: "connect.secondary_ca_retry_backoff_multiplier must be at least 1",
+	})
+	run(t, testCase{
+		desc: "Connect CA configure attempts validation",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+				"connect": {
+					"enabled": true,
+					"ca_configure_attempts": -1
+				}
+			}`},
+		hcl: []string{`
+			  connect {
+					enabled = true
+					ca_configure_attempts = -1
+				}
+			`},
+		expectedErr: "connect.ca_configure_attempts must not be negative",
+	})
+	run(t, testCase{
+		desc: "Connect min leaf cert TTL validation",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+				"connect": {
+					"enabled": true,
+					"min_leaf_cert_ttl": "-1m"
+				}
+			}`},
+		hcl: []string{`
+			  connect {
+					enabled = true
+					min_leaf_cert_ttl = "-1m"
+				}
+			`},
+		expectedErr: "connect.min_leaf_cert_ttl must be between 0 and 8760h0m0s",
+	})
+	run(t, testCase{
+		desc: "Connect leaf sign cache TTL validation",
+		args: []string{
+			`-data-dir=` + dataDir,
+		},
+		json: []string{`{
+				"connect": {
+					"enabled": true,
+					"leaf_sign_cache_ttl": "-1s"
+				}
+			}`},
+		hcl: []string{`
+			  connect {
+					enabled = true
+					leaf_sign_cache_ttl = "-1s"
+				}
+			`},
+		expectedErr: "connect.leaf_sign_cache_ttl must not be negative",
+	})
 	run(t, testCase{
 		desc: "Connect AWS CA provider EC key length validation",
 		args: []string{
@@ -5535,64 +5962,73 @@ func TestLoad_FullConfig(t *testing.T) {
 			"CSRMaxPerSecond":     float64(100),
 			"CSRMaxConcurrent":    float64(2),
 		},
-		ConnectMeshGatewayWANFederationEnabled: false,
-		DNSAddrs:                               []net.Addr{tcpAddr("93.95.95.81:7001"), udpAddr("93.95.95.81:7001")},
-		DNSARecordLimit:                        29907,
-		DNSAllowStale:                          true,
-		DNSDisableCompression:                  true,
-		DNSDomain:                              "7W1xXSqd",
-		DNSAltDomain:                           "1789hsd",
-		DNSEnableTruncate:                      true,
-		DNSMaxStale:                            29685 * time.Second,
-		DNSNodeTTL:                             7084 * time.Second,
-		DNSOnlyPassing:                         true,
-		DNSPort:                                7001,
-		DNSRecursorStrategy:                    "sequential",
-		DNSRecursorTimeout:                     4427 * time.Second,
-		DNSRecursors:                           []string{"63.38.39.58", "92.49.18.18"},
-		DNSSOA:                                 RuntimeSOAConfig{Refresh: 3600, Retry: 600, Expire: 86400, Minttl: 0},
-		DNSServiceTTL:                          map[string]time.Duration{"*": 32030 * time.Second},
-		DNSUDPAnswerLimit:                      29909,
-		DNSNodeMetaTXT:                         true,
-		DNSUseCache:                            true,
-		DNSCacheMaxAge:                         5 * time.Minute,
-		DataDir:                                dataDir,
-		Datacenter:                             "rzo029wg",
-		DefaultQueryTime:                       16743 * time.Second,
-		DisableAnonymousSignature:              true,
-		DisableCoordinates:                     true,
-		DisableHostNodeID:                      true,
-		DisableHTTPUnprintableCharFilter:       true,
-		DisableKeyringFile:                     true,
-		DisableRemoteExec:                      true,
-		DisableUpdateCheck:                     true,
-		DiscardCheckOutput:                     true,
-		DiscoveryMaxStale:                      5 * time.Second,
-		EnableAgentTLSForChecks:                true,
-		EnableCentralServiceConfig:             false,
-		EnableDebug:                            true,
-		EnableRemoteScriptChecks:               true,
-		EnableLocalScriptChecks:                true,
-		EncryptKey:                             "A4wELWqH",
-		EncryptVerifyIncoming:                  true,
-		EncryptVerifyOutgoing:                  true,
-		GRPCPort:                               4881,
-		GRPCAddrs:                              []net.Addr{tcpAddr("32.31.61.91:4881")},
-		HTTPAddrs:                              []net.Addr{tcpAddr("83.39.91.39:7999")},
-		HTTPBlockEndpoints:                     []string{"RBvAFcGD", "fWOWFznh"},
-		AllowWriteHTTPFrom:                     []*net.IPNet{cidr("127.0.0.0/8"), cidr("22.33.44.55/32"), cidr("0.0.0.0/0")},
-		HTTPPort:                               7999,
-		HTTPResponseHeaders:                    map[string]string{"M6TKa9NP": "xjuxjOzQ", "JRCrHZed": "rl0mTx81"},
-		HTTPSAddrs:                             []net.Addr{tcpAddr("95.17.17.19:15127")},
-		HTTPMaxConnsPerClient:                  100,
-		HTTPMaxHeaderBytes:                     10,
-		HTTPSHandshakeTimeout:                  2391 * time.Millisecond,
-		HTTPSPort:                              15127,
-		HTTPUseCache:                           false,
-		KeyFile:                                "IEkkwgIA",
-		KVMaxValueSize:                         1234567800,
-		LeaveDrainTime:                         8265 * time.Second,
-		LeaveOnTerm:                            true,
+		ConnectAllowedCAKeyTypes:                 []string{"ec:256", "rsa:4096"},
+		ConnectMeshGatewayWANFederationEnabled:   false,
+		ConnectMinPrimaryRootKeyTypes:            []string{"ec:256", "rsa:2048"},
+		ConnectSecondaryCARetryMinBackoff:        3 * time.Second,
+		ConnectSecondaryCARetryMaxBackoff:        5 * time.Minute,
+		ConnectSecondaryCARetryBackoffMultiplier: 1.5,
+		ConnectCAConfigureAttempts:               3,
+		ConnectMinLeafCertTTL:                    10 * time.Minute,
+		ConnectCAManualSecondaryInit:             true,
+		ConnectLeafSignCacheTTL:                  5 * time.Second,
+		DNSAddrs:                                 []net.Addr{tcpAddr("93.95.95.81:7001"), udpAddr("93.95.95.81:7001")},
+		DNSARecordLimit:                          29907,
+		DNSAllowStale:                            true,
+		DNSDisableCompression:                    true,
+		DNSDomain:                                "7W1xXSqd",
+		DNSAltDomain:                             "1789hsd",
+		DNSEnableTruncate:                        true,
+		DNSMaxStale:                              29685 * time.Second,
+		DNSNodeTTL:                               7084 * time.Second,
+		DNSOnlyPassing:                           true,
+		DNSPort:                                  7001,
+		DNSRecursorStrategy:                      "sequential",
+		DNSRecursorTimeout:                       4427 * time.Second,
+		DNSRecursors:                             []string{"63.38.39.58", "92.49.18.18"},
+		DNSSOA:                                   RuntimeSOAConfig{Refresh: 3600, Retry: 600, Expire: 86400, Minttl: 0},
+		DNSServiceTTL:                            map[string]time.Duration{"*": 32030 * time.Second},
+		DNSUDPAnswerLimit:                        29909,
+		DNSNodeMetaTXT:                           true,
+		DNSUseCache:                              true,
+		DNSCacheMaxAge:                           5 * time.Minute,
+		DataDir:                                  dataDir,
+		Datacenter:                               "rzo029wg",
+		DefaultQueryTime:                         16743 * time.Second,
+		DisableAnonymousSignature:                true,
+		DisableCoordinates:                       true,
+		DisableHostNodeID:                        true,
+		DisableHTTPUnprintableCharFilter:         true,
+		DisableKeyringFile:                       true,
+		DisableRemoteExec:                        true,
+		DisableUpdateCheck:                       true,
+		DiscardCheckOutput:                       true,
+		DiscoveryMaxStale:                        5 * time.Second,
+		EnableAgentTLSForChecks:                  true,
+		EnableCentralServiceConfig:               false,
+		EnableDebug:                              true,
+		EnableRemoteScriptChecks:                 true,
+		EnableLocalScriptChecks:                  true,
+		EncryptKey:                               "A4wELWqH",
+		EncryptVerifyIncoming:                    true,
+		EncryptVerifyOutgoing:                    true,
+		GRPCPort:                                 4881,
+		GRPCAddrs:                                []net.Addr{tcpAddr("32.31.61.91:4881")},
+		HTTPAddrs:                                []net.Addr{tcpAddr("83.39.91.39:7999")},
+		HTTPBlockEndpoints:                       []string{"RBvAFcGD", "fWOWFznh"},
+		AllowWriteHTTPFrom:                       []*net.IPNet{cidr("127.0.0.0/8"), cidr("22.33.44.55/32"), cidr("0.0.0.0/0")},
+		HTTPPort:                                 7999,
+		HTTPResponseHeaders:                      map[string]string{"M6TKa9NP": "xjuxjOzQ", "JRCrHZed": "rl0mTx81"},
+		HTTPSAddrs:                               []net.Addr{tcpAddr("95.17.17.19:15127")},
+		HTTPMaxConnsPerClient:                    100,
+		HTTPMaxHeaderBytes:                       10,
+		HTTPSHandshakeTimeout:                    2391 * time.Millisecond,
+		HTTPSPort:                                15127,
+		HTTPUseCache:                             false,
+		KeyFile:                                  "IEkkwgIA",
+		KVMaxValueSize:                           1234567800,
+		LeaveDrainTime:                           8265 * time.Second,
+		LeaveOnTerm:                              true,
 		Logging: logging.Config{
 			LogLevel:       "k1zo9Spt",
 			LogJSON:        true,
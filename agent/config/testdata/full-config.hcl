@@ -206,6 +206,15 @@ connect {
         csr_max_per_second = 100.0
         csr_max_concurrent = 2.0
     }
+    allowed_ca_key_types = ["ec:256", "rsa:4096"]
+    min_primary_root_key_types = ["ec:256", "rsa:2048"]
+    secondary_ca_retry_min_backoff = "3s"
+    secondary_ca_retry_max_backoff = "5m"
+    secondary_ca_retry_backoff_multiplier = 1.5
+    ca_configure_attempts = 3
+    min_leaf_cert_ttl = "10m"
+    ca_manual_secondary_init = true
+    leaf_sign_cache_ttl = "5s"
     enable_mesh_gateway_wan_federation = false
     enabled = true
 }
@@ -2,9 +2,13 @@ package ca
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/consul/agent/connect"
 )
@@ -26,19 +30,9 @@ func validateSetIntermediate(
 		if err != nil {
 			return err
 		}
-
-		// Compare the two keys to make sure they match.
-		b1, err := x509.MarshalPKIXPublicKey(intermediate.PublicKey)
-		if err != nil {
-			return err
-		}
-		b2, err := x509.MarshalPKIXPublicKey(privKey.Public())
-		if err != nil {
+		if err := validateIntermediateKey(intermediate, privKey.Public()); err != nil {
 			return err
 		}
-		if !bytes.Equal(b1, b2) {
-			return fmt.Errorf("intermediate cert is for a different private key")
-		}
 	}
 
 	// Validate the remaining fields and make sure the intermediate validates against
@@ -65,6 +59,23 @@ func validateSetIntermediate(
 	return nil
 }
 
+// validateIntermediateKey makes sure the intermediate cert is for the given
+// public key.
+func validateIntermediateKey(intermediate *x509.Certificate, pub crypto.PublicKey) error {
+	b1, err := x509.MarshalPKIXPublicKey(intermediate.PublicKey)
+	if err != nil {
+		return err
+	}
+	b2, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(b1, b2) {
+		return fmt.Errorf("intermediate cert is for a different private key")
+	}
+	return nil
+}
+
 func validateSignIntermediate(csr *x509.CertificateRequest, spiffeID *connect.SpiffeIDSigning) error {
 	// We explicitly _don't_ require that the CSR has a valid SPIFFE signing URI
 	// SAN because AWS PCA doesn't let us set one :(. We need to relax it here
@@ -102,3 +113,65 @@ func EnsureTrailingNewline(cert string) string {
 	}
 	return fmt.Sprintf("%s\n", cert)
 }
+
+// certExpiryCache remembers the validity period of the last cert it parsed so
+// that repeated lookups for an unchanged PEM don't parse it again.
+type certExpiryCache struct {
+	lock      sync.Mutex
+	pem       string
+	notBefore time.Time
+	notAfter  time.Time
+}
+
+// Validity returns the NotBefore and NotAfter times of the first cert in pem,
+// parsing it only if pem differs from the one seen by the previous call.
+func (c *certExpiryCache) Validity(pem string) (notBefore, notAfter time.Time, err error) {
+	if pem == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("no active intermediate")
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if pem == c.pem {
+		return c.notBefore, c.notAfter, nil
+	}
+
+	cert, err := connect.ParseCert(pem)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("error parsing active intermediate cert: %v", err)
+	}
+	c.pem, c.notBefore, c.notAfter = pem, cert.NotBefore, cert.NotAfter
+	return c.notBefore, c.notAfter, nil
+}
+
+// intermediateSubject holds the subject fields configured for intermediate
+// certs with ProviderConfig.IntermediateCertCommonName and
+// ProviderConfig.IntermediateCertOrganization.
+type intermediateSubject struct {
+	commonName   string
+	organization string
+}
+
+func newIntermediateSubject(cfg ProviderConfig) intermediateSubject {
+	return intermediateSubject{
+		commonName:   cfg.IntermediateCertCommonName,
+		organization: cfg.IntermediateCertOrganization,
+	}
+}
+
+// isSet returns true if any subject field is configured.
+func (s intermediateSubject) isSet() bool {
+	return s.commonName != "" || s.organization != ""
+}
+
+// apply returns subject with the configured fields replaced. Fields that
+// aren't configured are left as they are.
+func (s intermediateSubject) apply(subject pkix.Name) pkix.Name {
+	if s.commonName != "" {
+		subject.CommonName = s.commonName
+	}
+	if s.organization != "" {
+		subject.Organization = []string{s.organization}
+	}
+	return subject
+}
@@ -238,24 +238,3 @@ func (_m *MockProvider) State() (map[string]string, error) {
 
 	return r0, r1
 }
-
-// SupportsCrossSigning provides a mock function with given fields:
-func (_m *MockProvider) SupportsCrossSigning() (bool, error) {
-	ret := _m.Called()
-
-	var r0 bool
-	if rf, ok := ret.Get(0).(func() bool); ok {
-		r0 = rf()
-	} else {
-		r0 = ret.Get(0).(bool)
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
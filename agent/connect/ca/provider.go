@@ -3,6 +3,9 @@ package ca
 import (
 	"crypto/x509"
 	"errors"
+	"time"
+
+	"github.com/hashicorp/consul/agent/structs"
 )
 
 //go:generate mockery -name Provider -inpkg
@@ -14,6 +17,49 @@ import (
 // on servers and CA provider.
 var ErrRateLimited = errors.New("operation rate limited by CA provider")
 
+// Providers wrap failures in one of these sentinels with WrapProviderError so
+// that Consul can tell with errors.Is whether retrying is worthwhile. The
+// message of the wrapped error is left unchanged.
+var (
+	// ErrProviderUnreachable means the provider's backend could not be reached
+	// or failed while handling the request. Retrying may succeed.
+	ErrProviderUnreachable = errors.New("CA provider backend is unreachable")
+
+	// ErrProviderMisconfigured means the provider's configuration is invalid
+	// or its credentials were rejected. Retrying won't succeed until the CA
+	// configuration changes.
+	ErrProviderMisconfigured = errors.New("CA provider is misconfigured")
+
+	// ErrSigningDenied means the backend refused to sign a certificate.
+	ErrSigningDenied = errors.New("CA provider denied the signing request")
+)
+
+// WrapProviderError returns err classified as kind, which should be one of
+// the provider error sentinels above. It returns nil if err is nil.
+func WrapProviderError(kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &providerError{kind: kind, err: err}
+}
+
+type providerError struct {
+	kind error
+	err  error
+}
+
+func (e *providerError) Error() string {
+	return e.err.Error()
+}
+
+func (e *providerError) Unwrap() error {
+	return e.err
+}
+
+func (e *providerError) Is(target error) bool {
+	return target == e.kind
+}
+
 // PrimaryUsesIntermediate is an optional interface  that CA providers may implement
 // to indicate that they use an intermediate cert in the primary datacenter as
 // well as the secondary. This is used when determining whether to run the
@@ -22,6 +68,54 @@ type PrimaryUsesIntermediate interface {
 	PrimaryUsesIntermediate()
 }
 
+// ProviderCapabilities describes the optional features a CA provider
+// supports, so callers can make decisions about a provider without knowing its
+// type.
+type ProviderCapabilities struct {
+	// CrossSigning is true if CrossSignCA can cross-sign a root from another
+	// provider, so the root can be rotated without disrupting connectivity.
+	CrossSigning bool
+
+	// ExternalRoot is true if the root CA and its private key are held outside
+	// of Consul. Roots of these providers can't be rotated by handing the
+	// provider a new key, only by changing the provider's configuration.
+	ExternalRoot bool
+
+	// KeyTypes lists the PrivateKeyType values the provider can generate keys
+	// for. It is empty if the provider can't tell.
+	KeyTypes []string
+}
+
+// SupportsKeyType returns true if the provider can generate keys of the given
+// type, or if it doesn't report which key types it supports.
+func (c ProviderCapabilities) SupportsKeyType(keyType string) bool {
+	if len(c.KeyTypes) == 0 {
+		return true
+	}
+	for _, t := range c.KeyTypes {
+		if t == keyType {
+			return true
+		}
+	}
+	return false
+}
+
+// CapabilityReporter is an optional interface for providers that describe
+// the optional features they support. Capabilities is only called after a
+// successful Configure and may depend on the configuration. Providers that
+// don't implement it support none of these features.
+type CapabilityReporter interface {
+	Capabilities() ProviderCapabilities
+}
+
+// Capabilities returns the optional features p supports.
+func Capabilities(p Provider) ProviderCapabilities {
+	if r, ok := p.(CapabilityReporter); ok {
+		return r.Capabilities()
+	}
+	return ProviderCapabilities{}
+}
+
 // ProviderConfig encapsulates all the data Consul passes to `Configure` on a
 // new provider instance. The provider must treat this as read-only and make
 // copies of any map or slice if it might modify them internally.
@@ -50,6 +144,27 @@ type ProviderConfig struct {
 	// of state like UUIDs of external resources that the provider has created and
 	// needs to continue to manage.
 	State map[string]string
+
+	// OCSPResponderURL is the OCSP server providers should embed in the
+	// leaf certs they sign, if any. Providers that can't embed one must fail
+	// to configure when it is set rather than silently ignoring it.
+	OCSPResponderURL string
+
+	// IntermediateCertCommonName and IntermediateCertOrganization override the
+	// subject of the intermediates the provider generates or signs when set.
+	// Providers that can't set them must fail to configure rather than
+	// silently ignoring them.
+	IntermediateCertCommonName   string
+	IntermediateCertOrganization string
+
+	// SignatureAlgorithm is the algorithm providers should sign leaf certs
+	// with when their key is RSA, see structs.CAConfiguration. Providers that
+	// can't choose the algorithm must fail to configure when it is set.
+	SignatureAlgorithm string
+
+	// MinLeafCertTTL is the shortest lifetime providers should issue leaf
+	// certs with from SignWithTTL. Zero uses structs.MinLeafCertTTL.
+	MinLeafCertTTL time.Duration
 }
 
 // Provider is the interface for Consul to interact with
@@ -155,18 +270,11 @@ type PrimaryProvider interface {
 	// returned as a PEM formatted string.
 	//
 	// If the CA provider does not support this operation, it may return an error
-	// provided `SupportsCrossSigning` also returns false. Note that
+	// provided its Capabilities don't report CrossSigning. Note that
 	// providers should return ErrRateLimited if they are unable to complete the
 	// operation due to upstream rate limiting so that clients can intelligently
 	// backoff.
 	CrossSignCA(*x509.Certificate) (string, error)
-
-	// SupportsCrossSigning should indicate whether the CA provider supports
-	// cross-signing an external root to provide a seamless rotation. If the CA
-	// does not support this, the user will have to force an upgrade when that CA
-	// provider is the current CA as the upgrade may cause interruptions to
-	// connectivity during the rollout.
-	SupportsCrossSigning() (bool, error)
 }
 
 type SecondaryProvider interface {
@@ -181,6 +289,46 @@ type SecondaryProvider interface {
 	SetIntermediate(intermediatePEM, rootPEM string) error
 }
 
+// HealthChecker is an optional interface for providers that can check that
+// they are able to reach their backend and sign certificates. HealthCheck
+// returns an error if they can't. Providers that don't implement it are
+// assumed to be healthy.
+type HealthChecker interface {
+	HealthCheck() error
+}
+
+// IntermediateExpirer is an optional interface for providers that track the
+// NotBefore and NotAfter times of the cert returned by ActiveIntermediate, so
+// it is cheap to call repeatedly. When it isn't implemented or returns an
+// error, callers parse ActiveIntermediate instead.
+type IntermediateExpirer interface {
+	IntermediateExpiry() (notBefore, notAfter time.Time, err error)
+}
+
+// SignerWithTTL is an optional interface for providers that can issue leaf
+// certificates with a lifetime shorter than their configured LeafCertTTL.
+// Implementations must clamp ttl with structs.ClampLeafCertTTLWithMin using
+// ProviderConfig.MinLeafCertTTL.
+type SignerWithTTL interface {
+	SignWithTTL(csr *x509.CertificateRequest, ttl time.Duration) (string, error)
+}
+
+// CRLSigner is an optional interface for providers that can produce a
+// certificate revocation list signed by their active signing certificate.
+// number is the CRL number, which must increase whenever the list changes.
+type CRLSigner interface {
+	GenerateCRL(revoked []*structs.CARevokedCert, number uint64) ([]byte, error)
+}
+
+// RootKeyExporter is an optional interface for providers that hold the
+// private key of their root in Consul. It allows the key to be kept in the
+// built-in provider's state after the root is rotated out, under
+// RetainedRootKeyStateID, so that the root can be made active again.
+// RootPrivateKey returns an empty string if the provider doesn't hold the key.
+type RootKeyExporter interface {
+	RootPrivateKey() (string, error)
+}
+
 // NeedsStop is an optional interface that allows a CA to define a function
 // to be called when the CA instance is no longer in use. This is different
 // from Cleanup(), as only the local provider instance is being shut down
@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"sync/atomic"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/acmpca"
 
@@ -83,7 +85,22 @@ func NewAWSProvider(logger hclog.Logger) *AWSProvider {
 func (a *AWSProvider) Configure(cfg ProviderConfig) error {
 	config, err := ParseAWSCAConfig(cfg.RawConfig)
 	if err != nil {
-		return err
+		return WrapProviderError(ErrProviderMisconfigured, err)
+	}
+
+	// ACM PCA builds the extensions of issued certs from its own templates,
+	// so there's no way to add an OCSP server to them.
+	if cfg.OCSPResponderURL != "" {
+		return WrapProviderError(ErrProviderMisconfigured,
+			fmt.Errorf("the AWS CA provider does not support an OCSP responder URL"))
+	}
+	if newIntermediateSubject(cfg).isSet() {
+		return WrapProviderError(ErrProviderMisconfigured,
+			fmt.Errorf("the AWS CA provider does not support overriding the intermediate cert subject"))
+	}
+	if cfg.SignatureAlgorithm != "" {
+		return WrapProviderError(ErrProviderMisconfigured,
+			fmt.Errorf("the AWS CA provider does not support choosing the signature algorithm"))
 	}
 
 	// We only support setting IAM credentials through the normal methods ENV,
@@ -160,7 +177,7 @@ func (a *AWSProvider) ensureCA() error {
 		}
 		output, err := a.client.DescribeCertificateAuthority(input)
 		if err != nil {
-			return err
+			return awsError(err, ErrProviderMisconfigured)
 		}
 		// Allow it to be active or pending a certificate (leadership might have
 		// changed during a secondary initialization for example).
@@ -175,8 +192,8 @@ func (a *AWSProvider) ensureCA() error {
 			// "recreate" config option to allow rotating without a manual creation
 			// but this is simpler and less surprising default behavior if user
 			// disabled a CA due to a security concern and we just work around it.
-			return fmt.Errorf("the %s PCA is not active: status is %s", verb,
-				*output.CertificateAuthority.Status)
+			return WrapProviderError(ErrProviderMisconfigured, fmt.Errorf("the %s PCA is not active: status is %s", verb,
+				*output.CertificateAuthority.Status))
 		}
 
 		// Load the certs
@@ -334,7 +351,7 @@ func (a *AWSProvider) getCACSR() (string, error) {
 	a.logger.Debug("retrieving CSR for PCA", "pca", a.arn)
 	output, err := a.client.GetCertificateAuthorityCsr(input)
 	if err != nil {
-		return "", err
+		return "", awsError(err, ErrProviderMisconfigured)
 	}
 
 	csrPEM := output.Csr
@@ -351,7 +368,7 @@ func (a *AWSProvider) loadCACerts() error {
 	}
 	output, err := a.client.GetCertificateAuthorityCertificate(input)
 	if err != nil {
-		return err
+		return awsError(err, ErrProviderMisconfigured)
 	}
 
 	if a.isPrimary {
@@ -463,7 +480,7 @@ func (a *AWSProvider) signCSR(csrPEM string, templateARN string, ttl time.Durati
 		}
 	}
 	if err != nil {
-		return "", fmt.Errorf("error issuing certificate from PCA: %s", err)
+		return "", awsError(fmt.Errorf("error issuing certificate from PCA: %w", err), ErrSigningDenied)
 	}
 
 	// wait for certificate to be created
@@ -489,6 +506,31 @@ func (a *AWSProvider) signCSR(csrPEM string, templateARN string, ttl time.Durati
 		})
 }
 
+// awsError classifies an error returned by the AWS SDK. Requests AWS denied
+// are classified as denied, while transport failures and server errors mean
+// the PCA is unreachable.
+func awsError(err error, denied error) error {
+	var aerr awserr.Error
+	if !errors.As(err, &aerr) {
+		return err
+	}
+
+	var reqErr awserr.RequestFailure
+	if errors.As(err, &reqErr) && reqErr.StatusCode() >= 500 {
+		return WrapProviderError(ErrProviderUnreachable, err)
+	}
+
+	switch aerr.Code() {
+	case "AccessDeniedException":
+		return WrapProviderError(denied, err)
+	case "NoCredentialProviders", acmpca.ErrCodeResourceNotFoundException, acmpca.ErrCodeInvalidArnException:
+		return WrapProviderError(ErrProviderMisconfigured, err)
+	case "RequestError", request.ErrCodeResponseTimeout:
+		return WrapProviderError(ErrProviderUnreachable, err)
+	}
+	return err
+}
+
 // ActiveRoot implements Provider
 func (a *AWSProvider) ActiveRoot() (string, error) {
 	err := a.ensureCA()
@@ -594,6 +636,16 @@ func (a *AWSProvider) Sign(csr *x509.CertificateRequest) (string, error) {
 		return "", fmt.Errorf("AWS CA provider not fully Initialized")
 	}
 
+	// The leaf template ARN fixes the extended key usages so only the default
+	// of client and server auth can be honored.
+	extKeyUsage, err := connect.LeafExtKeyUsage(csr)
+	if err != nil {
+		return "", err
+	}
+	if len(extKeyUsage) != 2 {
+		return "", fmt.Errorf("AWS CA provider does not support restricting leaf extended key usage")
+	}
+
 	a.logger.Debug("signing csr for requester",
 		"requester", csr.Subject.CommonName,
 	)
@@ -689,9 +741,12 @@ func (a *AWSProvider) Cleanup(providerTypeChange bool, otherConfig map[string]in
 	return nil
 }
 
-// SupportsCrossSigning implements Provider
-func (a *AWSProvider) SupportsCrossSigning() (bool, error) {
-	return false, nil
+// Capabilities implements CapabilityReporter
+func (a *AWSProvider) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{
+		ExternalRoot: true,
+		KeyTypes:     []string{"ec", "rsa"},
+	}
 }
 
 // ParseAWSCAConfig parses and validates AWS CA Provider configuration.
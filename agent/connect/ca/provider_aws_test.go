@@ -1,6 +1,8 @@
 package ca
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -8,6 +10,7 @@ import (
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/acmpca"
 	"github.com/stretchr/testify/require"
 
@@ -277,9 +280,7 @@ func TestAWSNoCrossSigning(t *testing.T) {
 	// Don't bother initializing a PCA as that is slow and unnecessary for this
 	// test
 
-	ok, err := p1.SupportsCrossSigning()
-	require.NoError(t, err)
-	require.False(t, ok)
+	require.False(t, p1.Capabilities().CrossSigning)
 
 	// Attempt to cross sign a CA should fail with sensible error
 	ca := connect.TestCA(t, nil)
@@ -453,3 +454,67 @@ func testProviderConfigSecondary(t *testing.T, cfg map[string]interface{}) Provi
 	c.Datacenter = "dc2"
 	return c
 }
+
+func TestAWSError(t *testing.T) {
+	cases := map[string]struct {
+		err    error
+		denied error
+		expect error
+	}{
+		"access denied": {
+			err:    awserr.New("AccessDeniedException", "not allowed", nil),
+			denied: ErrSigningDenied,
+			expect: ErrSigningDenied,
+		},
+		"missing CA": {
+			err:    awserr.New(acmpca.ErrCodeResourceNotFoundException, "no such CA", nil),
+			denied: ErrProviderMisconfigured,
+			expect: ErrProviderMisconfigured,
+		},
+		"no credentials": {
+			err:    awserr.New("NoCredentialProviders", "no valid providers in chain", nil),
+			denied: ErrProviderMisconfigured,
+			expect: ErrProviderMisconfigured,
+		},
+		"request error": {
+			err:    awserr.New("RequestError", "send request failed", fmt.Errorf("dial tcp: i/o timeout")),
+			denied: ErrSigningDenied,
+			expect: ErrProviderUnreachable,
+		},
+		"server error": {
+			err:    awserr.NewRequestFailure(awserr.New("InternalFailure", "oops", nil), 503, "req-id"),
+			denied: ErrSigningDenied,
+			expect: ErrProviderUnreachable,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := awsError(tc.err, tc.denied)
+			require.True(t, errors.Is(err, tc.expect), "expected %v, got %v", tc.expect, err)
+			require.True(t, errors.Is(err, tc.err))
+		})
+	}
+
+	t.Run("unclassified", func(t *testing.T) {
+		orig := awserr.New(acmpca.ErrCodeLimitExceededException, "slow down", nil)
+		require.Equal(t, orig, awsError(orig, ErrSigningDenied))
+
+		plain := fmt.Errorf("some other error")
+		require.Equal(t, plain, awsError(plain, ErrSigningDenied))
+	})
+}
+
+func TestAWSProvider_Configure_OCSPResponderURL(t *testing.T) {
+	p := &AWSProvider{}
+	err := p.Configure(ProviderConfig{
+		ClusterID:        connect.TestClusterID,
+		IsPrimary:        true,
+		RawConfig:        map[string]interface{}{},
+		OCSPResponderURL: "http://ocsp.example.com",
+	})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrProviderMisconfigured))
+	require.Contains(t, err.Error(), "does not support an OCSP responder URL")
+}
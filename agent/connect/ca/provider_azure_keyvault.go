@@ -0,0 +1,992 @@
+package ca
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/hashicorp/go-hclog"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+const (
+	// AzureKeyVaultTimeout is the maximum time we will wait for a single
+	// request to Key Vault.
+	AzureKeyVaultTimeout = 30 * time.Second
+
+	// The keys in the provider State. The key name and version identify the
+	// Key Vault key the provider signs with. The certs are public so are safe
+	// to keep in State, which saves reissuing them on every restart.
+	AzureStateVaultURLKey         = "VAULT_URL"
+	AzureStateKeyNameKey          = "KEY_NAME"
+	AzureStateKeyVersionKey       = "KEY_VERSION"
+	AzureStateRootCertKey         = "ROOT_CERT"
+	AzureStateIntermediateCertKey = "INTERMEDIATE_CERT"
+)
+
+// azureKeyVaultClient is the part of the Key Vault API the provider uses.
+// keyvault.BaseClient implements it.
+type azureKeyVaultClient interface {
+	CreateKey(ctx context.Context, vaultBaseURL string, keyName string, parameters keyvault.KeyCreateParameters) (keyvault.KeyBundle, error)
+	GetKey(ctx context.Context, vaultBaseURL string, keyName string, keyVersion string) (keyvault.KeyBundle, error)
+	Sign(ctx context.Context, vaultBaseURL string, keyName string, keyVersion string, parameters keyvault.KeySignParameters) (keyvault.KeyOperationResult, error)
+	DeleteKey(ctx context.Context, vaultBaseURL string, keyName string) (keyvault.DeletedKeyBundle, error)
+}
+
+// AzureKeyVaultProvider implements Provider using keys held in Azure Key
+// Vault. The certs are built by Consul, but every signature is made by Key
+// Vault so the CA private keys never leave it.
+type AzureKeyVaultProvider struct {
+	lock sync.Mutex
+
+	config     *structs.AzureKeyVaultCAProviderConfig
+	client     azureKeyVaultClient
+	isPrimary  bool
+	clusterID  string
+	datacenter string
+	spiffeID   *connect.SpiffeIDSigning
+	ocspServer string
+
+	// intermediateSubject is requested for this provider's intermediate and
+	// enforced on the intermediates it signs for secondaries.
+	intermediateSubject intermediateSubject
+
+	// minLeafCertTTL is the shortest lifetime SignWithTTL issues. Zero uses
+	// structs.MinLeafCertTTL.
+	minLeafCertTTL time.Duration
+
+	// keyName and keyVersion identify the key the active signing cert is for.
+	// signer is loaded from Key Vault on first use.
+	keyName    string
+	keyVersion string
+	signer     *azureKeyVaultSigner
+
+	// pendingKey is the key a secondary generated its last intermediate CSR
+	// for. It becomes the signing key once the intermediate is set.
+	pendingKey *azureKeyVaultSigner
+
+	// createdKeys are the names of all keys this instance created, so that
+	// they can be deleted on exit.
+	createdKeys []string
+
+	rootPEM            string
+	intermediatePEM    string
+	intermediateExpiry certExpiryCache
+	logger             hclog.Logger
+}
+
+// NewAzureKeyVaultProvider returns a new AzureKeyVaultProvider
+func NewAzureKeyVaultProvider(logger hclog.Logger) *AzureKeyVaultProvider {
+	return &AzureKeyVaultProvider{logger: logger}
+}
+
+// Configure implements Provider
+func (a *AzureKeyVaultProvider) Configure(cfg ProviderConfig) error {
+	config, err := ParseAzureKeyVaultCAConfig(cfg.RawConfig)
+	if err != nil {
+		return WrapProviderError(ErrProviderMisconfigured, err)
+	}
+
+	// Signatures are made with SHA-256 only, see azureKeyVaultSigner.
+	if cfg.SignatureAlgorithm != "" {
+		return WrapProviderError(ErrProviderMisconfigured,
+			fmt.Errorf("the Azure Key Vault CA provider does not support choosing the signature algorithm"))
+	}
+
+	// Like the AWS provider we only support credentials from the environment,
+	// which covers managed identities as well as service principals, rather
+	// than persisting secrets in the CA config. Tests set a client before
+	// configuring.
+	if a.client == nil {
+		client, err := newAzureKeyVaultClient()
+		if err != nil {
+			return WrapProviderError(ErrProviderMisconfigured, err)
+		}
+		a.client = client
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.config = config
+	a.isPrimary = cfg.IsPrimary
+	a.clusterID = cfg.ClusterID
+	a.datacenter = cfg.Datacenter
+	a.spiffeID = connect.SpiffeIDSigningForCluster(&structs.CAConfiguration{ClusterID: cfg.ClusterID})
+	a.ocspServer = cfg.OCSPResponderURL
+	a.minLeafCertTTL = cfg.MinLeafCertTTL
+	a.intermediateSubject = newIntermediateSubject(cfg)
+
+	// Keys in another vault can't be used with this config, so only pick up
+	// the previous state when the vault hasn't changed.
+	if cfg.State[AzureStateVaultURLKey] == config.VaultURL {
+		a.keyName = cfg.State[AzureStateKeyNameKey]
+		a.keyVersion = cfg.State[AzureStateKeyVersionKey]
+		a.rootPEM = cfg.State[AzureStateRootCertKey]
+		a.intermediatePEM = cfg.State[AzureStateIntermediateCertKey]
+	}
+
+	return nil
+}
+
+// newAzureKeyVaultClient returns a Key Vault client authorized with the
+// credentials found in the environment.
+func newAzureKeyVaultClient() (azureKeyVaultClient, error) {
+	settings, err := auth.GetSettingsFromEnvironment()
+	if err != nil {
+		return nil, err
+	}
+	settings.Values[auth.Resource] = strings.TrimSuffix(settings.Environment.ResourceIdentifiers.KeyVault, "/")
+
+	authorizer, err := settings.GetAuthorizer()
+	if err != nil {
+		return nil, err
+	}
+
+	client := keyvault.New()
+	client.Authorizer = authorizer
+	return client, nil
+}
+
+// State implements Provider
+func (a *AzureKeyVaultProvider) State() (map[string]string, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.keyName == "" {
+		return nil, nil
+	}
+
+	state := map[string]string{
+		AzureStateVaultURLKey:   a.config.VaultURL,
+		AzureStateKeyNameKey:    a.keyName,
+		AzureStateKeyVersionKey: a.keyVersion,
+	}
+	if a.rootPEM != "" {
+		state[AzureStateRootCertKey] = a.rootPEM
+	}
+	if a.intermediatePEM != "" {
+		state[AzureStateIntermediateCertKey] = a.intermediatePEM
+	}
+	return state, nil
+}
+
+// GenerateRoot implements Provider
+func (a *AzureKeyVaultProvider) GenerateRoot() error {
+	if !a.isPrimary {
+		return fmt.Errorf("provider is not the root certificate authority")
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.rootPEM != "" && a.keyName != "" {
+		rootCert, err := connect.ParseCert(a.rootPEM)
+		if err != nil {
+			return fmt.Errorf("error parsing root cert from provider state: %v", err)
+		}
+		keyType, keyBits, err := connect.KeyInfoFromCert(rootCert)
+		if err != nil {
+			return err
+		}
+
+		// Keep the existing root unless the key type was changed, which is how
+		// operators rotate to a different kind of key.
+		if keyType == a.config.PrivateKeyType && keyBits == a.config.PrivateKeyBits {
+			_, err := a.loadSigner()
+			return err
+		}
+		a.logger.Info("configured private key type differs from the existing root, generating a new root",
+			"key_name", a.keyName,
+		)
+	}
+
+	signer, err := a.createKey()
+	if err != nil {
+		return err
+	}
+	rootPEM, err := a.generateCA(signer)
+	if err != nil {
+		return err
+	}
+
+	a.useKey(signer)
+	a.rootPEM = rootPEM
+	a.intermediatePEM = ""
+	return nil
+}
+
+// generateCA makes a new self-signed root CA for the given key.
+func (a *AzureKeyVaultProvider) generateCA(signer *azureKeyVaultSigner) (string, error) {
+	keyId, err := connect.KeyId(signer.Public())
+	if err != nil {
+		return "", err
+	}
+
+	uid, err := connect.CompactUID()
+	if err != nil {
+		return "", err
+	}
+	sn, err := randomSerialNumber()
+	if err != nil {
+		return "", err
+	}
+	template := x509.Certificate{
+		SerialNumber:          sn,
+		Subject:               pkix.Name{CommonName: connect.CACN("azure", uid, a.clusterID, a.isPrimary)},
+		URIs:                  []*url.URL{a.spiffeID.URI()},
+		SignatureAlgorithm:    connect.SigAlgoForKey(signer),
+		BasicConstraintsValid: true,
+		KeyUsage: x509.KeyUsageCertSign |
+			x509.KeyUsageCRLSign |
+			x509.KeyUsageDigitalSignature,
+		IsCA:           true,
+		NotAfter:       time.Now().Add(a.config.RootCertTTL),
+		NotBefore:      time.Now(),
+		AuthorityKeyId: keyId,
+		SubjectKeyId:   keyId,
+	}
+
+	bs, err := x509.CreateCertificate(
+		rand.Reader, &template, &template, signer.Public(), signer)
+	if err != nil {
+		return "", fmt.Errorf("error generating CA certificate: %s", err)
+	}
+	return encodeCert(bs)
+}
+
+// ActiveRoot implements Provider
+func (a *AzureKeyVaultProvider) ActiveRoot() (string, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.rootPEM == "" {
+		return "", fmt.Errorf("Azure Key Vault CA provider not fully initialized")
+	}
+	return a.rootPEM, nil
+}
+
+// GenerateIntermediateCSR implements Provider. Each CSR is for a new key so
+// the current intermediate keeps working until its replacement is set.
+func (a *AzureKeyVaultProvider) GenerateIntermediateCSR() (string, error) {
+	if a.isPrimary {
+		return "", fmt.Errorf("provider is the root certificate authority, " +
+			"cannot generate an intermediate CSR")
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	signer, err := a.createKey()
+	if err != nil {
+		return "", err
+	}
+	csr, err := connect.CreateCACSRWithSubject(a.spiffeID, signer, a.intermediateSubject.apply(pkix.Name{}))
+	if err != nil {
+		return "", err
+	}
+
+	a.pendingKey = signer
+	return csr, nil
+}
+
+// SetIntermediate implements Provider
+func (a *AzureKeyVaultProvider) SetIntermediate(intermediatePEM, rootPEM string) error {
+	if a.isPrimary {
+		return fmt.Errorf("cannot set an intermediate using another root in the primary datacenter")
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	// Leadership may have changed since the CSR was generated, in which case
+	// the intermediate can only be for the current key.
+	signer := a.pendingKey
+	if signer == nil {
+		var err error
+		if signer, err = a.loadSigner(); err != nil {
+			return err
+		}
+	}
+
+	if err := validateSetIntermediate(intermediatePEM, rootPEM, "", a.spiffeID); err != nil {
+		return err
+	}
+	intermediate, err := connect.ParseCert(intermediatePEM)
+	if err != nil {
+		return fmt.Errorf("error parsing intermediate PEM: %v", err)
+	}
+	if err := validateIntermediateKey(intermediate, signer.Public()); err != nil {
+		return err
+	}
+
+	a.useKey(signer)
+	a.pendingKey = nil
+	a.rootPEM = EnsureTrailingNewline(rootPEM)
+	a.intermediatePEM = EnsureTrailingNewline(intermediatePEM)
+	return nil
+}
+
+// ActiveIntermediate implements Provider
+func (a *AzureKeyVaultProvider) ActiveIntermediate() (string, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.activeIntermediate(), nil
+}
+
+// activeIntermediate returns the cert leafs are signed with. Like the consul
+// provider the primary signs leafs with its root directly.
+func (a *AzureKeyVaultProvider) activeIntermediate() string {
+	if a.isPrimary {
+		return a.rootPEM
+	}
+	return a.intermediatePEM
+}
+
+// IntermediateExpiry implements IntermediateExpirer
+func (a *AzureKeyVaultProvider) IntermediateExpiry() (notBefore, notAfter time.Time, err error) {
+	pem, _ := a.ActiveIntermediate()
+	return a.intermediateExpiry.Validity(pem)
+}
+
+// GenerateIntermediate implements Provider. The primary doesn't use a
+// separate intermediate so this just returns the root.
+func (a *AzureKeyVaultProvider) GenerateIntermediate() (string, error) {
+	return a.ActiveIntermediate()
+}
+
+// Sign implements Provider
+func (a *AzureKeyVaultProvider) Sign(csr *x509.CertificateRequest) (string, error) {
+	return a.SignWithTTL(csr, 0)
+}
+
+// SignWithTTL implements SignerWithTTL.
+func (a *AzureKeyVaultProvider) SignWithTTL(csr *x509.CertificateRequest, ttl time.Duration) (string, error) {
+	connect.HackSANExtensionForCSR(csr)
+
+	signer, caCert, err := a.signingState(a.activeIntermediate)
+	if err != nil {
+		return "", err
+	}
+
+	keyId, err := connect.KeyId(signer.Public())
+	if err != nil {
+		return "", err
+	}
+	subjectKeyID, err := connect.KeyId(csr.PublicKey)
+	if err != nil {
+		return "", err
+	}
+	extKeyUsage, err := connect.LeafExtKeyUsage(csr)
+	if err != nil {
+		return "", err
+	}
+	sn, err := randomSerialNumber()
+	if err != nil {
+		return "", err
+	}
+
+	// Sign the certificate valid from the drift buffer in the past, this helps
+	// it be accepted right away even when nodes are not in close time sync
+	// across the cluster.
+	effectiveNow := time.Now().Add(-1 * LeafNotBeforeBackdate(a.config.CommonCAProviderConfig))
+	template := x509.Certificate{
+		SerialNumber:          sn,
+		URIs:                  csr.URIs,
+		Signature:             csr.Signature,
+		SignatureAlgorithm:    connect.SigAlgoForKey(signer),
+		PublicKeyAlgorithm:    csr.PublicKeyAlgorithm,
+		PublicKey:             csr.PublicKey,
+		BasicConstraintsValid: true,
+		KeyUsage: x509.KeyUsageDataEncipherment |
+			x509.KeyUsageKeyAgreement |
+			x509.KeyUsageDigitalSignature |
+			x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:    extKeyUsage,
+		NotAfter:       effectiveNow.Add(structs.ClampLeafCertTTLWithMin(ttl, a.minLeafCertTTL, a.config.LeafCertTTL)),
+		NotBefore:      effectiveNow,
+		AuthorityKeyId: keyId,
+		SubjectKeyId:   subjectKeyID,
+		DNSNames:       csr.DNSNames,
+		IPAddresses:    csr.IPAddresses,
+	}
+	if a.ocspServer != "" {
+		template.OCSPServer = []string{a.ocspServer}
+	}
+
+	bs, err := x509.CreateCertificate(rand.Reader, &template, caCert, csr.PublicKey, signer)
+	if err != nil {
+		return "", fmt.Errorf("error generating certificate: %w", err)
+	}
+	return encodeCert(bs)
+}
+
+// SignIntermediate implements Provider
+func (a *AzureKeyVaultProvider) SignIntermediate(csr *x509.CertificateRequest) (string, error) {
+	if err := validateSignIntermediate(csr, a.spiffeID); err != nil {
+		return "", err
+	}
+
+	signer, rootCert, err := a.signingState(a.activeRoot)
+	if err != nil {
+		return "", err
+	}
+
+	subjectKeyID, err := connect.KeyId(csr.PublicKey)
+	if err != nil {
+		return "", err
+	}
+	sn, err := randomSerialNumber()
+	if err != nil {
+		return "", err
+	}
+
+	effectiveNow := time.Now().Add(-1 * IntermediateNotBeforeBackdate(a.config.CommonCAProviderConfig))
+	template := x509.Certificate{
+		SerialNumber:          sn,
+		DNSNames:              csr.DNSNames,
+		EmailAddresses:        csr.EmailAddresses,
+		IPAddresses:           csr.IPAddresses,
+		URIs:                  csr.URIs,
+		ExtraExtensions:       csr.ExtraExtensions,
+		Subject:               a.intermediateSubject.apply(csr.Subject),
+		Signature:             csr.Signature,
+		SignatureAlgorithm:    connect.SigAlgoForKey(signer),
+		PublicKeyAlgorithm:    csr.PublicKeyAlgorithm,
+		PublicKey:             csr.PublicKey,
+		BasicConstraintsValid: true,
+		KeyUsage: x509.KeyUsageCertSign |
+			x509.KeyUsageCRLSign |
+			x509.KeyUsageDigitalSignature,
+		IsCA:           true,
+		MaxPathLenZero: true,
+		NotAfter:       effectiveNow.Add(a.config.IntermediateCertTTL),
+		NotBefore:      effectiveNow,
+		SubjectKeyId:   subjectKeyID,
+	}
+
+	bs, err := x509.CreateCertificate(rand.Reader, &template, rootCert, csr.PublicKey, signer)
+	if err != nil {
+		return "", fmt.Errorf("error generating certificate: %w", err)
+	}
+	return encodeCert(bs)
+}
+
+// CrossSignCA implements Provider
+func (a *AzureKeyVaultProvider) CrossSignCA(cert *x509.Certificate) (string, error) {
+	signer, rootCert, err := a.signingState(a.activeRoot)
+	if err != nil {
+		return "", err
+	}
+
+	keyId, err := connect.KeyId(signer.Public())
+	if err != nil {
+		return "", err
+	}
+	sn, err := randomSerialNumber()
+	if err != nil {
+		return "", err
+	}
+
+	template := *cert
+	template.SerialNumber = sn
+	template.SignatureAlgorithm = connect.SigAlgoForKey(signer)
+	template.AuthorityKeyId = keyId
+
+	// The cross-signed cert is only needed while leafs signed by the old root
+	// are still in use, so it has the same 7 day lifetime as the consul
+	// provider's.
+	effectiveNow := time.Now().Add(-1 * IntermediateNotBeforeBackdate(a.config.CommonCAProviderConfig))
+	template.NotBefore = effectiveNow
+	template.NotAfter = effectiveNow.AddDate(0, 0, 7)
+
+	bs, err := x509.CreateCertificate(rand.Reader, &template, rootCert, cert.PublicKey, signer)
+	if err != nil {
+		return "", fmt.Errorf("error generating CA certificate: %w", err)
+	}
+	return encodeCert(bs)
+}
+
+// Capabilities implements CapabilityReporter
+func (a *AzureKeyVaultProvider) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{
+		CrossSigning: true,
+		ExternalRoot: true,
+		KeyTypes:     []string{"ec", "rsa"},
+	}
+}
+
+// HealthCheck implements HealthChecker. It makes sure the signing key can
+// still be read from Key Vault and that the signing cert hasn't expired.
+func (a *AzureKeyVaultProvider) HealthCheck() error {
+	a.lock.Lock()
+	keyName, keyVersion := a.keyName, a.keyVersion
+	signingPEM := a.activeIntermediate()
+	a.lock.Unlock()
+
+	if keyName == "" {
+		return fmt.Errorf("no signing key is set")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), AzureKeyVaultTimeout)
+	defer cancel()
+	bundle, err := a.client.GetKey(ctx, a.config.VaultURL, keyName, keyVersion)
+	if err != nil {
+		return azureError(err, ErrProviderMisconfigured)
+	}
+	if err := azureKeyEnabled(bundle); err != nil {
+		return err
+	}
+
+	if signingPEM == "" {
+		return fmt.Errorf("no signing certificate is set")
+	}
+	cert, err := connect.ParseCert(signingPEM)
+	if err != nil {
+		return fmt.Errorf("error parsing signing cert: %s", err)
+	}
+	if time.Now().After(cert.NotAfter) {
+		return fmt.Errorf("signing cert expired at %s", cert.NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Cleanup implements Provider
+func (a *AzureKeyVaultProvider) Cleanup(providerTypeChange bool, otherConfig map[string]interface{}) error {
+	if !providerTypeChange {
+		azureConfig, err := ParseAzureKeyVaultCAConfig(otherConfig)
+		if err != nil {
+			return err
+		}
+
+		// The new provider picks up our keys from the state when it uses the
+		// same vault, so they must be kept.
+		if a.config.VaultURL == azureConfig.VaultURL {
+			return nil
+		}
+	}
+
+	if !a.config.DeleteOnExit {
+		return nil
+	}
+
+	a.lock.Lock()
+	keyNames := append([]string{}, a.createdKeys...)
+	if a.keyName != "" && !containsString(keyNames, a.keyName) {
+		keyNames = append(keyNames, a.keyName)
+	}
+	a.lock.Unlock()
+
+	for _, name := range keyNames {
+		a.logger.Info("deleting Key Vault key", "key_name", name)
+		ctx, cancel := context.WithTimeout(context.Background(), AzureKeyVaultTimeout)
+		_, err := a.client.DeleteKey(ctx, a.config.VaultURL, name)
+		cancel()
+		if err != nil {
+			// This is a best-effort delete so don't stall leader shutdown.
+			a.logger.Error("failed to delete Key Vault key",
+				"key_name", name,
+				"error", err,
+			)
+		}
+	}
+	return nil
+}
+
+// activeRoot returns the root cert, which the primary signs intermediates and
+// cross-signed certs with.
+func (a *AzureKeyVaultProvider) activeRoot() string {
+	return a.rootPEM
+}
+
+// signingState returns the current signing key along with the CA cert chosen
+// by certPEM, which is called with the lock held. The key is returned so that
+// signing can happen without holding the lock.
+func (a *AzureKeyVaultProvider) signingState(certPEM func() string) (*azureKeyVaultSigner, *x509.Certificate, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	pem := certPEM()
+	if pem == "" {
+		return nil, nil, ErrNotInitialized
+	}
+	cert, err := connect.ParseCert(pem)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing CA cert: %s", err)
+	}
+	signer, err := a.loadSigner()
+	if err != nil {
+		return nil, nil, err
+	}
+	return signer, cert, nil
+}
+
+// loadSigner returns the signer for the current key, fetching its public key
+// from Key Vault the first time. It must be called with the lock held.
+func (a *AzureKeyVaultProvider) loadSigner() (*azureKeyVaultSigner, error) {
+	if a.signer != nil {
+		return a.signer, nil
+	}
+	if a.keyName == "" {
+		return nil, ErrNotInitialized
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), AzureKeyVaultTimeout)
+	defer cancel()
+	bundle, err := a.client.GetKey(ctx, a.config.VaultURL, a.keyName, a.keyVersion)
+	if err != nil {
+		return nil, azureError(fmt.Errorf("error reading key %q: %w", a.keyName, err), ErrProviderMisconfigured)
+	}
+	if err := azureKeyEnabled(bundle); err != nil {
+		return nil, err
+	}
+	signer, err := a.signerForKey(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	a.signer = signer
+	return signer, nil
+}
+
+// createKey creates a new key in Key Vault of the configured type. It must be
+// called with the lock held.
+func (a *AzureKeyVaultProvider) createKey() (*azureKeyVaultSigner, error) {
+	uid, err := connect.CompactUID()
+	if err != nil {
+		return nil, err
+	}
+	name := "consul-ca-" + uid
+
+	clusterID, datacenter := a.clusterID, a.datacenter
+	params := keyvault.KeyCreateParameters{
+		KeyOps: &[]keyvault.JSONWebKeyOperation{keyvault.Sign, keyvault.Verify},
+		Tags: map[string]*string{
+			"consul_cluster_id": &clusterID,
+			"consul_datacenter": &datacenter,
+		},
+	}
+	switch a.config.PrivateKeyType {
+	case "ec":
+		params.Kty = keyvault.EC
+		if a.config.HSM {
+			params.Kty = keyvault.ECHSM
+		}
+		params.Curve = keyvault.P256
+	case "rsa":
+		params.Kty = keyvault.RSA
+		if a.config.HSM {
+			params.Kty = keyvault.RSAHSM
+		}
+		size := int32(a.config.PrivateKeyBits)
+		params.KeySize = &size
+	}
+
+	a.logger.Debug("creating new Key Vault key", "key_name", name, "key_type", params.Kty)
+	ctx, cancel := context.WithTimeout(context.Background(), AzureKeyVaultTimeout)
+	defer cancel()
+	bundle, err := a.client.CreateKey(ctx, a.config.VaultURL, name, params)
+	if err != nil {
+		return nil, azureError(fmt.Errorf("error creating key %q: %w", name, err), ErrProviderMisconfigured)
+	}
+	a.createdKeys = append(a.createdKeys, name)
+
+	return a.signerForKey(bundle)
+}
+
+// useKey makes signer the signing key. It must be called with the lock held.
+func (a *AzureKeyVaultProvider) useKey(signer *azureKeyVaultSigner) {
+	a.signer = signer
+	a.keyName = signer.name
+	a.keyVersion = signer.version
+}
+
+// signerForKey returns a signer for the key in bundle, pinned to the
+// version it describes.
+func (a *AzureKeyVaultProvider) signerForKey(bundle keyvault.KeyBundle) (*azureKeyVaultSigner, error) {
+	if bundle.Key == nil || bundle.Key.Kid == nil {
+		return nil, fmt.Errorf("Key Vault returned a key without an ID")
+	}
+	name, version, err := parseAzureKeyID(*bundle.Key.Kid)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := azurePublicKey(bundle.Key)
+	if err != nil {
+		return nil, fmt.Errorf("error reading public key of %q: %v", name, err)
+	}
+
+	return &azureKeyVaultSigner{
+		client:   a.client,
+		vaultURL: a.config.VaultURL,
+		name:     name,
+		version:  version,
+		public:   pub,
+	}, nil
+}
+
+// azureKeyVaultSigner is a crypto.Signer for a key held in Key Vault.
+type azureKeyVaultSigner struct {
+	client   azureKeyVaultClient
+	vaultURL string
+	name     string
+	version  string
+	public   crypto.PublicKey
+}
+
+// Public implements crypto.Signer
+func (s *azureKeyVaultSigner) Public() crypto.PublicKey {
+	return s.public
+}
+
+// Sign implements crypto.Signer. Only the SHA-256 digests that
+// connect.SigAlgoForKey selects for the supported key types are accepted.
+func (s *azureKeyVaultSigner) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if _, ok := opts.(*rsa.PSSOptions); ok {
+		return nil, fmt.Errorf("RSA-PSS signatures are not supported")
+	}
+	if opts.HashFunc() != crypto.SHA256 {
+		return nil, fmt.Errorf("unsupported signature hash %v", opts.HashFunc())
+	}
+
+	var alg keyvault.JSONWebKeySignatureAlgorithm
+	switch s.public.(type) {
+	case *ecdsa.PublicKey:
+		alg = keyvault.ES256
+	case *rsa.PublicKey:
+		alg = keyvault.RS256
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", s.public)
+	}
+
+	value := base64.RawURLEncoding.EncodeToString(digest)
+	ctx, cancel := context.WithTimeout(context.Background(), AzureKeyVaultTimeout)
+	defer cancel()
+	result, err := s.client.Sign(ctx, s.vaultURL, s.name, s.version, keyvault.KeySignParameters{
+		Algorithm: alg,
+		Value:     &value,
+	})
+	if err != nil {
+		return nil, azureError(fmt.Errorf("error signing with key %q: %w", s.name, err), ErrSigningDenied)
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("Key Vault returned no signature")
+	}
+	sig, err := decodeBase64URL(*result.Result)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding signature: %v", err)
+	}
+
+	if alg != keyvault.ES256 {
+		return sig, nil
+	}
+
+	// Key Vault returns EC signatures in the JWS form of r and s concatenated,
+	// but x509 expects them ASN.1 encoded.
+	if len(sig) == 0 || len(sig)%2 != 0 {
+		return nil, fmt.Errorf("invalid EC signature length %d", len(sig))
+	}
+	half := len(sig) / 2
+	return asn1.Marshal(struct {
+		R, S *big.Int
+	}{
+		R: new(big.Int).SetBytes(sig[:half]),
+		S: new(big.Int).SetBytes(sig[half:]),
+	})
+}
+
+// azurePublicKey converts the public part of a Key Vault key.
+func azurePublicKey(key *keyvault.JSONWebKey) (crypto.PublicKey, error) {
+	switch key.Kty {
+	case keyvault.EC, keyvault.ECHSM:
+		if key.Crv != keyvault.P256 {
+			return nil, fmt.Errorf("unsupported curve %q", key.Crv)
+		}
+		if key.X == nil || key.Y == nil {
+			return nil, fmt.Errorf("EC key is missing its coordinates")
+		}
+		x, err := decodeBase64URL(*key.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBase64URL(*key.Y)
+		if err != nil {
+			return nil, err
+		}
+		pub := &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, fmt.Errorf("EC key is not on curve %q", key.Crv)
+		}
+		return pub, nil
+
+	case keyvault.RSA, keyvault.RSAHSM:
+		if key.N == nil || key.E == nil {
+			return nil, fmt.Errorf("RSA key is missing its modulus or exponent")
+		}
+		n, err := decodeBase64URL(*key.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBase64URL(*key.E)
+		if err != nil {
+			return nil, err
+		}
+		exp := new(big.Int).SetBytes(e)
+		if !exp.IsInt64() || exp.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("RSA exponent is too large")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exp.Int64())}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", key.Kty)
+}
+
+// azureKeyEnabled returns an error if the key has been disabled in Key Vault.
+func azureKeyEnabled(bundle keyvault.KeyBundle) error {
+	if bundle.Attributes != nil && bundle.Attributes.Enabled != nil && !*bundle.Attributes.Enabled {
+		return WrapProviderError(ErrProviderMisconfigured, fmt.Errorf("the Key Vault key is disabled"))
+	}
+	return nil
+}
+
+// parseAzureKeyID splits a key ID of the form
+// https://{vault}.vault.azure.net/keys/{name}/{version} into the key name and
+// version.
+func parseAzureKeyID(kid string) (string, string, error) {
+	u, err := url.Parse(kid)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid Key Vault key ID %q: %v", kid, err)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 3 || parts[0] != "keys" {
+		return "", "", fmt.Errorf("invalid Key Vault key ID %q", kid)
+	}
+	return parts[1], parts[2], nil
+}
+
+// decodeBase64URL decodes the base64url values used by Key Vault, which
+// leaves off the padding.
+func decodeBase64URL(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// azureError classifies an error returned by the Key Vault client. Requests
+// Key Vault rejected as unauthorized are classified as denied, missing vaults
+// or keys mean the provider is misconfigured, while transport failures and
+// server errors mean Key Vault is unreachable.
+func azureError(err error, denied error) error {
+	var derr autorest.DetailedError
+	if !errors.As(err, &derr) {
+		return err
+	}
+
+	status, _ := derr.StatusCode.(int)
+	switch {
+	case status == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return WrapProviderError(denied, err)
+	case status == http.StatusNotFound:
+		return WrapProviderError(ErrProviderMisconfigured, err)
+	case status == autorest.UndefinedStatusCode || status >= 500:
+		return WrapProviderError(ErrProviderUnreachable, err)
+	}
+	return err
+}
+
+// randomSerialNumber returns a random serial number that fits in a uint64,
+// since the provider has no counter in the state store to draw from.
+func randomSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 63))
+}
+
+// encodeCert PEM encodes a DER certificate.
+func encodeCert(der []byte) (string, error) {
+	var buf bytes.Buffer
+	if err := pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: der}); err != nil {
+		return "", fmt.Errorf("error encoding certificate: %s", err)
+	}
+	return buf.String(), nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseAzureKeyVaultCAConfig parses and validates Azure Key Vault CA
+// Provider configuration.
+func ParseAzureKeyVaultCAConfig(raw map[string]interface{}) (*structs.AzureKeyVaultCAProviderConfig, error) {
+	config := structs.AzureKeyVaultCAProviderConfig{
+		CommonCAProviderConfig: defaultCommonConfig(),
+	}
+
+	decodeConf := &mapstructure.DecoderConfig{
+		DecodeHook:       structs.ParseDurationFunc(),
+		Result:           &config,
+		WeaklyTypedInput: true,
+	}
+
+	decoder, err := mapstructure.NewDecoder(decodeConf)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := decoder.Decode(raw); err != nil {
+		return nil, fmt.Errorf("error decoding config: %s", err)
+	}
+
+	if err := config.CommonCAProviderConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	if config.VaultURL == "" {
+		return nil, fmt.Errorf("must provide the VaultURL of the key vault")
+	}
+	u, err := url.Parse(config.VaultURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("VaultURL must be an https URL, got %q", config.VaultURL)
+	}
+
+	// Certs are signed with SHA-256, which Key Vault only allows for P256 EC
+	// keys and RSA keys.
+	switch {
+	case config.PrivateKeyType == "ec" && config.PrivateKeyBits == 256:
+	case config.PrivateKeyType == "rsa" && (config.PrivateKeyBits == 2048 || config.PrivateKeyBits == 4096):
+	default:
+		return nil, fmt.Errorf("Azure Key Vault CA provider only supports P256 EC curve, or RSA"+
+			" 2048/4096. %s, %d configured", config.PrivateKeyType, config.PrivateKeyBits)
+	}
+
+	return &config, nil
+}
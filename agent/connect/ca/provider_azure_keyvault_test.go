@@ -0,0 +1,552 @@
+package ca
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/sdk/testutil"
+)
+
+const testAzureVaultURL = "https://consul-test.vault.azure.net"
+
+func skipIfAzureNotConfigured(t *testing.T) {
+	enabled := os.Getenv("ENABLE_AZURE_KEYVAULT_TESTS")
+	ok, err := strconv.ParseBool(enabled)
+	if err != nil || !ok {
+		t.Skip("Skipping because Azure Key Vault tests are not enabled")
+	}
+	if os.Getenv("AZURE_KEYVAULT_URL") == "" {
+		t.Skip("Skipping because AZURE_KEYVAULT_URL is not set")
+	}
+}
+
+func TestAzureKeyVaultProvider_BootstrapAndSignPrimary(t *testing.T) {
+	for _, tc := range KeyTestCases {
+		tc := tc
+		t.Run(tc.Desc, func(t *testing.T) {
+			provider := testAzureKeyVaultProvider(t, newFakeAzureKeyVault(), testProviderConfigPrimary(t, map[string]interface{}{
+				"VaultURL":       testAzureVaultURL,
+				"PrivateKeyType": tc.KeyType,
+				"PrivateKeyBits": tc.KeyBits,
+			}))
+
+			require.NoError(t, provider.GenerateRoot())
+
+			rootPEM, err := provider.ActiveRoot()
+			require.NoError(t, err)
+			interPEM, err := provider.GenerateIntermediate()
+			require.NoError(t, err)
+			require.Equal(t, rootPEM, interPEM)
+
+			rootCert, err := connect.ParseCert(rootPEM)
+			require.NoError(t, err)
+			keyType, keyBits, err := connect.KeyInfoFromCert(rootCert)
+			require.NoError(t, err)
+			require.Equal(t, tc.KeyType, keyType)
+			require.Equal(t, tc.KeyBits, keyBits)
+			require.NoError(t, rootCert.CheckSignatureFrom(rootCert))
+
+			testSignAndValidate(t, provider, rootPEM, nil)
+			require.NoError(t, provider.HealthCheck())
+		})
+	}
+}
+
+func TestAzureKeyVaultProvider_BootstrapAndSignSecondary(t *testing.T) {
+	for _, tc := range CASigningKeyTypeCases() {
+		tc := tc
+		t.Run(tc.Desc, func(t *testing.T) {
+			kv := newFakeAzureKeyVault()
+			p1 := testAzureKeyVaultProvider(t, kv, testProviderConfigPrimary(t, map[string]interface{}{
+				"VaultURL":       testAzureVaultURL,
+				"PrivateKeyType": tc.SigningKeyType,
+				"PrivateKeyBits": tc.SigningKeyBits,
+			}))
+			require.NoError(t, p1.GenerateRoot())
+
+			p2 := testAzureKeyVaultProvider(t, kv, testProviderConfigSecondary(t, map[string]interface{}{
+				"VaultURL":       testAzureVaultURL,
+				"PrivateKeyType": tc.CSRKeyType,
+				"PrivateKeyBits": tc.CSRKeyBits,
+			}))
+
+			testSignIntermediateCrossDC(t, p1, p2)
+			require.NoError(t, p2.HealthCheck())
+
+			// Renewing the intermediate switches to a new key.
+			oldKey := p2.keyName
+			testSignIntermediateCrossDC(t, p1, p2)
+			require.NotEqual(t, oldKey, p2.keyName)
+		})
+	}
+}
+
+func TestAzureKeyVaultProvider_SetIntermediate_WrongKey(t *testing.T) {
+	kv := newFakeAzureKeyVault()
+	p1 := testAzureKeyVaultProvider(t, kv, testProviderConfigPrimary(t, map[string]interface{}{
+		"VaultURL": testAzureVaultURL,
+	}))
+	require.NoError(t, p1.GenerateRoot())
+	rootPEM, err := p1.ActiveRoot()
+	require.NoError(t, err)
+
+	p2 := testAzureKeyVaultProvider(t, kv, testProviderConfigSecondary(t, map[string]interface{}{
+		"VaultURL": testAzureVaultURL,
+	}))
+	_, err = p2.GenerateIntermediateCSR()
+	require.NoError(t, err)
+
+	// Sign a CSR for some other key.
+	signer, _, err := connect.GeneratePrivateKey()
+	require.NoError(t, err)
+	csrPEM, err := connect.CreateCACSR(p2.spiffeID, signer)
+	require.NoError(t, err)
+	csr, err := connect.ParseCSR(csrPEM)
+	require.NoError(t, err)
+	intermediatePEM, err := p1.SignIntermediate(csr)
+	require.NoError(t, err)
+
+	err = p2.SetIntermediate(intermediatePEM, rootPEM)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "intermediate cert is for a different private key")
+
+	inter, err := p2.ActiveIntermediate()
+	require.NoError(t, err)
+	require.Empty(t, inter)
+}
+
+func TestAzureKeyVaultProvider_CrossSign(t *testing.T) {
+	kv := newFakeAzureKeyVault()
+	p1 := testAzureKeyVaultProvider(t, kv, testProviderConfigPrimary(t, map[string]interface{}{
+		"VaultURL": testAzureVaultURL,
+	}))
+	require.NoError(t, p1.GenerateRoot())
+
+	p2 := testAzureKeyVaultProvider(t, kv, testProviderConfigPrimary(t, map[string]interface{}{
+		"VaultURL":       testAzureVaultURL,
+		"PrivateKeyType": "rsa",
+		"PrivateKeyBits": 2048,
+	}))
+	require.NoError(t, p2.GenerateRoot())
+
+	require.True(t, p1.Capabilities().CrossSigning)
+
+	testCrossSignProviders(t, p1, p2)
+}
+
+func TestAzureKeyVaultProvider_State(t *testing.T) {
+	kv := newFakeAzureKeyVault()
+	cfg := testProviderConfigPrimary(t, map[string]interface{}{
+		"VaultURL": testAzureVaultURL,
+	})
+	p1 := testAzureKeyVaultProvider(t, kv, cfg)
+	require.NoError(t, p1.GenerateRoot())
+	rootPEM, err := p1.ActiveRoot()
+	require.NoError(t, err)
+
+	state, err := p1.State()
+	require.NoError(t, err)
+	require.Equal(t, testAzureVaultURL, state[AzureStateVaultURLKey])
+	require.Equal(t, p1.keyName, state[AzureStateKeyNameKey])
+	require.Equal(t, rootPEM, state[AzureStateRootCertKey])
+
+	t.Run("same config keeps the root", func(t *testing.T) {
+		cfg := cfg
+		cfg.State = state
+		p := testAzureKeyVaultProvider(t, kv, cfg)
+		require.NoError(t, p.GenerateRoot())
+
+		newRootPEM, err := p.ActiveRoot()
+		require.NoError(t, err)
+		require.Equal(t, rootPEM, newRootPEM)
+		require.Empty(t, p.createdKeys)
+
+		testSignAndValidate(t, p, rootPEM, nil)
+	})
+
+	t.Run("new key type rotates the root", func(t *testing.T) {
+		cfg := testProviderConfigPrimary(t, map[string]interface{}{
+			"VaultURL":       testAzureVaultURL,
+			"PrivateKeyType": "rsa",
+			"PrivateKeyBits": 2048,
+		})
+		cfg.State = state
+		p := testAzureKeyVaultProvider(t, kv, cfg)
+		require.NoError(t, p.GenerateRoot())
+
+		newRootPEM, err := p.ActiveRoot()
+		require.NoError(t, err)
+		require.NotEqual(t, rootPEM, newRootPEM)
+		require.NotEqual(t, state[AzureStateKeyNameKey], p.keyName)
+	})
+
+	t.Run("state from another vault is ignored", func(t *testing.T) {
+		cfg := testProviderConfigPrimary(t, map[string]interface{}{
+			"VaultURL": "https://other.vault.azure.net",
+		})
+		cfg.State = state
+		p := testAzureKeyVaultProvider(t, kv, cfg)
+
+		_, err := p.ActiveRoot()
+		require.Error(t, err)
+		state, err := p.State()
+		require.NoError(t, err)
+		require.Nil(t, state)
+	})
+
+	t.Run("deleted key", func(t *testing.T) {
+		deleted := newFakeAzureKeyVault()
+		cfg := cfg
+		cfg.State = state
+		p := testAzureKeyVaultProvider(t, deleted, cfg)
+
+		err := p.GenerateRoot()
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrProviderMisconfigured))
+	})
+}
+
+func TestAzureKeyVaultProvider_Cleanup(t *testing.T) {
+	newProvider := func(t *testing.T, kv *fakeAzureKeyVault) *AzureKeyVaultProvider {
+		p := testAzureKeyVaultProvider(t, kv, testProviderConfigPrimary(t, map[string]interface{}{
+			"VaultURL": testAzureVaultURL,
+		}))
+		require.NoError(t, p.GenerateRoot())
+		require.Len(t, kv.keys, 1)
+		return p
+	}
+
+	t.Run("provider type change", func(t *testing.T) {
+		kv := newFakeAzureKeyVault()
+		p := newProvider(t, kv)
+		require.NoError(t, p.Cleanup(true, nil))
+		require.Empty(t, kv.keys)
+	})
+
+	t.Run("same vault", func(t *testing.T) {
+		kv := newFakeAzureKeyVault()
+		p := newProvider(t, kv)
+		require.NoError(t, p.Cleanup(false, map[string]interface{}{
+			"VaultURL": testAzureVaultURL,
+		}))
+		require.Len(t, kv.keys, 1)
+	})
+
+	t.Run("different vault", func(t *testing.T) {
+		kv := newFakeAzureKeyVault()
+		p := newProvider(t, kv)
+		require.NoError(t, p.Cleanup(false, map[string]interface{}{
+			"VaultURL": "https://other.vault.azure.net",
+		}))
+		require.Empty(t, kv.keys)
+	})
+}
+
+func TestAzureKeyVaultProvider_SignWithTTL(t *testing.T) {
+	provider := testAzureKeyVaultProvider(t, newFakeAzureKeyVault(), ProviderConfig{
+		ClusterID:        connect.TestClusterID,
+		IsPrimary:        true,
+		RawConfig:        map[string]interface{}{"VaultURL": testAzureVaultURL},
+		OCSPResponderURL: "http://ocsp.example.com",
+	})
+	require.NoError(t, provider.GenerateRoot())
+
+	csrPEM, _ := connect.TestCSR(t, connect.TestSpiffeIDService(t, "testsvc"))
+	csr, err := connect.ParseCSR(csrPEM)
+	require.NoError(t, err)
+
+	leafPEM, err := provider.SignWithTTL(csr, 0)
+	require.NoError(t, err)
+	leaf, err := connect.ParseCert(leafPEM)
+	require.NoError(t, err)
+	require.Equal(t, []string{"http://ocsp.example.com"}, leaf.OCSPServer)
+}
+
+func TestParseAzureKeyVaultCAConfig(t *testing.T) {
+	cases := map[string]struct {
+		raw    map[string]interface{}
+		expect string
+	}{
+		"missing vault URL": {
+			raw:    map[string]interface{}{},
+			expect: "must provide the VaultURL",
+		},
+		"not https": {
+			raw:    map[string]interface{}{"VaultURL": "http://example.vault.azure.net"},
+			expect: "VaultURL must be an https URL",
+		},
+		"unsupported EC curve": {
+			raw: map[string]interface{}{
+				"VaultURL":       testAzureVaultURL,
+				"PrivateKeyType": "ec",
+				"PrivateKeyBits": 384,
+			},
+			expect: "only supports P256 EC curve, or RSA 2048/4096",
+		},
+		"ed25519": {
+			raw: map[string]interface{}{
+				"VaultURL":       testAzureVaultURL,
+				"PrivateKeyType": "ed25519",
+				"PrivateKeyBits": 256,
+			},
+			expect: "only supports P256 EC curve, or RSA 2048/4096",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseAzureKeyVaultCAConfig(tc.raw)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expect)
+		})
+	}
+
+	config, err := ParseAzureKeyVaultCAConfig(map[string]interface{}{
+		"VaultURL":     testAzureVaultURL,
+		"HSM":          true,
+		"DeleteOnExit": "true",
+	})
+	require.NoError(t, err)
+	require.Equal(t, testAzureVaultURL, config.VaultURL)
+	require.True(t, config.HSM)
+	require.True(t, config.DeleteOnExit)
+}
+
+func TestAzureError(t *testing.T) {
+	detailed := func(status int) error {
+		return autorest.DetailedError{StatusCode: status, Message: "oops"}
+	}
+	cases := map[string]struct {
+		err    error
+		denied error
+		expect error
+	}{
+		"forbidden": {
+			err:    detailed(http.StatusForbidden),
+			denied: ErrSigningDenied,
+			expect: ErrSigningDenied,
+		},
+		"missing key": {
+			err:    detailed(http.StatusNotFound),
+			denied: ErrSigningDenied,
+			expect: ErrProviderMisconfigured,
+		},
+		"request error": {
+			err:    autorest.NewErrorWithError(fmt.Errorf("dial tcp: i/o timeout"), "keyvault.BaseClient", "Sign", nil, "Failure sending request"),
+			denied: ErrSigningDenied,
+			expect: ErrProviderUnreachable,
+		},
+		"server error": {
+			err:    fmt.Errorf("error signing: %w", detailed(http.StatusServiceUnavailable)),
+			denied: ErrSigningDenied,
+			expect: ErrProviderUnreachable,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := azureError(tc.err, tc.denied)
+			require.True(t, errors.Is(err, tc.expect), "expected %v, got %v", tc.expect, err)
+		})
+	}
+
+	t.Run("throttled", func(t *testing.T) {
+		require.Equal(t, ErrRateLimited, azureError(detailed(http.StatusTooManyRequests), ErrSigningDenied))
+	})
+
+	t.Run("unclassified", func(t *testing.T) {
+		orig := detailed(http.StatusConflict)
+		require.Equal(t, orig, azureError(orig, ErrSigningDenied))
+
+		plain := fmt.Errorf("some other error")
+		require.Equal(t, plain, azureError(plain, ErrSigningDenied))
+	})
+}
+
+func TestAzureKeyVaultBootstrapAndSign(t *testing.T) {
+	// Note not parallel so that the tests don't hit Key Vault throttling.
+	skipIfAzureNotConfigured(t)
+
+	vaultURL := os.Getenv("AZURE_KEYVAULT_URL")
+	p1 := NewAzureKeyVaultProvider(testutil.Logger(t))
+	require.NoError(t, p1.Configure(testProviderConfigPrimary(t, map[string]interface{}{
+		"VaultURL": vaultURL,
+	})))
+	defer p1.Cleanup(true, nil)
+	require.NoError(t, p1.GenerateRoot())
+
+	rootPEM, err := p1.ActiveRoot()
+	require.NoError(t, err)
+	testSignAndValidate(t, p1, rootPEM, nil)
+
+	p2 := NewAzureKeyVaultProvider(testutil.Logger(t))
+	require.NoError(t, p2.Configure(testProviderConfigSecondary(t, map[string]interface{}{
+		"VaultURL": vaultURL,
+	})))
+	defer p2.Cleanup(true, nil)
+
+	testSignIntermediateCrossDC(t, p1, p2)
+}
+
+func testAzureKeyVaultProvider(t *testing.T, client azureKeyVaultClient, cfg ProviderConfig) *AzureKeyVaultProvider {
+	p := NewAzureKeyVaultProvider(testutil.Logger(t))
+	p.client = client
+	require.NoError(t, p.Configure(cfg))
+	return p
+}
+
+// fakeAzureKeyVault is an in-memory Key Vault that signs with local keys.
+type fakeAzureKeyVault struct {
+	lock sync.Mutex
+	keys map[string]*fakeAzureKey
+}
+
+type fakeAzureKey struct {
+	version string
+	signer  crypto.Signer
+	bundle  keyvault.KeyBundle
+}
+
+func newFakeAzureKeyVault() *fakeAzureKeyVault {
+	return &fakeAzureKeyVault{keys: make(map[string]*fakeAzureKey)}
+}
+
+func (f *fakeAzureKeyVault) CreateKey(_ context.Context, vaultBaseURL string, keyName string, params keyvault.KeyCreateParameters) (keyvault.KeyBundle, error) {
+	var (
+		signer crypto.Signer
+		err    error
+	)
+	jwk := &keyvault.JSONWebKey{Kty: params.Kty}
+	switch params.Kty {
+	case keyvault.EC, keyvault.ECHSM:
+		signer, _, err = connect.GeneratePrivateKeyWithConfig("ec", 256)
+		if err != nil {
+			return keyvault.KeyBundle{}, err
+		}
+		pub := signer.Public().(*ecdsa.PublicKey)
+		jwk.Crv = params.Curve
+		jwk.X = fakeBase64URL(pub.X.Bytes())
+		jwk.Y = fakeBase64URL(pub.Y.Bytes())
+	case keyvault.RSA, keyvault.RSAHSM:
+		signer, _, err = connect.GeneratePrivateKeyWithConfig("rsa", int(*params.KeySize))
+		if err != nil {
+			return keyvault.KeyBundle{}, err
+		}
+		pub := signer.Public().(*rsa.PublicKey)
+		jwk.N = fakeBase64URL(pub.N.Bytes())
+		jwk.E = fakeBase64URL(big.NewInt(int64(pub.E)).Bytes())
+	default:
+		return keyvault.KeyBundle{}, autorest.DetailedError{StatusCode: http.StatusBadRequest}
+	}
+
+	version, err := connect.CompactUID()
+	if err != nil {
+		return keyvault.KeyBundle{}, err
+	}
+	kid := fmt.Sprintf("%s/keys/%s/%s", vaultBaseURL, keyName, version)
+	jwk.Kid = &kid
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	key := &fakeAzureKey{
+		version: version,
+		signer:  signer,
+		bundle:  keyvault.KeyBundle{Key: jwk, Tags: params.Tags},
+	}
+	f.keys[keyName] = key
+	return key.bundle, nil
+}
+
+func (f *fakeAzureKeyVault) getKey(keyName, keyVersion string) (*fakeAzureKey, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	key, ok := f.keys[keyName]
+	if !ok || (keyVersion != "" && keyVersion != key.version) {
+		return nil, autorest.DetailedError{StatusCode: http.StatusNotFound, Message: "key not found"}
+	}
+	return key, nil
+}
+
+func (f *fakeAzureKeyVault) GetKey(_ context.Context, _ string, keyName string, keyVersion string) (keyvault.KeyBundle, error) {
+	key, err := f.getKey(keyName, keyVersion)
+	if err != nil {
+		return keyvault.KeyBundle{}, err
+	}
+	return key.bundle, nil
+}
+
+func (f *fakeAzureKeyVault) Sign(_ context.Context, _ string, keyName string, keyVersion string, params keyvault.KeySignParameters) (keyvault.KeyOperationResult, error) {
+	key, err := f.getKey(keyName, keyVersion)
+	if err != nil {
+		return keyvault.KeyOperationResult{}, err
+	}
+	digest, err := base64.RawURLEncoding.DecodeString(*params.Value)
+	if err != nil || len(digest) != sha256.Size {
+		return keyvault.KeyOperationResult{}, autorest.DetailedError{StatusCode: http.StatusBadRequest}
+	}
+
+	var sig []byte
+	switch params.Algorithm {
+	case keyvault.ES256:
+		priv, ok := key.signer.(*ecdsa.PrivateKey)
+		if !ok {
+			return keyvault.KeyOperationResult{}, autorest.DetailedError{StatusCode: http.StatusBadRequest}
+		}
+		r, s, err := ecdsa.Sign(rand.Reader, priv, digest)
+		if err != nil {
+			return keyvault.KeyOperationResult{}, err
+		}
+		// JWS signatures are r and s padded to the curve size.
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+	case keyvault.RS256:
+		priv, ok := key.signer.(*rsa.PrivateKey)
+		if !ok {
+			return keyvault.KeyOperationResult{}, autorest.DetailedError{StatusCode: http.StatusBadRequest}
+		}
+		sig, err = rsa.SignPKCS1v15(rand.Reader, priv, crypto.SHA256, digest)
+		if err != nil {
+			return keyvault.KeyOperationResult{}, err
+		}
+	default:
+		return keyvault.KeyOperationResult{}, autorest.DetailedError{StatusCode: http.StatusBadRequest}
+	}
+	return keyvault.KeyOperationResult{Kid: key.bundle.Key.Kid, Result: fakeBase64URL(sig)}, nil
+}
+
+func (f *fakeAzureKeyVault) DeleteKey(_ context.Context, _ string, keyName string) (keyvault.DeletedKeyBundle, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	key, ok := f.keys[keyName]
+	if !ok {
+		return keyvault.DeletedKeyBundle{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+	}
+	delete(f.keys, keyName)
+	return keyvault.DeletedKeyBundle{Key: key.bundle.Key}, nil
+}
+
+func fakeBase64URL(b []byte) *string {
+	s := base64.RawURLEncoding.EncodeToString(b)
+	return &s
+}
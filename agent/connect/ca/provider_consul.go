@@ -2,7 +2,9 @@ package ca
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
 	"crypto/x509/pkix"
@@ -23,12 +25,41 @@ import (
 
 var (
 	// NotBefore will be CertificateTimeDriftBuffer in the past to account for
-	// time drift between different servers.
+	// time drift between different servers. This is the default used when the
+	// CA configuration doesn't set CertificateTimeDriftBuffer.
 	CertificateTimeDriftBuffer = time.Minute
 
 	ErrNotInitialized = errors.New("provider not initialized")
 )
 
+// TimeDriftBuffer returns the configured CertificateTimeDriftBuffer, or the
+// package default CertificateTimeDriftBuffer if it isn't set.
+func TimeDriftBuffer(c structs.CommonCAProviderConfig) time.Duration {
+	if c.CertificateTimeDriftBuffer == 0 {
+		return CertificateTimeDriftBuffer
+	}
+	return c.CertificateTimeDriftBuffer
+}
+
+// LeafNotBeforeBackdate returns how far in the past to set the NotBefore of
+// leaf certs, which is the drift buffer unless configured separately.
+func LeafNotBeforeBackdate(c structs.CommonCAProviderConfig) time.Duration {
+	if c.LeafNotBeforeBackdate != nil {
+		return *c.LeafNotBeforeBackdate
+	}
+	return TimeDriftBuffer(c)
+}
+
+// IntermediateNotBeforeBackdate returns how far in the past to set the
+// NotBefore of intermediate and cross-signed certs, which is the drift buffer
+// unless configured separately.
+func IntermediateNotBeforeBackdate(c structs.CommonCAProviderConfig) time.Duration {
+	if c.IntermediateNotBeforeBackdate != nil {
+		return *c.IntermediateNotBeforeBackdate
+	}
+	return TimeDriftBuffer(c)
+}
+
 type ConsulProvider struct {
 	Delegate ConsulProviderStateDelegate
 
@@ -39,6 +70,21 @@ type ConsulProvider struct {
 	spiffeID  *connect.SpiffeIDSigning
 	logger    hclog.Logger
 
+	// ocspServer is embedded in signed leaf certs when set.
+	ocspServer string
+
+	// minLeafCertTTL is the shortest lifetime SignWithTTL issues. Zero uses
+	// structs.MinLeafCertTTL.
+	minLeafCertTTL time.Duration
+
+	// intermediateSubject is requested for this provider's intermediate and
+	// enforced on the intermediates it signs for secondaries.
+	intermediateSubject intermediateSubject
+
+	// rsaSigAlgo is used instead of the default to sign leaf certs when the
+	// CA key is RSA. It is unset unless configured.
+	rsaSigAlgo x509.SignatureAlgorithm
+
 	// testState is only used to test Consul leader's handling of providers that
 	// need to persist state. Consul provider actually manages it's state directly
 	// in the FSM since it is highly sensitive not (root private keys) not just
@@ -47,6 +93,9 @@ type ConsulProvider struct {
 	// and is a lot more boilerplate to test this for equivalent functionality.
 	testState map[string]string
 
+	// intermediateExpiry caches the expiry of the active intermediate.
+	intermediateExpiry certExpiryCache
+
 	sync.RWMutex
 }
 
@@ -70,13 +119,32 @@ func (c *ConsulProvider) Configure(cfg ProviderConfig) error {
 	// Parse the raw config and update our ID.
 	config, err := ParseConsulCAConfig(cfg.RawConfig)
 	if err != nil {
-		return err
+		return WrapProviderError(ErrProviderMisconfigured, err)
 	}
 	c.config = config
 	c.id = hexStringHash(fmt.Sprintf("%s,%s,%s,%d,%v", config.PrivateKey, config.RootCert, config.PrivateKeyType, config.PrivateKeyBits, cfg.IsPrimary))
+	if config.ExternalRootCert != "" {
+		if !cfg.IsPrimary {
+			return WrapProviderError(ErrProviderMisconfigured,
+				fmt.Errorf("ExternalRootCert can only be set in the primary datacenter"))
+		}
+		// Include the certs so that replacing the intermediate or the root
+		// results in fresh provider state.
+		c.id = hexStringHash(fmt.Sprintf("%s,%s,%s,%v", config.PrivateKey, config.IntermediateCert, config.ExternalRootCert, cfg.IsPrimary))
+	}
 	c.clusterID = cfg.ClusterID
 	c.isPrimary = cfg.IsPrimary
 	c.spiffeID = connect.SpiffeIDSigningForCluster(&structs.CAConfiguration{ClusterID: c.clusterID})
+	c.ocspServer = cfg.OCSPResponderURL
+	c.minLeafCertTTL = cfg.MinLeafCertTTL
+	c.intermediateSubject = newIntermediateSubject(cfg)
+	c.rsaSigAlgo = x509.UnknownSignatureAlgorithm
+	if cfg.SignatureAlgorithm != "" {
+		c.rsaSigAlgo, err = connect.RSASigAlgo(cfg.SignatureAlgorithm)
+		if err != nil {
+			return WrapProviderError(ErrProviderMisconfigured, err)
+		}
+	}
 
 	// Passthrough test state for state handling tests. See testState doc.
 	c.parseTestState(cfg.RawConfig, cfg.State)
@@ -91,9 +159,13 @@ func (c *ConsulProvider) Configure(cfg ProviderConfig) error {
 		return nil
 	}
 
-	oldIDs := []string{
-		hexStringHash(fmt.Sprintf("%s,%s,%v", config.PrivateKey, config.RootCert, cfg.IsPrimary)),
-		fmt.Sprintf("%s,%s", config.PrivateKey, config.RootCert),
+	// Old ID schemes predate ExternalRootCert so there is nothing to migrate.
+	var oldIDs []string
+	if config.ExternalRootCert == "" {
+		oldIDs = []string{
+			hexStringHash(fmt.Sprintf("%s,%s,%v", config.PrivateKey, config.RootCert, cfg.IsPrimary)),
+			fmt.Sprintf("%s,%s", config.PrivateKey, config.RootCert),
+		}
 	}
 
 	// Check if there are any entries with old ID schemes.
@@ -175,10 +247,24 @@ func (c *ConsulProvider) GenerateRoot() error {
 		return nil
 	}
 
-	// Generate a private key if needed
 	newState := *providerState
+	if c.config.ExternalRootCert != "" {
+		// The root key is offline so there is nothing to generate, the
+		// configured intermediate signs everything.
+		newState.PrivateKey = c.config.PrivateKey
+		newState.RootCert = c.config.ExternalRootCert
+		newState.IntermediateCert = c.config.IntermediateCert
+		args := &structs.CARequest{
+			Op:            structs.CAOpSetProviderState,
+			ProviderState: &newState,
+		}
+		_, err := c.Delegate.ApplyCARequest(args)
+		return err
+	}
+
+	// Generate a private key if needed
 	if c.config.PrivateKey == "" {
-		_, pk, err := connect.GeneratePrivateKeyWithConfig(c.config.PrivateKeyType, c.config.PrivateKeyBits)
+		_, pk, err := connect.GeneratePrivateKeyWithType(c.config.PrivateKeyType, c.config.PrivateKeyBits)
 		if err != nil {
 			return err
 		}
@@ -229,12 +315,12 @@ func (c *ConsulProvider) GenerateIntermediateCSR() (string, error) {
 	}
 
 	// Create a new private key and CSR.
-	signer, pk, err := connect.GeneratePrivateKeyWithConfig(c.config.PrivateKeyType, c.config.PrivateKeyBits)
+	signer, pk, err := connect.GeneratePrivateKeyWithType(c.config.PrivateKeyType, c.config.PrivateKeyBits)
 	if err != nil {
 		return "", err
 	}
 
-	csr, err := connect.CreateCACSR(c.spiffeID, signer)
+	csr, err := connect.CreateCACSRWithSubject(c.spiffeID, signer, c.intermediateSubject.apply(pkix.Name{}))
 	if err != nil {
 		return "", err
 	}
@@ -290,9 +376,10 @@ func (c *ConsulProvider) SetIntermediate(intermediatePEM, rootPEM string) error
 }
 
 // We aren't maintaining separate root/intermediate CAs for the builtin
-// provider, so just return the root.
+// provider, so just return the root. The exception is a primary with an
+// ExternalRootCert, where the configured intermediate signs leaf certs.
 func (c *ConsulProvider) ActiveIntermediate() (string, error) {
-	if c.isPrimary {
+	if c.isPrimary && c.config.ExternalRootCert == "" {
 		return c.ActiveRoot()
 	}
 
@@ -304,6 +391,37 @@ func (c *ConsulProvider) ActiveIntermediate() (string, error) {
 	return providerState.IntermediateCert, nil
 }
 
+// RetainedRootKeyStateID returns the ID of the provider state entry that holds
+// the private key of the rotated out root with the given ID.
+func RetainedRootKeyStateID(rootID string) string {
+	return "retained-root-key:" + rootID
+}
+
+// RootPrivateKey implements RootKeyExporter. Only a primary without an
+// ExternalRootCert holds the key of its root.
+func (c *ConsulProvider) RootPrivateKey() (string, error) {
+	if !c.isPrimary || c.config.ExternalRootCert != "" {
+		return "", nil
+	}
+
+	providerState, err := c.getState()
+	if err != nil {
+		return "", err
+	}
+
+	return providerState.PrivateKey, nil
+}
+
+// IntermediateExpiry implements IntermediateExpirer. The active intermediate
+// is read from the state store and only parsed when it has changed.
+func (c *ConsulProvider) IntermediateExpiry() (notBefore, notAfter time.Time, err error) {
+	pem, err := c.ActiveIntermediate()
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return c.intermediateExpiry.Validity(pem)
+}
+
 // We aren't maintaining separate root/intermediate CAs for the builtin
 // provider, so just return the root.
 func (c *ConsulProvider) GenerateIntermediate() (string, error) {
@@ -327,9 +445,52 @@ func (c *ConsulProvider) Cleanup(_ bool, _ map[string]interface{}) error {
 	return nil
 }
 
+// HealthCheck makes sure the provider state is present and holds a usable
+// signing key and an unexpired signing cert.
+func (c *ConsulProvider) HealthCheck() error {
+	providerState, err := c.getState()
+	if err != nil {
+		return err
+	}
+
+	if _, err := connect.ParseSigner(providerState.PrivateKey); err != nil {
+		return fmt.Errorf("error parsing private key: %s", err)
+	}
+
+	signingPEM := providerState.RootCert
+	if !c.isPrimary || c.config.ExternalRootCert != "" {
+		signingPEM = providerState.IntermediateCert
+	}
+	if signingPEM == "" {
+		return fmt.Errorf("no signing certificate is set")
+	}
+	cert, err := connect.ParseCert(signingPEM)
+	if err != nil {
+		return fmt.Errorf("error parsing signing cert: %s", err)
+	}
+	if time.Now().After(cert.NotAfter) {
+		return fmt.Errorf("signing cert expired at %s", cert.NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// leafSigAlgo returns the algorithm to sign leaf certs with using signer. The
+// configured algorithm only applies to RSA keys.
+func (c *ConsulProvider) leafSigAlgo(signer crypto.Signer) x509.SignatureAlgorithm {
+	if _, ok := signer.Public().(*rsa.PublicKey); ok && c.rsaSigAlgo != x509.UnknownSignatureAlgorithm {
+		return c.rsaSigAlgo
+	}
+	return connect.SigAlgoForKey(signer)
+}
+
 // Sign returns a new certificate valid for the given SpiffeIDService
 // using the current CA.
 func (c *ConsulProvider) Sign(csr *x509.CertificateRequest) (string, error) {
+	return c.SignWithTTL(csr, 0)
+}
+
+// SignWithTTL implements SignerWithTTL.
+func (c *ConsulProvider) SignWithTTL(csr *x509.CertificateRequest, ttl time.Duration) (string, error) {
 	connect.HackSANExtensionForCSR(csr)
 
 	// Lock during the signing so we don't use the same index twice
@@ -365,6 +526,11 @@ func (c *ConsulProvider) Sign(csr *x509.CertificateRequest) (string, error) {
 		return "", err
 	}
 
+	extKeyUsage, err := connect.LeafExtKeyUsage(csr)
+	if err != nil {
+		return "", err
+	}
+
 	// Parse the CA cert
 	certPEM, err := c.ActiveIntermediate()
 	if err != nil {
@@ -383,10 +549,11 @@ func (c *ConsulProvider) Sign(csr *x509.CertificateRequest) (string, error) {
 	// Cert template for generation
 	sn := &big.Int{}
 	sn.SetUint64(nextSerial)
-	// Sign the certificate valid from 1 minute in the past, this helps it be
-	// accepted right away even when nodes are not in close time sync across the
-	// cluster. A minute is more than enough for typical DC clock drift.
-	effectiveNow := time.Now().Add(-1 * time.Minute)
+	// Sign the certificate valid from the drift buffer in the past, this helps
+	// it be accepted right away even when nodes are not in close time sync
+	// across the cluster. The default of a minute is more than enough for
+	// typical DC clock drift.
+	effectiveNow := time.Now().Add(-1 * LeafNotBeforeBackdate(c.config.CommonCAProviderConfig))
 	template := x509.Certificate{
 		SerialNumber: sn,
 		URIs:         csr.URIs,
@@ -394,7 +561,7 @@ func (c *ConsulProvider) Sign(csr *x509.CertificateRequest) (string, error) {
 		// We use the correct signature algorithm for the CA key we are signing with
 		// regardless of the algorithm used to sign the CSR signature above since
 		// the leaf might use a different key type.
-		SignatureAlgorithm:    connect.SigAlgoForKey(signer),
+		SignatureAlgorithm:    c.leafSigAlgo(signer),
 		PublicKeyAlgorithm:    csr.PublicKeyAlgorithm,
 		PublicKey:             csr.PublicKey,
 		BasicConstraintsValid: true,
@@ -402,17 +569,17 @@ func (c *ConsulProvider) Sign(csr *x509.CertificateRequest) (string, error) {
 			x509.KeyUsageKeyAgreement |
 			x509.KeyUsageDigitalSignature |
 			x509.KeyUsageKeyEncipherment,
-		ExtKeyUsage: []x509.ExtKeyUsage{
-			x509.ExtKeyUsageClientAuth,
-			x509.ExtKeyUsageServerAuth,
-		},
-		NotAfter:       effectiveNow.Add(c.config.LeafCertTTL),
+		ExtKeyUsage:    extKeyUsage,
+		NotAfter:       effectiveNow.Add(structs.ClampLeafCertTTLWithMin(ttl, c.minLeafCertTTL, c.config.LeafCertTTL)),
 		NotBefore:      effectiveNow,
 		AuthorityKeyId: keyId,
 		SubjectKeyId:   subjectKeyID,
 		DNSNames:       csr.DNSNames,
 		IPAddresses:    csr.IPAddresses,
 	}
+	if c.ocspServer != "" {
+		template.OCSPServer = []string{c.ocspServer}
+	}
 
 	// Create the certificate, PEM encode it and return that value.
 	var buf bytes.Buffer
@@ -430,11 +597,70 @@ func (c *ConsulProvider) Sign(csr *x509.CertificateRequest) (string, error) {
 	return buf.String(), nil
 }
 
+// GenerateCRL implements CRLSigner. The returned CRL is DER encoded and
+// signed by the active intermediate, which issues all leaf certs in this
+// datacenter.
+func (c *ConsulProvider) GenerateCRL(revoked []*structs.CARevokedCert, number uint64) ([]byte, error) {
+	providerState, err := c.getState()
+	if err != nil {
+		return nil, err
+	}
+
+	// In a secondary the intermediate key is stored alongside the
+	// intermediate cert; in the primary the root signs leaf certs directly.
+	signer, err := connect.ParseSigner(providerState.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	if signer == nil {
+		return nil, ErrNotInitialized
+	}
+
+	certPEM, err := c.ActiveIntermediate()
+	if err != nil {
+		return nil, err
+	}
+	caCert, err := connect.ParseCert(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing CA cert: %s", err)
+	}
+
+	entries := make([]pkix.RevokedCertificate, 0, len(revoked))
+	for _, r := range revoked {
+		sn, err := connect.ParseSerialNumber(r.SerialNumber)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, pkix.RevokedCertificate{
+			SerialNumber:   sn,
+			RevocationTime: r.RevokedAt,
+		})
+	}
+
+	now := time.Now()
+	template := x509.RevocationList{
+		SignatureAlgorithm:  connect.SigAlgoForKey(signer),
+		RevokedCertificates: entries,
+		Number:              new(big.Int).SetUint64(number),
+		ThisUpdate:          now.Add(-1 * LeafNotBeforeBackdate(c.config.CommonCAProviderConfig)),
+		NextUpdate:          now.Add(c.config.LeafCertTTL),
+	}
+	crl, err := x509.CreateRevocationList(rand.Reader, &template, caCert, signer)
+	if err != nil {
+		return nil, fmt.Errorf("error generating CRL: %s", err)
+	}
+	return crl, nil
+}
+
 // SignIntermediate will validate the CSR to ensure the trust domain in the
 // URI SAN matches the local one and that basic constraints for a CA certificate
 // are met. It should return a signed CA certificate with a path length constraint
 // of 0 to ensure that the certificate cannot be used to generate further CA certs.
 func (c *ConsulProvider) SignIntermediate(csr *x509.CertificateRequest) (string, error) {
+	if c.config.ExternalRootCert != "" {
+		return "", errExternalRootKey("sign intermediate certificates for secondary datacenters")
+	}
+
 	providerState, err := c.getState()
 	if err != nil {
 		return "", err
@@ -469,10 +695,11 @@ func (c *ConsulProvider) SignIntermediate(csr *x509.CertificateRequest) (string,
 	// Cert template for generation
 	sn := &big.Int{}
 	sn.SetUint64(nextSerial)
-	// Sign the certificate valid from 1 minute in the past, this helps it be
-	// accepted right away even when nodes are not in close time sync across the
-	// cluster. A minute is more than enough for typical DC clock drift.
-	effectiveNow := time.Now().Add(-1 * CertificateTimeDriftBuffer)
+	// Sign the certificate valid from the drift buffer in the past, this helps
+	// it be accepted right away even when nodes are not in close time sync
+	// across the cluster. The default of a minute is more than enough for
+	// typical DC clock drift.
+	effectiveNow := time.Now().Add(-1 * IntermediateNotBeforeBackdate(c.config.CommonCAProviderConfig))
 	template := x509.Certificate{
 		SerialNumber:          sn,
 		DNSNames:              csr.DNSNames,
@@ -480,7 +707,7 @@ func (c *ConsulProvider) SignIntermediate(csr *x509.CertificateRequest) (string,
 		IPAddresses:           csr.IPAddresses,
 		URIs:                  csr.URIs,
 		ExtraExtensions:       csr.ExtraExtensions,
-		Subject:               csr.Subject,
+		Subject:               c.intermediateSubject.apply(csr.Subject),
 		Signature:             csr.Signature,
 		SignatureAlgorithm:    connect.SigAlgoForKey(signer),
 		PublicKeyAlgorithm:    csr.PublicKeyAlgorithm,
@@ -520,6 +747,9 @@ func (c *ConsulProvider) CrossSignCA(cert *x509.Certificate) (string, error) {
 	if c.config.DisableCrossSigning {
 		return "", errors.New("cross-signing disabled")
 	}
+	if c.config.ExternalRootCert != "" {
+		return "", errExternalRootKey("cross-sign a CA certificate")
+	}
 
 	// Get the provider state
 	providerState, err := c.getState()
@@ -555,10 +785,11 @@ func (c *ConsulProvider) CrossSignCA(cert *x509.Certificate) (string, error) {
 	template.SignatureAlgorithm = rootCA.SignatureAlgorithm
 	template.AuthorityKeyId = keyId
 
-	// Sign the certificate valid from 1 minute in the past, this helps it be
-	// accepted right away even when nodes are not in close time sync across the
-	// cluster. A minute is more than enough for typical DC clock drift.
-	effectiveNow := time.Now().Add(-1 * time.Minute)
+	// Sign the certificate valid from the drift buffer in the past, this helps
+	// it be accepted right away even when nodes are not in close time sync
+	// across the cluster. The default of a minute is more than enough for
+	// typical DC clock drift.
+	effectiveNow := time.Now().Add(-1 * IntermediateNotBeforeBackdate(c.config.CommonCAProviderConfig))
 	template.NotBefore = effectiveNow
 	// This cross-signed cert is only needed during rotation, and only while old
 	// leaf certs are still in use. They expire within 3 days currently so 7 is
@@ -581,9 +812,20 @@ func (c *ConsulProvider) CrossSignCA(cert *x509.Certificate) (string, error) {
 	return buf.String(), nil
 }
 
-// SupportsCrossSigning implements Provider
-func (c *ConsulProvider) SupportsCrossSigning() (bool, error) {
-	return !c.config.DisableCrossSigning, nil
+// Capabilities implements CapabilityReporter
+func (c *ConsulProvider) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{
+		CrossSigning: !c.config.DisableCrossSigning && c.config.ExternalRootCert == "",
+		ExternalRoot: c.config.ExternalRootCert != "",
+		KeyTypes:     []string{"ec", "rsa", "ed25519"},
+	}
+}
+
+// errExternalRootKey is returned for operations that need the private key of
+// a root configured with ExternalRootCert.
+func errExternalRootKey(op string) error {
+	return fmt.Errorf("cannot %s: the root is external (ExternalRootCert is set) "+
+		"and Consul does not hold its private key", op)
 }
 
 // getState returns the current provider state from the state delegate, and returns
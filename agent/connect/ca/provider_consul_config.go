@@ -1,7 +1,11 @@
 package ca
 
 import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/consul/agent/connect"
@@ -26,6 +30,25 @@ func ParseConsulCAConfig(raw map[string]interface{}) (*structs.ConsulCAProviderC
 		return nil, fmt.Errorf("error decoding config: %s", err)
 	}
 
+	if config.CABundle != "" {
+		if config.PrivateKey != "" || config.RootCert != "" {
+			return nil, fmt.Errorf("CABundle cannot be combined with PrivateKey or RootCert")
+		}
+		privateKey, rootCert, err := parseCABundle(config.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CABundle: %v", err)
+		}
+		config.PrivateKey, config.RootCert = privateKey, rootCert
+	}
+
+	if config.ExternalRootCert != "" {
+		if err := validateExternalRoot(&config); err != nil {
+			return nil, err
+		}
+	} else if config.IntermediateCert != "" {
+		return nil, fmt.Errorf("IntermediateCert can only be set along with ExternalRootCert")
+	}
+
 	if config.PrivateKey == "" && config.RootCert != "" {
 		return nil, fmt.Errorf("must provide a private key when providing a root cert")
 	}
@@ -41,6 +64,133 @@ func ParseConsulCAConfig(raw map[string]interface{}) (*structs.ConsulCAProviderC
 	return &config, nil
 }
 
+// parseCABundle splits a PEM bundle into the private key and the RootCert
+// value to use for it. The bundle must hold exactly one private key, one
+// self-signed root and at most one intermediate signed by that root. The key
+// must match the signing cert: the intermediate if there is one, otherwise the
+// root. With an intermediate the returned RootCert is the intermediate
+// followed by the root, so that the first cert is the one that signs leaves.
+func parseCABundle(bundle string) (privateKey, rootCert string, err error) {
+	var root, intermediate *x509.Certificate
+	var rootPEM, intermediatePEM string
+
+	rest := []byte(bundle)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		encoded := string(pem.EncodeToMemory(block))
+
+		switch {
+		case strings.HasSuffix(block.Type, "PRIVATE KEY"):
+			if privateKey != "" {
+				return "", "", fmt.Errorf("bundle contains more than one private key")
+			}
+			privateKey = encoded
+
+		case block.Type == "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return "", "", fmt.Errorf("error parsing certificate: %v", err)
+			}
+			if bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil {
+				if root != nil {
+					return "", "", fmt.Errorf("bundle contains more than one root certificate")
+				}
+				root, rootPEM = cert, encoded
+			} else {
+				if intermediate != nil {
+					return "", "", fmt.Errorf("bundle contains more than one intermediate certificate")
+				}
+				intermediate, intermediatePEM = cert, encoded
+			}
+
+		default:
+			return "", "", fmt.Errorf("unexpected PEM block type %q", block.Type)
+		}
+	}
+
+	if privateKey == "" {
+		return "", "", fmt.Errorf("bundle does not contain a private key")
+	}
+	if root == nil {
+		return "", "", fmt.Errorf("bundle does not contain a self-signed root certificate")
+	}
+
+	signingCert, signingName := root, "root"
+	rootCert = rootPEM
+	if intermediate != nil {
+		if !intermediate.IsCA {
+			return "", "", fmt.Errorf("intermediate is not a CA certificate")
+		}
+		if err := intermediate.CheckSignatureFrom(root); err != nil {
+			return "", "", fmt.Errorf("intermediate was not signed by the root: %v", err)
+		}
+		signingCert, signingName = intermediate, "intermediate"
+		rootCert = intermediatePEM + rootPEM
+	}
+
+	if err := checkKeyMatchesCert(privateKey, signingCert, signingName); err != nil {
+		return "", "", err
+	}
+
+	return privateKey, rootCert, nil
+}
+
+// validateExternalRoot checks the config for running as an intermediate
+// signer under an offline root: IntermediateCert must be a CA cert signed by
+// ExternalRootCert and PrivateKey must be the intermediate's key.
+func validateExternalRoot(config *structs.ConsulCAProviderConfig) error {
+	if config.RootCert != "" {
+		return fmt.Errorf("ExternalRootCert cannot be combined with RootCert")
+	}
+	if config.IntermediateCert == "" || config.PrivateKey == "" {
+		return fmt.Errorf("ExternalRootCert requires IntermediateCert and its PrivateKey")
+	}
+
+	root, err := connect.ParseCert(config.ExternalRootCert)
+	if err != nil {
+		return fmt.Errorf("error parsing ExternalRootCert: %v", err)
+	}
+	if !root.IsCA {
+		return fmt.Errorf("ExternalRootCert is not a CA certificate")
+	}
+	intermediate, err := connect.ParseCert(config.IntermediateCert)
+	if err != nil {
+		return fmt.Errorf("error parsing IntermediateCert: %v", err)
+	}
+	if !intermediate.IsCA {
+		return fmt.Errorf("IntermediateCert is not a CA certificate")
+	}
+	if err := intermediate.CheckSignatureFrom(root); err != nil {
+		return fmt.Errorf("IntermediateCert was not signed by ExternalRootCert: %v", err)
+	}
+	return checkKeyMatchesCert(config.PrivateKey, intermediate, "intermediate")
+}
+
+// checkKeyMatchesCert returns an error if privateKey is not the key for cert.
+// certName describes cert in the error.
+func checkKeyMatchesCert(privateKey string, cert *x509.Certificate, certName string) error {
+	signer, err := connect.ParseSigner(privateKey)
+	if err != nil {
+		return fmt.Errorf("error parsing private key: %v", err)
+	}
+	keyBytes, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return err
+	}
+	certBytes, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(keyBytes, certBytes) {
+		return fmt.Errorf("private key does not match the %s certificate", certName)
+	}
+	return nil
+}
+
 func defaultConsulCAProviderConfig() structs.ConsulCAProviderConfig {
 	return structs.ConsulCAProviderConfig{
 		CommonCAProviderConfig: defaultCommonConfig(),
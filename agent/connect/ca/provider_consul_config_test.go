@@ -0,0 +1,163 @@
+package ca
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/connect"
+)
+
+func TestParseConsulCAConfig_CABundle(t *testing.T) {
+	root := connect.TestCA(t, nil)
+	other := connect.TestCA(t, nil)
+	// The cross-signed cert of a second CA is an intermediate signed by root.
+	inter := connect.TestCA(t, root)
+
+	t.Run("root and key", func(t *testing.T) {
+		conf, err := ParseConsulCAConfig(map[string]interface{}{
+			"CABundle": root.RootCert + root.SigningKey,
+		})
+		require.NoError(t, err)
+		require.Equal(t, root.SigningKey, conf.PrivateKey)
+		require.Equal(t, root.RootCert, conf.RootCert)
+	})
+
+	t.Run("root, intermediate and key", func(t *testing.T) {
+		conf, err := ParseConsulCAConfig(map[string]interface{}{
+			"CABundle": inter.SigningKey + root.RootCert + inter.SigningCert,
+		})
+		require.NoError(t, err)
+		require.Equal(t, inter.SigningKey, conf.PrivateKey)
+		// The intermediate comes first since it signs leaves.
+		require.Equal(t, inter.SigningCert+root.RootCert, conf.RootCert)
+	})
+
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+		err    string
+	}{
+		{
+			name:   "key for a different root",
+			config: map[string]interface{}{"CABundle": root.RootCert + other.SigningKey},
+			err:    "private key does not match the root certificate",
+		},
+		{
+			name:   "key for the root instead of the intermediate",
+			config: map[string]interface{}{"CABundle": root.RootCert + inter.SigningCert + root.SigningKey},
+			err:    "private key does not match the intermediate certificate",
+		},
+		{
+			name:   "intermediate from a different root",
+			config: map[string]interface{}{"CABundle": other.RootCert + inter.SigningCert + inter.SigningKey},
+			err:    "intermediate was not signed by the root",
+		},
+		{
+			name:   "missing key",
+			config: map[string]interface{}{"CABundle": root.RootCert},
+			err:    "bundle does not contain a private key",
+		},
+		{
+			name:   "missing root",
+			config: map[string]interface{}{"CABundle": inter.SigningCert + inter.SigningKey},
+			err:    "bundle does not contain a self-signed root certificate",
+		},
+		{
+			name:   "two keys",
+			config: map[string]interface{}{"CABundle": root.RootCert + root.SigningKey + other.SigningKey},
+			err:    "more than one private key",
+		},
+		{
+			name: "combined with PrivateKey",
+			config: map[string]interface{}{
+				"CABundle":   root.RootCert + root.SigningKey,
+				"PrivateKey": root.SigningKey,
+			},
+			err: "CABundle cannot be combined with PrivateKey or RootCert",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseConsulCAConfig(tc.config)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestParseConsulCAConfig_ExternalRootCert(t *testing.T) {
+	root := connect.TestCA(t, nil)
+	other := connect.TestCA(t, nil)
+	inter := connect.TestCA(t, root)
+
+	conf, err := ParseConsulCAConfig(map[string]interface{}{
+		"ExternalRootCert": root.RootCert,
+		"IntermediateCert": inter.SigningCert,
+		"PrivateKey":       inter.SigningKey,
+	})
+	require.NoError(t, err)
+	require.Equal(t, root.RootCert, conf.ExternalRootCert)
+	require.Equal(t, inter.SigningCert, conf.IntermediateCert)
+	require.Empty(t, conf.RootCert)
+
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+		err    string
+	}{
+		{
+			name: "combined with RootCert",
+			config: map[string]interface{}{
+				"ExternalRootCert": root.RootCert,
+				"IntermediateCert": inter.SigningCert,
+				"PrivateKey":       inter.SigningKey,
+				"RootCert":         root.RootCert,
+			},
+			err: "ExternalRootCert cannot be combined with RootCert",
+		},
+		{
+			name: "missing intermediate",
+			config: map[string]interface{}{
+				"ExternalRootCert": root.RootCert,
+				"PrivateKey":       inter.SigningKey,
+			},
+			err: "ExternalRootCert requires IntermediateCert and its PrivateKey",
+		},
+		{
+			name: "key for the root instead of the intermediate",
+			config: map[string]interface{}{
+				"ExternalRootCert": root.RootCert,
+				"IntermediateCert": inter.SigningCert,
+				"PrivateKey":       root.SigningKey,
+			},
+			err: "private key does not match the intermediate certificate",
+		},
+		{
+			name: "intermediate from a different root",
+			config: map[string]interface{}{
+				"ExternalRootCert": other.RootCert,
+				"IntermediateCert": inter.SigningCert,
+				"PrivateKey":       inter.SigningKey,
+			},
+			err: "IntermediateCert was not signed by ExternalRootCert",
+		},
+		{
+			name: "intermediate without an external root",
+			config: map[string]interface{}{
+				"IntermediateCert": inter.SigningCert,
+				"PrivateKey":       inter.SigningKey,
+			},
+			err: "IntermediateCert can only be set along with ExternalRootCert",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseConsulCAConfig(tc.config)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
@@ -2,6 +2,10 @@ package ca
 
 import (
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -52,10 +56,11 @@ func testConsulCAConfig() *structs.CAConfiguration {
 
 func testProviderConfig(caCfg *structs.CAConfiguration) ProviderConfig {
 	return ProviderConfig{
-		ClusterID:  caCfg.ClusterID,
-		Datacenter: "dc1",
-		IsPrimary:  true,
-		RawConfig:  caCfg.Config,
+		ClusterID:        caCfg.ClusterID,
+		Datacenter:       "dc1",
+		IsPrimary:        true,
+		RawConfig:        caCfg.Config,
+		OCSPResponderURL: caCfg.OCSPResponderURL,
 	}
 }
 
@@ -99,6 +104,20 @@ func TestConsulCAProvider_Bootstrap(t *testing.T) {
 	require.WithinDuration(expectedNotAfter, parsed.NotAfter, 10*time.Minute, "expected parsed cert ttl to be the same as the value configured")
 }
 
+func TestConsulCAProvider_Configure_Misconfigured(t *testing.T) {
+	t.Parallel()
+
+	conf := testConsulCAConfig()
+	conf.Config["RootCert"] = "not a cert"
+	delegate := newMockDelegate(t, conf)
+
+	provider := TestConsulProvider(t, delegate)
+	err := provider.Configure(testProviderConfig(conf))
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrProviderMisconfigured))
+	require.Contains(t, err.Error(), "must provide a private key when providing a root cert")
+}
+
 func TestConsulCAProvider_Bootstrap_WithCert(t *testing.T) {
 	t.Parallel()
 
@@ -245,6 +264,545 @@ func TestConsulCAProvider_SignLeaf(t *testing.T) {
 	}
 }
 
+// leafExtKeyUsageCases are the extended key usage requests every provider
+// must honor or reject when signing leaf certs.
+var leafExtKeyUsageCases = map[string]struct {
+	ext       func(t *testing.T) []pkix.Extension
+	expect    []x509.ExtKeyUsage
+	expectErr string
+}{
+	"default": {
+		ext:    func(t *testing.T) []pkix.Extension { return nil },
+		expect: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+	},
+	"server only": {
+		ext:    testExtKeyUsageExtension(x509.ExtKeyUsageServerAuth),
+		expect: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	},
+	"client only": {
+		ext:    testExtKeyUsageExtension(x509.ExtKeyUsageClientAuth),
+		expect: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	},
+	"client and server": {
+		ext:    testExtKeyUsageExtension(x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth),
+		expect: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+	},
+	"unsupported usage": {
+		ext: func(t *testing.T) []pkix.Extension {
+			// id-kp-codeSigning
+			bs, err := asn1.Marshal([]asn1.ObjectIdentifier{{1, 3, 6, 1, 5, 5, 7, 3, 3}})
+			require.NoError(t, err)
+			return []pkix.Extension{{Id: []int{2, 5, 29, 37}, Value: bs}}
+		},
+		expectErr: "unsupported extended key usage",
+	},
+	"no usage": {
+		ext: func(t *testing.T) []pkix.Extension {
+			bs, err := asn1.Marshal([]asn1.ObjectIdentifier{})
+			require.NoError(t, err)
+			return []pkix.Extension{{Id: []int{2, 5, 29, 37}, Value: bs}}
+		},
+		expectErr: "must include client or server auth",
+	},
+}
+
+func testExtKeyUsageExtension(usages ...x509.ExtKeyUsage) func(t *testing.T) []pkix.Extension {
+	return func(t *testing.T) []pkix.Extension {
+		ext, err := connect.CreateExtKeyUsageExtension(usages...)
+		require.NoError(t, err)
+		return []pkix.Extension{ext}
+	}
+}
+
+func testLeafCSRWithExtensions(t *testing.T, uri connect.CertURI, extensions []pkix.Extension) *x509.CertificateRequest {
+	signer, _, err := connect.GeneratePrivateKey()
+	require.NoError(t, err)
+	raw, err := connect.CreateCSR(uri, signer, nil, nil, extensions...)
+	require.NoError(t, err)
+	csr, err := connect.ParseCSR(raw)
+	require.NoError(t, err)
+	return csr
+}
+
+func TestConsulCAProvider_SignLeaf_OCSPResponderURL(t *testing.T) {
+	t.Parallel()
+
+	for _, ocspURL := range []string{"", "http://ocsp.example.com/ocsp"} {
+		ocspURL := ocspURL
+		t.Run(fmt.Sprintf("url=%q", ocspURL), func(t *testing.T) {
+			conf := testConsulCAConfig()
+			conf.OCSPResponderURL = ocspURL
+			delegate := newMockDelegate(t, conf)
+
+			provider := TestConsulProvider(t, delegate)
+			require.NoError(t, provider.Configure(testProviderConfig(conf)))
+			require.NoError(t, provider.GenerateRoot())
+
+			spiffeService := &connect.SpiffeIDService{
+				Host:       connect.TestClusterID + ".consul",
+				Namespace:  "default",
+				Datacenter: "dc1",
+				Service:    "foo",
+			}
+			csr, _ := connect.TestCSR(t, spiffeService)
+			req, err := connect.ParseCSR(csr)
+			require.NoError(t, err)
+
+			certPEM, err := provider.Sign(req)
+			require.NoError(t, err)
+			cert, err := connect.ParseCert(certPEM)
+			require.NoError(t, err)
+
+			if ocspURL == "" {
+				require.Empty(t, cert.OCSPServer)
+			} else {
+				require.Equal(t, []string{ocspURL}, cert.OCSPServer)
+			}
+		})
+	}
+}
+
+func TestConsulCAProvider_SignLeaf_DNSSANs(t *testing.T) {
+	t.Parallel()
+
+	conf := testConsulCAConfig()
+	delegate := newMockDelegate(t, conf)
+	provider := TestConsulProvider(t, delegate)
+	require.NoError(t, provider.Configure(testProviderConfig(conf)))
+	require.NoError(t, provider.GenerateRoot())
+
+	spiffeService := &connect.SpiffeIDService{
+		Host:       connect.TestClusterID + ".consul",
+		Namespace:  "default",
+		Datacenter: "dc1",
+		Service:    "foo",
+	}
+	csr := testLeafCSRWithDNSNames(t, spiffeService, []string{"foo.ingress.consul", "*.ingress.dc1.consul"})
+
+	certPEM, err := provider.Sign(csr)
+	require.NoError(t, err)
+	cert, err := connect.ParseCert(certPEM)
+	require.NoError(t, err)
+	require.Len(t, cert.URIs, 1)
+	require.Equal(t, spiffeService.URI().String(), cert.URIs[0].String())
+	require.Equal(t, []string{"foo.ingress.consul", "*.ingress.dc1.consul"}, cert.DNSNames)
+}
+
+func TestConsulCAProvider_SignLeaf_SignatureAlgorithm(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		keyType   string
+		keyBits   int
+		algorithm string
+		expected  x509.SignatureAlgorithm
+	}{
+		{"rsa default", "rsa", 2048, "", x509.SHA256WithRSA},
+		{"rsa sha256", "rsa", 2048, structs.SignatureAlgorithmSHA256WithRSA, x509.SHA256WithRSA},
+		{"rsa sha384", "rsa", 2048, structs.SignatureAlgorithmSHA384WithRSA, x509.SHA384WithRSA},
+		{"rsa sha512", "rsa", 2048, structs.SignatureAlgorithmSHA512WithRSA, x509.SHA512WithRSA},
+		// EC keys keep the hash that suits the curve.
+		{"ec ignores hint", "ec", 256, structs.SignatureAlgorithmSHA384WithRSA, x509.ECDSAWithSHA256},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			conf := testConsulCAConfig()
+			conf.Config["PrivateKeyType"] = tc.keyType
+			conf.Config["PrivateKeyBits"] = tc.keyBits
+			delegate := newMockDelegate(t, conf)
+			provider := TestConsulProvider(t, delegate)
+			cfg := testProviderConfig(conf)
+			cfg.SignatureAlgorithm = tc.algorithm
+			require.NoError(t, provider.Configure(cfg))
+			require.NoError(t, provider.GenerateRoot())
+
+			spiffeService := &connect.SpiffeIDService{
+				Host:       connect.TestClusterID + ".consul",
+				Namespace:  "default",
+				Datacenter: "dc1",
+				Service:    "foo",
+			}
+			raw, _ := connect.TestCSR(t, spiffeService)
+			csr, err := connect.ParseCSR(raw)
+			require.NoError(t, err)
+
+			leafPEM, err := provider.Sign(csr)
+			require.NoError(t, err)
+			leaf, err := connect.ParseCert(leafPEM)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, leaf.SignatureAlgorithm)
+
+			rootPEM, err := provider.ActiveRoot()
+			require.NoError(t, err)
+			require.NoError(t, connect.ValidateLeaf(rootPEM, leafPEM, nil))
+		})
+	}
+
+	t.Run("unknown algorithm", func(t *testing.T) {
+		conf := testConsulCAConfig()
+		provider := TestConsulProvider(t, newMockDelegate(t, conf))
+		cfg := testProviderConfig(conf)
+		cfg.SignatureAlgorithm = "MD5WithRSA"
+		err := provider.Configure(cfg)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, ErrProviderMisconfigured))
+	})
+}
+
+func TestConsulCAProvider_SignLeaf_Namespace(t *testing.T) {
+	t.Parallel()
+
+	conf := testConsulCAConfig()
+	delegate := newMockDelegate(t, conf)
+	provider := TestConsulProvider(t, delegate)
+	require.NoError(t, provider.Configure(testProviderConfig(conf)))
+	require.NoError(t, provider.GenerateRoot())
+
+	rootPEM, err := provider.ActiveRoot()
+	require.NoError(t, err)
+
+	spiffeService := &connect.SpiffeIDService{
+		Host:       connect.TestClusterID + ".consul",
+		Namespace:  "other",
+		Datacenter: "dc1",
+		Service:    "foo",
+	}
+	raw, _ := connect.TestCSR(t, spiffeService)
+	csr, err := connect.ParseCSR(raw)
+	require.NoError(t, err)
+	require.NoError(t, connect.ValidateCSR(csr, spiffeService))
+
+	leafPEM, err := provider.Sign(csr)
+	require.NoError(t, err)
+	leaf, err := connect.ParseCert(leafPEM)
+	require.NoError(t, err)
+	require.Len(t, leaf.URIs, 1)
+	require.Contains(t, leaf.URIs[0].Path, "/ns/other/")
+
+	require.NoError(t, connect.ValidateLeaf(rootPEM, leafPEM, nil, connect.WithNamespace("other")))
+
+	err = connect.ValidateLeaf(rootPEM, leafPEM, nil, connect.WithNamespace("default"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `leaf namespace "other" does not match "default"`)
+}
+
+func TestConsulCAProvider_SignLeaf_Agent(t *testing.T) {
+	t.Parallel()
+
+	conf := testConsulCAConfig()
+	delegate := newMockDelegate(t, conf)
+	provider := TestConsulProvider(t, delegate)
+	require.NoError(t, provider.Configure(testProviderConfig(conf)))
+	require.NoError(t, provider.GenerateRoot())
+
+	rootPEM, err := provider.ActiveRoot()
+	require.NoError(t, err)
+
+	spiffeAgent := &connect.SpiffeIDAgent{
+		Host:       connect.TestClusterID + ".consul",
+		Datacenter: "dc1",
+		Agent:      "node1",
+	}
+	raw, _ := connect.TestCSR(t, spiffeAgent)
+	csr, err := connect.ParseCSR(raw)
+	require.NoError(t, err)
+	require.NoError(t, connect.ValidateCSR(csr, spiffeAgent))
+
+	leafPEM, err := provider.Sign(csr)
+	require.NoError(t, err)
+
+	require.NoError(t, connect.ValidateLeaf(rootPEM, leafPEM, nil, connect.WithCertURI(spiffeAgent)))
+
+	// The agent leaf must not pass as a service or as a different agent.
+	spiffeService := &connect.SpiffeIDService{
+		Host:       connect.TestClusterID + ".consul",
+		Namespace:  "default",
+		Datacenter: "dc1",
+		Service:    "node1",
+	}
+	err = connect.ValidateLeaf(rootPEM, leafPEM, nil, connect.WithCertURI(spiffeService))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "expected a *connect.SpiffeIDService")
+
+	otherAgent := *spiffeAgent
+	otherAgent.Agent = "node2"
+	err = connect.ValidateLeaf(rootPEM, leafPEM, nil, connect.WithCertURI(&otherAgent))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not match")
+}
+
+func testLeafCSRWithDNSNames(t *testing.T, uri connect.CertURI, dnsNames []string) *x509.CertificateRequest {
+	signer, _, err := connect.GeneratePrivateKey()
+	require.NoError(t, err)
+	raw, err := connect.CreateCSR(uri, signer, dnsNames, nil)
+	require.NoError(t, err)
+	csr, err := connect.ParseCSR(raw)
+	require.NoError(t, err)
+	return csr
+}
+
+func TestConsulCAProvider_SignLeaf_ExtKeyUsage(t *testing.T) {
+	t.Parallel()
+
+	conf := testConsulCAConfig()
+	delegate := newMockDelegate(t, conf)
+	provider := TestConsulProvider(t, delegate)
+	require.NoError(t, provider.Configure(testProviderConfig(conf)))
+	require.NoError(t, provider.GenerateRoot())
+
+	spiffeService := &connect.SpiffeIDService{
+		Host:       connect.TestClusterID + ".consul",
+		Namespace:  "default",
+		Datacenter: "dc1",
+		Service:    "foo",
+	}
+
+	for name, tc := range leafExtKeyUsageCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			csr := testLeafCSRWithExtensions(t, spiffeService, tc.ext(t))
+
+			cert, err := provider.Sign(csr)
+			if tc.expectErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+
+			parsed, err := connect.ParseCert(cert)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, parsed.ExtKeyUsage)
+		})
+	}
+}
+
+func TestConsulCAProvider_SignLeaf_TimeDriftBuffer(t *testing.T) {
+	t.Parallel()
+
+	conf := testConsulCAConfig()
+	conf.Config["CertificateTimeDriftBuffer"] = "45m"
+	delegate := newMockDelegate(t, conf)
+	provider := TestConsulProvider(t, delegate)
+	require.NoError(t, provider.Configure(testProviderConfig(conf)))
+	require.NoError(t, provider.GenerateRoot())
+
+	spiffeService := &connect.SpiffeIDService{
+		Host:       connect.TestClusterID + ".consul",
+		Namespace:  "default",
+		Datacenter: "dc1",
+		Service:    "foo",
+	}
+	raw, _ := connect.TestCSR(t, spiffeService)
+	csr, err := connect.ParseCSR(raw)
+	require.NoError(t, err)
+
+	before := time.Now()
+	cert, err := provider.Sign(csr)
+	require.NoError(t, err)
+	after := time.Now()
+
+	parsed, err := connect.ParseCert(cert)
+	require.NoError(t, err)
+
+	// NotBefore is truncated to the second when encoded.
+	require.False(t, parsed.NotBefore.Before(before.Add(-45*time.Minute).Truncate(time.Second)))
+	require.False(t, parsed.NotBefore.After(after.Add(-45*time.Minute)))
+}
+
+func TestConsulCAProvider_NotBeforeBackdate(t *testing.T) {
+	t.Parallel()
+
+	// requireBackdated checks that cert's NotBefore is backdate before the
+	// window in which it was signed. NotBefore is truncated to the second when
+	// encoded.
+	requireBackdated := func(t *testing.T, certPEM string, backdate time.Duration, before, after time.Time) {
+		t.Helper()
+		cert, err := connect.ParseCert(certPEM)
+		require.NoError(t, err)
+		require.False(t, cert.NotBefore.Before(before.Add(-backdate).Truncate(time.Second)))
+		require.False(t, cert.NotBefore.After(after.Add(-backdate)))
+	}
+
+	cases := map[string]struct {
+		config       map[string]interface{}
+		leaf         time.Duration
+		intermediate time.Duration
+	}{
+		"defaults to drift buffer": {
+			config:       map[string]interface{}{"CertificateTimeDriftBuffer": "20m"},
+			leaf:         20 * time.Minute,
+			intermediate: 20 * time.Minute,
+		},
+		"independent": {
+			config: map[string]interface{}{
+				"CertificateTimeDriftBuffer":    "20m",
+				"LeafNotBeforeBackdate":         "5m",
+				"IntermediateNotBeforeBackdate": "40m",
+			},
+			leaf:         5 * time.Minute,
+			intermediate: 40 * time.Minute,
+		},
+		"zero leaf backdate": {
+			config:       map[string]interface{}{"LeafNotBeforeBackdate": "0s"},
+			leaf:         0,
+			intermediate: CertificateTimeDriftBuffer,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			conf := testConsulCAConfig()
+			for k, v := range tc.config {
+				conf.Config[k] = v
+			}
+			delegate := newMockDelegate(t, conf)
+			provider := TestConsulProvider(t, delegate)
+			require.NoError(t, provider.Configure(testProviderConfig(conf)))
+			require.NoError(t, provider.GenerateRoot())
+
+			spiffeService := &connect.SpiffeIDService{
+				Host:       connect.TestClusterID + ".consul",
+				Namespace:  "default",
+				Datacenter: "dc1",
+				Service:    "foo",
+			}
+			raw, _ := connect.TestCSR(t, spiffeService)
+			csr, err := connect.ParseCSR(raw)
+			require.NoError(t, err)
+
+			before := time.Now()
+			leafPEM, err := provider.Sign(csr)
+			require.NoError(t, err)
+			requireBackdated(t, leafPEM, tc.leaf, before, time.Now())
+
+			// The CRL covers leaf certs so it is backdated the same way.
+			before = time.Now()
+			crlDER, err := provider.GenerateCRL(nil, 1)
+			require.NoError(t, err)
+			crl, err := x509.ParseRevocationList(crlDER)
+			require.NoError(t, err)
+			require.False(t, crl.ThisUpdate.Before(before.Add(-tc.leaf).Truncate(time.Second)))
+			require.False(t, crl.ThisUpdate.After(time.Now().Add(-tc.leaf)))
+
+			conf2 := testConsulCAConfig()
+			conf2.CreateIndex = 10
+			delegate2 := newMockDelegate(t, conf2)
+			provider2 := TestConsulProvider(t, delegate2)
+			cfg := testProviderConfig(conf2)
+			cfg.IsPrimary = false
+			cfg.Datacenter = "dc2"
+			require.NoError(t, provider2.Configure(cfg))
+
+			csrPEM, err := provider2.GenerateIntermediateCSR()
+			require.NoError(t, err)
+			interCSR, err := connect.ParseCSR(csrPEM)
+			require.NoError(t, err)
+
+			before = time.Now()
+			intermediatePEM, err := provider.SignIntermediate(interCSR)
+			require.NoError(t, err)
+			requireBackdated(t, intermediatePEM, tc.intermediate, before, time.Now())
+		})
+	}
+}
+
+func TestConsulCAProvider_IntermediateExpiry(t *testing.T) {
+	t.Parallel()
+
+	requireExpiryMatches := func(t *testing.T, provider *ConsulProvider) {
+		t.Helper()
+		pem, err := provider.ActiveIntermediate()
+		require.NoError(t, err)
+		cert, err := connect.ParseCert(pem)
+		require.NoError(t, err)
+
+		notBefore, expiry, err := provider.IntermediateExpiry()
+		require.NoError(t, err)
+		require.Equal(t, cert.NotBefore, notBefore)
+		require.Equal(t, cert.NotAfter, expiry)
+	}
+
+	conf1 := testConsulCAConfig()
+	delegate1 := newMockDelegate(t, conf1)
+	provider1 := TestConsulProvider(t, delegate1)
+	require.NoError(t, provider1.Configure(testProviderConfig(conf1)))
+	require.NoError(t, provider1.GenerateRoot())
+
+	// The primary signs with its root.
+	requireExpiryMatches(t, provider1)
+
+	conf2 := testConsulCAConfig()
+	conf2.CreateIndex = 10
+	delegate2 := newMockDelegate(t, conf2)
+	provider2 := TestConsulProvider(t, delegate2)
+	cfg := testProviderConfig(conf2)
+	cfg.IsPrimary = false
+	cfg.Datacenter = "dc2"
+	require.NoError(t, provider2.Configure(cfg))
+
+	// A secondary has no intermediate until the primary signs one.
+	_, _, err := provider2.IntermediateExpiry()
+	require.Error(t, err)
+
+	testSignIntermediateCrossDC(t, provider1, provider2)
+	requireExpiryMatches(t, provider2)
+}
+
+func TestConsulCAProvider_GenerateCRL(t *testing.T) {
+	t.Parallel()
+
+	conf := testConsulCAConfig()
+	delegate := newMockDelegate(t, conf)
+	provider := TestConsulProvider(t, delegate)
+	require.NoError(t, provider.Configure(testProviderConfig(conf)))
+	require.NoError(t, provider.GenerateRoot())
+
+	rootPEM, err := provider.ActiveRoot()
+	require.NoError(t, err)
+
+	spiffeService := &connect.SpiffeIDService{
+		Host:       connect.TestClusterID + ".consul",
+		Namespace:  "default",
+		Datacenter: "dc1",
+		Service:    "foo",
+	}
+	raw, _ := connect.TestCSR(t, spiffeService)
+	csr, err := connect.ParseCSR(raw)
+	require.NoError(t, err)
+	leafPEM, err := provider.Sign(csr)
+	require.NoError(t, err)
+	leaf, err := connect.ParseCert(leafPEM)
+	require.NoError(t, err)
+
+	// An empty CRL does not affect validation.
+	crl, err := provider.GenerateCRL(nil, 1)
+	require.NoError(t, err)
+	crlPEM := string(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl}))
+	require.NoError(t, connect.ValidateLeaf(rootPEM, leafPEM, nil, connect.WithCRL(crlPEM)))
+
+	// Once the leaf is listed it fails validation.
+	revoked := []*structs.CARevokedCert{{
+		SerialNumber: connect.EncodeSerialNumber(leaf.SerialNumber),
+		RevokedAt:    time.Now(),
+	}}
+	crl, err = provider.GenerateCRL(revoked, 2)
+	require.NoError(t, err)
+	crlPEM = string(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl}))
+	err = connect.ValidateLeaf(rootPEM, leafPEM, nil, connect.WithCRL(crlPEM))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "has been revoked")
+
+	// Without the CRL the leaf is still valid.
+	require.NoError(t, connect.ValidateLeaf(rootPEM, leafPEM, nil))
+}
+
 func TestConsulCAProvider_CrossSignCA(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -406,6 +964,122 @@ func TestConsulProvider_SignIntermediate(t *testing.T) {
 
 }
 
+func TestConsulCAProvider_ExternalRootCert(t *testing.T) {
+	t.Parallel()
+
+	root := connect.TestCA(t, nil)
+	inter := connect.TestCA(t, root)
+
+	conf := testConsulCAConfig()
+	conf.Config["ExternalRootCert"] = root.RootCert
+	conf.Config["IntermediateCert"] = inter.SigningCert
+	conf.Config["PrivateKey"] = inter.SigningKey
+	delegate := newMockDelegate(t, conf)
+	provider := TestConsulProvider(t, delegate)
+	require.NoError(t, provider.Configure(testProviderConfig(conf)))
+	require.NoError(t, provider.GenerateRoot())
+
+	// The external root is published as is and the intermediate signs leaves.
+	rootPEM, err := provider.ActiveRoot()
+	require.NoError(t, err)
+	require.Equal(t, root.RootCert, rootPEM)
+	interPEM, err := provider.ActiveIntermediate()
+	require.NoError(t, err)
+	require.Equal(t, inter.SigningCert, interPEM)
+	require.NoError(t, provider.HealthCheck())
+
+	caps := provider.Capabilities()
+	require.False(t, caps.CrossSigning)
+	require.True(t, caps.ExternalRoot)
+
+	spiffeService := connect.TestSpiffeIDService(t, "foo")
+	raw, _ := connect.TestCSR(t, spiffeService)
+	csr, err := connect.ParseCSR(raw)
+	require.NoError(t, err)
+	leafPEM, err := provider.Sign(csr)
+	require.NoError(t, err)
+	require.NoError(t, connect.ValidateLeaf(rootPEM, leafPEM, []string{interPEM}, connect.WithAuthorityKeyIDCheck()))
+
+	// Anything needing the root's private key is refused.
+	otherRoot, err := connect.ParseCert(connect.TestCA(t, nil).RootCert)
+	require.NoError(t, err)
+	_, err = provider.CrossSignCA(otherRoot)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Consul does not hold its private key")
+
+	_, err = provider.SignIntermediate(csr)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot sign intermediate certificates for secondary datacenters")
+
+	// Secondaries get their intermediate from the primary.
+	secondary := TestConsulProvider(t, newMockDelegate(t, conf))
+	cfg := testProviderConfig(conf)
+	cfg.IsPrimary = false
+	cfg.Datacenter = "dc2"
+	err = secondary.Configure(cfg)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrProviderMisconfigured))
+	require.Contains(t, err.Error(), "ExternalRootCert can only be set in the primary datacenter")
+}
+
+func TestConsulCAProvider_IntermediateCertSubject(t *testing.T) {
+	t.Parallel()
+
+	newSecondary := func(t *testing.T, commonName, organization string) Provider {
+		conf := testConsulCAConfig()
+		conf.CreateIndex = 10
+		provider := TestConsulProvider(t, newMockDelegate(t, conf))
+		cfg := testProviderConfig(conf)
+		cfg.IsPrimary = false
+		cfg.Datacenter = "dc2"
+		cfg.IntermediateCertCommonName = commonName
+		cfg.IntermediateCertOrganization = organization
+		require.NoError(t, provider.Configure(cfg))
+		return provider
+	}
+	signIntermediate := func(t *testing.T, primary, secondary Provider) *x509.Certificate {
+		csrPEM, err := secondary.GenerateIntermediateCSR()
+		require.NoError(t, err)
+		csr, err := connect.ParseCSR(csrPEM)
+		require.NoError(t, err)
+		intermediatePEM, err := primary.SignIntermediate(csr)
+		require.NoError(t, err)
+		intermediate, err := connect.ParseCert(intermediatePEM)
+		require.NoError(t, err)
+		return intermediate
+	}
+
+	conf := testConsulCAConfig()
+	primary := TestConsulProvider(t, newMockDelegate(t, conf))
+	require.NoError(t, primary.Configure(testProviderConfig(conf)))
+	require.NoError(t, primary.GenerateRoot())
+
+	// Without any configuration the intermediate keeps today's empty subject.
+	intermediate := signIntermediate(t, primary, newSecondary(t, "", ""))
+	require.Empty(t, intermediate.Subject.CommonName)
+	require.Empty(t, intermediate.Subject.Organization)
+
+	// The secondary's configured subject is requested in its CSR.
+	intermediate = signIntermediate(t, primary, newSecondary(t, "Secondary CA", "Example"))
+	require.Equal(t, "Secondary CA", intermediate.Subject.CommonName)
+	require.Equal(t, []string{"Example"}, intermediate.Subject.Organization)
+
+	// A subject configured in the primary overrides what the secondary asked
+	// for.
+	conf = testConsulCAConfig()
+	primary = TestConsulProvider(t, newMockDelegate(t, conf))
+	cfg := testProviderConfig(conf)
+	cfg.IntermediateCertCommonName = "Mesh Intermediate CA"
+	cfg.IntermediateCertOrganization = "Example Corp"
+	require.NoError(t, primary.Configure(cfg))
+	require.NoError(t, primary.GenerateRoot())
+
+	intermediate = signIntermediate(t, primary, newSecondary(t, "Secondary CA", "Example"))
+	require.Equal(t, "Mesh Intermediate CA", intermediate.Subject.CommonName)
+	require.Equal(t, []string{"Example Corp"}, intermediate.Subject.Organization)
+	require.Len(t, intermediate.URIs, 1)
+}
+
 func testSignIntermediateCrossDC(t *testing.T, provider1, provider2 Provider) {
 	require := require.New(t)
 
@@ -0,0 +1,492 @@
+package ca
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/hashicorp/go-hclog"
+	"github.com/mitchellh/mapstructure"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/lib"
+	"github.com/hashicorp/consul/proto/pbcaprovider"
+)
+
+// GRPCRequestTimeout is the maximum time we will wait for a single request to
+// the external CA provider.
+const GRPCRequestTimeout = 30 * time.Second
+
+// GRPCProvider implements Provider by proxying to an external CA provider
+// process that implements the pbcaprovider.CAProvider gRPC service.
+type GRPCProvider struct {
+	config    *structs.GRPCCAProviderConfig
+	conn      *grpc.ClientConn
+	client    pbcaprovider.CAProviderClient
+	isPrimary bool
+	spiffeID  *connect.SpiffeIDSigning
+
+	supportsCrossSigning bool
+	intermediateExpiry   certExpiryCache
+
+	// minLeafCertTTL is the shortest lifetime SignWithTTL requests. Zero
+	// uses structs.MinLeafCertTTL.
+	minLeafCertTTL time.Duration
+
+	logger hclog.Logger
+}
+
+// NewGRPCProvider returns a new GRPCProvider
+func NewGRPCProvider(logger hclog.Logger) *GRPCProvider {
+	return &GRPCProvider{logger: logger}
+}
+
+// Configure implements Provider
+func (g *GRPCProvider) Configure(cfg ProviderConfig) error {
+	config, err := ParseGRPCCAConfig(cfg.RawConfig)
+	if err != nil {
+		return WrapProviderError(ErrProviderMisconfigured, err)
+	}
+
+	// The external process isn't told about the intermediate subject, so it
+	// couldn't honor it.
+	if newIntermediateSubject(cfg).isSet() {
+		return WrapProviderError(ErrProviderMisconfigured,
+			fmt.Errorf("the external CA provider does not support overriding the intermediate cert subject"))
+	}
+	if cfg.SignatureAlgorithm != "" {
+		return WrapProviderError(ErrProviderMisconfigured,
+			fmt.Errorf("the external CA provider does not support choosing the signature algorithm"))
+	}
+
+	configJSON, err := encodeGRPCCAConfig(cfg.RawConfig)
+	if err != nil {
+		return WrapProviderError(ErrProviderMisconfigured, err)
+	}
+
+	creds := grpc.WithInsecure()
+	if useGRPCCATLS(config) {
+		tlsConfig, err := grpcCATLSConfig(config)
+		if err != nil {
+			return WrapProviderError(ErrProviderMisconfigured, err)
+		}
+		creds = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	}
+
+	// Dialing doesn't block, so an unreachable process is only reported by
+	// the Configure call below.
+	conn, err := grpc.Dial(config.Address, creds, grpc.WithContextDialer(dialGRPCCAProvider))
+	if err != nil {
+		return WrapProviderError(ErrProviderMisconfigured, err)
+	}
+	client := pbcaprovider.NewCAProviderClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), GRPCRequestTimeout)
+	defer cancel()
+	resp, err := client.Configure(ctx, &pbcaprovider.ConfigureRequest{
+		ClusterID:        cfg.ClusterID,
+		Datacenter:       cfg.Datacenter,
+		IsPrimary:        cfg.IsPrimary,
+		ConfigJSON:       configJSON,
+		State:            cfg.State,
+		OCSPResponderURL: cfg.OCSPResponderURL,
+	})
+	if err != nil {
+		conn.Close()
+		return grpcCAError(fmt.Errorf("error configuring external CA provider: %w", err), ErrProviderMisconfigured)
+	}
+
+	g.Stop()
+	g.config = config
+	g.conn = conn
+	g.client = client
+	g.isPrimary = cfg.IsPrimary
+	g.spiffeID = connect.SpiffeIDSigningForCluster(&structs.CAConfiguration{ClusterID: cfg.ClusterID})
+	g.supportsCrossSigning = resp.SupportsCrossSigning
+	g.minLeafCertTTL = cfg.MinLeafCertTTL
+	return nil
+}
+
+// encodeGRPCCAConfig JSON encodes the raw provider config for the external
+// process. Values that went through msgpack may have become []uint8 so are
+// converted back to strings first.
+func encodeGRPCCAConfig(raw map[string]interface{}) ([]byte, error) {
+	if raw == nil {
+		return json.Marshal(map[string]interface{}{})
+	}
+	walked, err := lib.MapWalk(raw)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding config: %v", err)
+	}
+	return json.Marshal(walked)
+}
+
+// grpcCATLSConfig returns the TLS config for connecting to the external
+// process.
+func grpcCATLSConfig(config *structs.GRPCCAProviderConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{ServerName: config.TLSServerName}
+
+	if config.CAFile != "" {
+		pem, err := ioutil.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CAFile: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CAFile %q", config.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// useGRPCCATLS reports whether to connect to the external process over TLS.
+// Only unix sockets without CAFile or CertFile, and configs that explicitly set
+// Insecure, connect in plaintext. TCP addresses verify the process against the
+// system roots when CAFile isn't set.
+func useGRPCCATLS(config *structs.GRPCCAProviderConfig) bool {
+	if config.Insecure {
+		return false
+	}
+	return !strings.HasPrefix(config.Address, "unix://") || config.CAFile != "" || config.CertFile != ""
+}
+
+// dialGRPCCAProvider dials addr over TCP, or over a unix socket for addresses
+// of the form "unix:///path/to/socket".
+func dialGRPCCAProvider(ctx context.Context, addr string) (net.Conn, error) {
+	network := "tcp"
+	if strings.HasPrefix(addr, "unix://") {
+		network, addr = "unix", strings.TrimPrefix(addr, "unix://")
+	}
+	var d net.Dialer
+	return d.DialContext(ctx, network, addr)
+}
+
+// State implements Provider
+func (g *GRPCProvider) State() (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), GRPCRequestTimeout)
+	defer cancel()
+	resp, err := g.client.State(ctx, &pbcaprovider.StateRequest{})
+	if err != nil {
+		return nil, grpcCAError(err, ErrProviderMisconfigured)
+	}
+	return resp.State, nil
+}
+
+// GenerateRoot implements Provider
+func (g *GRPCProvider) GenerateRoot() error {
+	if !g.isPrimary {
+		return fmt.Errorf("provider is not the root certificate authority")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), GRPCRequestTimeout)
+	defer cancel()
+	if _, err := g.client.GenerateRoot(ctx, &pbcaprovider.GenerateRootRequest{}); err != nil {
+		return grpcCAError(fmt.Errorf("error generating root: %w", err), ErrProviderMisconfigured)
+	}
+	return nil
+}
+
+// activeIntermediate returns the signing cert and root reported by the
+// external process.
+func (g *GRPCProvider) activeIntermediate() (*pbcaprovider.ActiveIntermediateResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), GRPCRequestTimeout)
+	defer cancel()
+	resp, err := g.client.ActiveIntermediate(ctx, &pbcaprovider.ActiveIntermediateRequest{})
+	if err != nil {
+		return nil, grpcCAError(err, ErrProviderMisconfigured)
+	}
+	return resp, nil
+}
+
+// ActiveRoot implements Provider
+func (g *GRPCProvider) ActiveRoot() (string, error) {
+	resp, err := g.activeIntermediate()
+	if err != nil {
+		return "", err
+	}
+	if resp.RootPEM == "" {
+		return "", fmt.Errorf("external CA provider not fully initialized")
+	}
+	return EnsureTrailingNewline(resp.RootPEM), nil
+}
+
+// ActiveIntermediate implements Provider
+func (g *GRPCProvider) ActiveIntermediate() (string, error) {
+	resp, err := g.activeIntermediate()
+	if err != nil {
+		return "", err
+	}
+	return EnsureTrailingNewline(resp.IntermediatePEM), nil
+}
+
+// IntermediateExpiry implements IntermediateExpirer
+func (g *GRPCProvider) IntermediateExpiry() (notBefore, notAfter time.Time, err error) {
+	pem, err := g.ActiveIntermediate()
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return g.intermediateExpiry.Validity(pem)
+}
+
+// GenerateIntermediate implements Provider. The external process decides
+// whether the primary signs with its root or a separate intermediate, so
+// this returns whatever it reports as the signing cert.
+func (g *GRPCProvider) GenerateIntermediate() (string, error) {
+	return g.ActiveIntermediate()
+}
+
+// GenerateIntermediateCSR implements Provider
+func (g *GRPCProvider) GenerateIntermediateCSR() (string, error) {
+	if g.isPrimary {
+		return "", fmt.Errorf("provider is the root certificate authority, " +
+			"cannot generate an intermediate CSR")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), GRPCRequestTimeout)
+	defer cancel()
+	resp, err := g.client.GenerateIntermediateCSR(ctx, &pbcaprovider.GenerateIntermediateCSRRequest{})
+	if err != nil {
+		return "", grpcCAError(fmt.Errorf("error generating intermediate CSR: %w", err), ErrProviderMisconfigured)
+	}
+	return resp.CSRPEM, nil
+}
+
+// SetIntermediate implements Provider. The intermediate is checked against
+// the root before it is handed to the external process, which must check it
+// is for the key of the last CSR it generated.
+func (g *GRPCProvider) SetIntermediate(intermediatePEM, rootPEM string) error {
+	if g.isPrimary {
+		return fmt.Errorf("cannot set an intermediate using another root in the primary datacenter")
+	}
+	if err := validateSetIntermediate(intermediatePEM, rootPEM, "", g.spiffeID); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), GRPCRequestTimeout)
+	defer cancel()
+	_, err := g.client.SetIntermediate(ctx, &pbcaprovider.SetIntermediateRequest{
+		IntermediatePEM: intermediatePEM,
+		RootPEM:         rootPEM,
+	})
+	if err != nil {
+		return grpcCAError(fmt.Errorf("error setting intermediate: %w", err), ErrProviderMisconfigured)
+	}
+	return nil
+}
+
+// Sign implements Provider
+func (g *GRPCProvider) Sign(csr *x509.CertificateRequest) (string, error) {
+	return g.SignWithTTL(csr, 0)
+}
+
+// SignWithTTL implements SignerWithTTL. The clamped TTL is always sent so
+// the external process doesn't need to know the configured leaf TTL.
+func (g *GRPCProvider) SignWithTTL(csr *x509.CertificateRequest, ttl time.Duration) (string, error) {
+	ttl = structs.ClampLeafCertTTLWithMin(ttl, g.minLeafCertTTL, g.config.LeafCertTTL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), GRPCRequestTimeout)
+	defer cancel()
+	resp, err := g.client.SignLeaf(ctx, &pbcaprovider.SignLeafRequest{
+		CSR: csr.Raw,
+		TTL: types.DurationProto(ttl),
+	})
+	if err != nil {
+		return "", grpcCAError(fmt.Errorf("error signing leaf certificate: %w", err), ErrSigningDenied)
+	}
+	return validGRPCCert(resp.CertPEM)
+}
+
+// SignIntermediate implements Provider
+func (g *GRPCProvider) SignIntermediate(csr *x509.CertificateRequest) (string, error) {
+	if err := validateSignIntermediate(csr, g.spiffeID); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), GRPCRequestTimeout)
+	defer cancel()
+	resp, err := g.client.SignIntermediate(ctx, &pbcaprovider.SignIntermediateRequest{CSR: csr.Raw})
+	if err != nil {
+		return "", grpcCAError(fmt.Errorf("error signing intermediate certificate: %w", err), ErrSigningDenied)
+	}
+	return validGRPCCert(resp.CertPEM)
+}
+
+// CrossSignCA implements Provider
+func (g *GRPCProvider) CrossSignCA(cert *x509.Certificate) (string, error) {
+	if !g.supportsCrossSigning {
+		return "", fmt.Errorf("the external CA provider does not support cross-signing")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), GRPCRequestTimeout)
+	defer cancel()
+	resp, err := g.client.CrossSignCA(ctx, &pbcaprovider.CrossSignCARequest{Cert: cert.Raw})
+	if err != nil {
+		return "", grpcCAError(fmt.Errorf("error cross-signing CA certificate: %w", err), ErrSigningDenied)
+	}
+	return validGRPCCert(resp.CertPEM)
+}
+
+// validGRPCCert makes sure the external process returned a parseable cert so
+// a broken response fails here rather than in every client.
+func validGRPCCert(certPEM string) (string, error) {
+	if _, err := connect.ParseCert(certPEM); err != nil {
+		return "", fmt.Errorf("external CA provider returned an invalid certificate: %v", err)
+	}
+	return EnsureTrailingNewline(certPEM), nil
+}
+
+// Capabilities implements CapabilityReporter. The external process only
+// reports whether it can cross-sign, so no key types are listed.
+func (g *GRPCProvider) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{
+		CrossSigning: g.supportsCrossSigning,
+		ExternalRoot: true,
+	}
+}
+
+// HealthCheck implements HealthChecker. It makes sure the external process can
+// be reached and has an unexpired signing cert.
+func (g *GRPCProvider) HealthCheck() error {
+	resp, err := g.activeIntermediate()
+	if err != nil {
+		return err
+	}
+	if resp.IntermediatePEM == "" {
+		return fmt.Errorf("no signing certificate is set")
+	}
+	cert, err := connect.ParseCert(resp.IntermediatePEM)
+	if err != nil {
+		return fmt.Errorf("error parsing signing cert: %s", err)
+	}
+	if time.Now().After(cert.NotAfter) {
+		return fmt.Errorf("signing cert expired at %s", cert.NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Cleanup implements Provider
+func (g *GRPCProvider) Cleanup(providerTypeChange bool, otherConfig map[string]interface{}) error {
+	defer g.Stop()
+
+	otherJSON, err := encodeGRPCCAConfig(otherConfig)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), GRPCRequestTimeout)
+	defer cancel()
+	_, err = g.client.Cleanup(ctx, &pbcaprovider.CleanupRequest{
+		ProviderTypeChange: providerTypeChange,
+		OtherConfigJSON:    otherJSON,
+	})
+	if err != nil {
+		return grpcCAError(fmt.Errorf("error cleaning up external CA provider: %w", err), ErrProviderMisconfigured)
+	}
+	return nil
+}
+
+// Stop implements NeedsStop by closing the connection to the external
+// process.
+func (g *GRPCProvider) Stop() {
+	if g.conn != nil {
+		g.conn.Close()
+		g.conn = nil
+	}
+}
+
+// grpcCAError classifies a gRPC status error returned by the external
+// process. Rejected requests are classified as denied, while unavailable
+// processes and timeouts mean the provider is unreachable.
+func grpcCAError(err error, denied error) error {
+	s, ok := status.FromError(unwrapAll(err))
+	if !ok {
+		return err
+	}
+
+	switch s.Code() {
+	case codes.ResourceExhausted:
+		return ErrRateLimited
+	case codes.PermissionDenied:
+		return WrapProviderError(denied, err)
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.Unauthenticated, codes.Unimplemented:
+		return WrapProviderError(ErrProviderMisconfigured, err)
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return WrapProviderError(ErrProviderUnreachable, err)
+	}
+	return err
+}
+
+// unwrapAll returns the innermost error wrapped by err, which is where a gRPC
+// status error ends up since status.FromError doesn't unwrap.
+func unwrapAll(err error) error {
+	for {
+		u, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return err
+		}
+		next := u.Unwrap()
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+}
+
+// ParseGRPCCAConfig parses and validates gRPC CA Provider configuration.
+func ParseGRPCCAConfig(raw map[string]interface{}) (*structs.GRPCCAProviderConfig, error) {
+	config := structs.GRPCCAProviderConfig{
+		CommonCAProviderConfig: defaultCommonConfig(),
+	}
+
+	decodeConf := &mapstructure.DecoderConfig{
+		DecodeHook:       structs.ParseDurationFunc(),
+		Result:           &config,
+		WeaklyTypedInput: true,
+	}
+
+	decoder, err := mapstructure.NewDecoder(decodeConf)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := decoder.Decode(raw); err != nil {
+		return nil, fmt.Errorf("error decoding config: %s", err)
+	}
+
+	if err := config.CommonCAProviderConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	if config.Address == "" {
+		return nil, fmt.Errorf("must provide the Address of the external CA provider")
+	}
+	if (config.CertFile == "") != (config.KeyFile == "") {
+		return nil, fmt.Errorf("CertFile and KeyFile must be set together")
+	}
+	if config.Insecure && (config.CAFile != "" || config.CertFile != "") {
+		return nil, fmt.Errorf("Insecure can't be set with CAFile or CertFile")
+	}
+
+	return &config, nil
+}
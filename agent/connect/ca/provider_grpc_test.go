@@ -0,0 +1,294 @@
+package ca
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/sdk/testutil"
+)
+
+func TestGRPCProvider_BootstrapAndSignPrimary(t *testing.T) {
+	for _, tc := range KeyTestCases {
+		tc := tc
+		t.Run(tc.Desc, func(t *testing.T) {
+			srv := NewTestGRPCProviderServer(t)
+			provider := testGRPCProvider(t, testProviderConfigPrimary(t, map[string]interface{}{
+				"Address":        srv.Addr,
+				"Insecure":       true,
+				"PrivateKeyType": tc.KeyType,
+				"PrivateKeyBits": tc.KeyBits,
+			}))
+
+			require.NoError(t, provider.GenerateRoot())
+
+			rootPEM, err := provider.ActiveRoot()
+			require.NoError(t, err)
+			interPEM, err := provider.GenerateIntermediate()
+			require.NoError(t, err)
+			require.Equal(t, rootPEM, interPEM)
+
+			rootCert, err := connect.ParseCert(rootPEM)
+			require.NoError(t, err)
+			keyType, keyBits, err := connect.KeyInfoFromCert(rootCert)
+			require.NoError(t, err)
+			require.Equal(t, tc.KeyType, keyType)
+			require.Equal(t, tc.KeyBits, keyBits)
+
+			testSignAndValidate(t, provider, rootPEM, nil)
+			require.NoError(t, provider.HealthCheck())
+		})
+	}
+}
+
+func TestGRPCProvider_BootstrapAndSignSecondary(t *testing.T) {
+	for _, tc := range CASigningKeyTypeCases() {
+		tc := tc
+		t.Run(tc.Desc, func(t *testing.T) {
+			srv1 := NewTestGRPCProviderServer(t)
+			p1 := testGRPCProvider(t, testProviderConfigPrimary(t, map[string]interface{}{
+				"Address":        srv1.Addr,
+				"Insecure":       true,
+				"PrivateKeyType": tc.SigningKeyType,
+				"PrivateKeyBits": tc.SigningKeyBits,
+			}))
+			require.NoError(t, p1.GenerateRoot())
+
+			srv2 := NewTestGRPCProviderServer(t)
+			p2 := testGRPCProvider(t, testProviderConfigSecondary(t, map[string]interface{}{
+				"Address":        srv2.Addr,
+				"Insecure":       true,
+				"PrivateKeyType": tc.CSRKeyType,
+				"PrivateKeyBits": tc.CSRKeyBits,
+			}))
+
+			testSignIntermediateCrossDC(t, p1, p2)
+			require.NoError(t, p2.HealthCheck())
+		})
+	}
+}
+
+func TestGRPCProvider_Reconfigure(t *testing.T) {
+	srv := NewTestGRPCProviderServer(t)
+	cfg := testProviderConfigPrimary(t, map[string]interface{}{"Address": srv.Addr, "Insecure": true})
+
+	p1 := testGRPCProvider(t, cfg)
+	require.NoError(t, p1.GenerateRoot())
+	root1, err := p1.ActiveRoot()
+	require.NoError(t, err)
+
+	// A new provider for the same external process, such as after a leader
+	// election, must find the same root.
+	p2 := testGRPCProvider(t, cfg)
+	require.NoError(t, p2.GenerateRoot())
+	root2, err := p2.ActiveRoot()
+	require.NoError(t, err)
+	require.Equal(t, root1, root2)
+}
+
+func TestGRPCProvider_CrossSign(t *testing.T) {
+	srv1 := NewTestGRPCProviderServer(t)
+	p1 := testGRPCProvider(t, testProviderConfigPrimary(t, map[string]interface{}{
+		"Address":  srv1.Addr,
+		"Insecure": true,
+	}))
+	require.NoError(t, p1.GenerateRoot())
+
+	srv2 := NewTestGRPCProviderServer(t)
+	p2 := testGRPCProvider(t, testProviderConfigPrimary(t, map[string]interface{}{
+		"Address":        srv2.Addr,
+		"Insecure":       true,
+		"PrivateKeyType": "rsa",
+		"PrivateKeyBits": 2048,
+	}))
+	require.NoError(t, p2.GenerateRoot())
+
+	require.Equal(t, ProviderCapabilities{CrossSigning: true, ExternalRoot: true}, p1.Capabilities())
+
+	testCrossSignProviders(t, p1, p2)
+}
+
+func TestGRPCProvider_SignWithTTL(t *testing.T) {
+	srv := NewTestGRPCProviderServer(t)
+	provider := testGRPCProvider(t, testProviderConfigPrimary(t, map[string]interface{}{
+		"Address":     srv.Addr,
+		"Insecure":    true,
+		"LeafCertTTL": "24h",
+	}))
+	require.NoError(t, provider.GenerateRoot())
+
+	csrPEM, _ := connect.TestCSR(t, connect.TestSpiffeIDService(t, "testsvc"))
+	csr, err := connect.ParseCSR(csrPEM)
+	require.NoError(t, err)
+
+	cases := map[string]struct {
+		ttl    time.Duration
+		expect time.Duration
+	}{
+		"default":       {ttl: 0, expect: 24 * time.Hour},
+		"shorter":       {ttl: time.Hour, expect: time.Hour},
+		"clamped":       {ttl: 48 * time.Hour, expect: 24 * time.Hour},
+		"below minimum": {ttl: time.Second, expect: time.Hour},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			leafPEM, err := provider.SignWithTTL(csr, tc.ttl)
+			require.NoError(t, err)
+			requireTrailingNewline(t, leafPEM)
+			leaf, err := connect.ParseCert(leafPEM)
+			require.NoError(t, err)
+			require.WithinDuration(t, time.Now().Add(tc.expect), leaf.NotAfter, 5*time.Minute)
+		})
+	}
+}
+
+func TestGRPCProvider_Unreachable(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+	require.NoError(t, lis.Close())
+
+	provider := NewGRPCProvider(testutil.Logger(t))
+	err = provider.Configure(testProviderConfigPrimary(t, map[string]interface{}{
+		"Address":  addr,
+		"Insecure": true,
+	}))
+	require.True(t, errors.Is(err, ErrProviderUnreachable), "expected unreachable, got %v", err)
+}
+
+func TestParseGRPCCAConfig(t *testing.T) {
+	cases := map[string]struct {
+		raw    map[string]interface{}
+		expect string
+	}{
+		"missing address": {
+			raw:    map[string]interface{}{},
+			expect: "must provide the Address",
+		},
+		"cert without key": {
+			raw: map[string]interface{}{
+				"Address":  "127.0.0.1:8600",
+				"CertFile": "/path/to/cert.pem",
+			},
+			expect: "CertFile and KeyFile must be set together",
+		},
+		"invalid common config": {
+			raw: map[string]interface{}{
+				"Address":        "127.0.0.1:8600",
+				"PrivateKeyType": "dsa",
+			},
+			expect: "private key type must be",
+		},
+		"insecure with tls": {
+			raw: map[string]interface{}{
+				"Address":  "127.0.0.1:8600",
+				"CAFile":   "/path/to/ca.pem",
+				"Insecure": true,
+			},
+			expect: "Insecure can't be set with CAFile or CertFile",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseGRPCCAConfig(tc.raw)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expect)
+		})
+	}
+
+	config, err := ParseGRPCCAConfig(map[string]interface{}{
+		"Address":       "unix:///var/run/ca.sock",
+		"CAFile":        "/path/to/ca.pem",
+		"TLSServerName": "ca.example.com",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "unix:///var/run/ca.sock", config.Address)
+	require.Equal(t, "/path/to/ca.pem", config.CAFile)
+	require.Equal(t, "ca.example.com", config.TLSServerName)
+}
+
+func TestGRPCProvider_UseTLS(t *testing.T) {
+	cases := map[string]struct {
+		raw    map[string]interface{}
+		expect bool
+	}{
+		"tcp":                {raw: map[string]interface{}{"Address": "127.0.0.1:8600"}, expect: true},
+		"tcp with CAFile":    {raw: map[string]interface{}{"Address": "127.0.0.1:8600", "CAFile": "/path/to/ca.pem"}, expect: true},
+		"tcp insecure":       {raw: map[string]interface{}{"Address": "127.0.0.1:8600", "Insecure": true}, expect: false},
+		"unix":               {raw: map[string]interface{}{"Address": "unix:///var/run/ca.sock"}, expect: false},
+		"unix with CertFile": {raw: map[string]interface{}{"Address": "unix:///var/run/ca.sock", "CertFile": "/path/to/cert.pem", "KeyFile": "/path/to/key.pem"}, expect: true},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			config, err := ParseGRPCCAConfig(tc.raw)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, useGRPCCATLS(config))
+		})
+	}
+}
+
+func TestGRPCProvider_TLSByDefault(t *testing.T) {
+	// The test server doesn't serve TLS, so a TCP address without Insecure
+	// must fail the handshake rather than connect in plaintext.
+	srv := NewTestGRPCProviderServer(t)
+	provider := NewGRPCProvider(testutil.Logger(t))
+	err := provider.Configure(testProviderConfigPrimary(t, map[string]interface{}{
+		"Address": srv.Addr,
+	}))
+	require.Error(t, err)
+}
+
+func TestGRPCCAError(t *testing.T) {
+	cases := map[string]struct {
+		code   codes.Code
+		expect error
+	}{
+		"permission denied": {code: codes.PermissionDenied, expect: ErrSigningDenied},
+		"invalid argument":  {code: codes.InvalidArgument, expect: ErrProviderMisconfigured},
+		"unimplemented":     {code: codes.Unimplemented, expect: ErrProviderMisconfigured},
+		"unavailable":       {code: codes.Unavailable, expect: ErrProviderUnreachable},
+		"deadline exceeded": {code: codes.DeadlineExceeded, expect: ErrProviderUnreachable},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := fmt.Errorf("error signing: %w", status.Error(tc.code, "oops"))
+			err = grpcCAError(err, ErrSigningDenied)
+			require.True(t, errors.Is(err, tc.expect), "expected %v, got %v", tc.expect, err)
+		})
+	}
+
+	t.Run("rate limited", func(t *testing.T) {
+		err := grpcCAError(status.Error(codes.ResourceExhausted, "slow down"), ErrSigningDenied)
+		require.Equal(t, ErrRateLimited, err)
+	})
+
+	t.Run("unclassified", func(t *testing.T) {
+		orig := status.Error(codes.Internal, "oops")
+		require.Equal(t, orig, grpcCAError(orig, ErrSigningDenied))
+
+		plain := fmt.Errorf("some other error")
+		require.Equal(t, plain, grpcCAError(plain, ErrSigningDenied))
+	})
+}
+
+func testGRPCProvider(t *testing.T, cfg ProviderConfig) *GRPCProvider {
+	p := NewGRPCProvider(testutil.Logger(t))
+	require.NoError(t, p.Configure(cfg))
+	t.Cleanup(p.Stop)
+	return p
+}
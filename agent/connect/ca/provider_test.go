@@ -2,6 +2,8 @@ package ca
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -198,3 +200,77 @@ func TestStructs_CAConfiguration_MsgpackEncodeDecode(t *testing.T) {
 		})
 	}
 }
+
+func TestWrapProviderError(t *testing.T) {
+	inner := fmt.Errorf("backend said no")
+	err := WrapProviderError(ErrSigningDenied, inner)
+
+	require.Equal(t, "backend said no", err.Error())
+	require.True(t, errors.Is(err, ErrSigningDenied))
+	require.True(t, errors.Is(err, inner))
+	require.False(t, errors.Is(err, ErrProviderUnreachable))
+	require.False(t, errors.Is(err, ErrProviderMisconfigured))
+
+	// Classification survives further wrapping.
+	wrapped := fmt.Errorf("error signing: %w", err)
+	require.True(t, errors.Is(wrapped, ErrSigningDenied))
+
+	require.Nil(t, WrapProviderError(ErrSigningDenied, nil))
+}
+
+// noOptionalInterfacesProvider hides every optional interface of the wrapped
+// provider, like a provider written before they were added.
+type noOptionalInterfacesProvider struct {
+	Provider
+}
+
+func TestProviderCapabilities(t *testing.T) {
+	crossSigning := &ConsulProvider{config: &structs.ConsulCAProviderConfig{}}
+	noCrossSigning := &ConsulProvider{config: &structs.ConsulCAProviderConfig{DisableCrossSigning: true}}
+
+	cases := map[string]struct {
+		provider Provider
+		expected ProviderCapabilities
+	}{
+		"consul": {
+			provider: crossSigning,
+			expected: ProviderCapabilities{CrossSigning: true, KeyTypes: []string{"ec", "rsa", "ed25519"}},
+		},
+		"consul without cross-signing": {
+			provider: noCrossSigning,
+			expected: ProviderCapabilities{KeyTypes: []string{"ec", "rsa", "ed25519"}},
+		},
+		"vault": {
+			provider: &VaultProvider{},
+			expected: ProviderCapabilities{CrossSigning: true, ExternalRoot: true, KeyTypes: []string{"ec", "rsa"}},
+		},
+		"aws-pca": {
+			provider: &AWSProvider{},
+			expected: ProviderCapabilities{ExternalRoot: true, KeyTypes: []string{"ec", "rsa"}},
+		},
+		"azure-keyvault": {
+			provider: &AzureKeyVaultProvider{},
+			expected: ProviderCapabilities{CrossSigning: true, ExternalRoot: true, KeyTypes: []string{"ec", "rsa"}},
+		},
+		"without CapabilityReporter": {
+			provider: noOptionalInterfacesProvider{crossSigning},
+			expected: ProviderCapabilities{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, Capabilities(tc.provider))
+		})
+	}
+}
+
+func TestProviderCapabilities_SupportsKeyType(t *testing.T) {
+	caps := ProviderCapabilities{KeyTypes: []string{"ec", "rsa"}}
+	require.True(t, caps.SupportsKeyType("ec"))
+	require.True(t, caps.SupportsKeyType("rsa"))
+	require.False(t, caps.SupportsKeyType("ed25519"))
+
+	// Providers that don't list key types aren't restricted.
+	require.True(t, ProviderCapabilities{}.SupportsKeyType("ed25519"))
+}
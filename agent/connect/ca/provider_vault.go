@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -21,6 +23,17 @@ import (
 
 const VaultCALeafCertRole = "leaf-cert"
 
+// The roles used to issue leaf certs whose CSR requested only server or only
+// client auth usage.
+const (
+	VaultCALeafServerCertRole = "leaf-cert-server"
+	VaultCALeafClientCertRole = "leaf-cert-client"
+)
+
+// vaultLoginRetryInterval is how long to wait before retrying a failed login
+// with the configured auth method.
+var vaultLoginRetryInterval = 10 * time.Second
+
 var ErrBackendNotMounted = fmt.Errorf("backend not mounted")
 var ErrBackendNotInitialized = fmt.Errorf("backend not initialized")
 
@@ -28,6 +41,10 @@ type VaultProvider struct {
 	config *structs.VaultCAProviderConfig
 	client *vaultapi.Client
 
+	// signingClient is used to issue leaf certs. It uses SigningToken when
+	// one is configured, otherwise it is the same as client.
+	signingClient *vaultapi.Client
+
 	shutdown func()
 
 	isPrimary                    bool
@@ -35,6 +52,26 @@ type VaultProvider struct {
 	spiffeID                     *connect.SpiffeIDSigning
 	setupIntermediatePKIPathDone bool
 	logger                       hclog.Logger
+
+	// ocspServer is written to the intermediate PKI mount's URL config so
+	// that Vault embeds it in the leaf certs it issues.
+	ocspServer string
+
+	// intermediateSubject is requested for this provider's intermediate and
+	// enforced on the intermediates it signs for secondaries.
+	intermediateSubject intermediateSubject
+
+	// minLeafCertTTL is the shortest lifetime SignWithTTL issues. Zero uses
+	// structs.MinLeafCertTTL.
+	minLeafCertTTL time.Duration
+
+	// intermediateExpiry caches the expiry of the active intermediate.
+	intermediateExpiry certExpiryCache
+
+	// externalRoot is set by GenerateRoot when the root PKI mount holds a CA
+	// that was signed by another CA rather than a self-signed root. Vault
+	// can't cross-sign with such a mount.
+	externalRoot bool
 }
 
 func NewVaultProvider(logger hclog.Logger) *VaultProvider {
@@ -59,7 +96,13 @@ func vaultTLSConfig(config *structs.VaultCAProviderConfig) *vaultapi.TLSConfig {
 func (v *VaultProvider) Configure(cfg ProviderConfig) error {
 	config, err := ParseVaultCAConfig(cfg.RawConfig)
 	if err != nil {
-		return err
+		return WrapProviderError(ErrProviderMisconfigured, err)
+	}
+
+	// Vault picks the signature algorithm for the certs it issues itself.
+	if cfg.SignatureAlgorithm != "" {
+		return WrapProviderError(ErrProviderMisconfigured,
+			fmt.Errorf("the Vault CA provider does not support choosing the signature algorithm"))
 	}
 
 	clientConf := &vaultapi.Config{
@@ -74,17 +117,61 @@ func (v *VaultProvider) Configure(cfg ProviderConfig) error {
 		return err
 	}
 
-	client.SetToken(config.Token)
 	v.config = config
 	v.client = client
+	v.signingClient = client
 	v.isPrimary = cfg.IsPrimary
 	v.clusterID = cfg.ClusterID
 	v.spiffeID = connect.SpiffeIDSigningForCluster(&structs.CAConfiguration{ClusterID: v.clusterID})
+	v.ocspServer = cfg.OCSPResponderURL
+	v.minLeafCertTTL = cfg.MinLeafCertTTL
+	v.intermediateSubject = newIntermediateSubject(cfg)
 
+	var loginSecret *vaultapi.Secret
+	if config.AuthMethod != nil {
+		loginSecret, err = v.login()
+		if err != nil {
+			return err
+		}
+	} else {
+		client.SetToken(config.Token)
+	}
+
+	if config.SigningToken != "" {
+		signingClient, err := client.Clone()
+		if err != nil {
+			return err
+		}
+		signingClient.SetToken(config.SigningToken)
+		v.signingClient = signingClient
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	v.shutdown = cancel
+
+	if loginSecret != nil {
+		go v.renewAuthMethodToken(ctx, loginSecret)
+	} else if err := v.setupTokenRenewal(ctx, client, config.Token); err != nil {
+		cancel()
+		return err
+	}
+	if config.SigningToken != "" {
+		if err := v.setupTokenRenewal(ctx, v.signingClient, config.SigningToken); err != nil {
+			cancel()
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setupTokenRenewal looks up the given client's token and, if it is
+// renewable, starts a goroutine that renews its lease until ctx is cancelled.
+func (v *VaultProvider) setupTokenRenewal(ctx context.Context, client *vaultapi.Client, clientToken string) error {
 	// Look up the token to see if we can auto-renew its lease.
 	secret, err := client.Auth().Token().LookupSelf()
 	if err != nil {
-		return err
+		return vaultError(err, ErrProviderMisconfigured)
 	} else if secret == nil {
 		return fmt.Errorf("Could not look up Vault provider token: not found")
 	}
@@ -101,7 +188,7 @@ func (v *VaultProvider) Configure(cfg ProviderConfig) error {
 		lifetimeWatcher, err := client.NewLifetimeWatcher(&vaultapi.LifetimeWatcherInput{
 			Secret: &vaultapi.Secret{
 				Auth: &vaultapi.SecretAuth{
-					ClientToken:   config.Token,
+					ClientToken:   clientToken,
 					Renewable:     token.Renewable,
 					LeaseDuration: secret.LeaseDuration,
 				},
@@ -113,8 +200,6 @@ func (v *VaultProvider) Configure(cfg ProviderConfig) error {
 			return fmt.Errorf("Error beginning Vault provider token renewal: %v", err)
 		}
 
-		ctx, cancel := context.WithCancel(context.TODO())
-		v.shutdown = cancel
 		go v.renewToken(ctx, lifetimeWatcher)
 	}
 
@@ -145,6 +230,121 @@ func (v *VaultProvider) renewToken(ctx context.Context, watcher *vaultapi.Lifeti
 	}
 }
 
+// login authenticates with the configured auth method and sets the resulting
+// token on the provider's client.
+func (v *VaultProvider) login() (*vaultapi.Secret, error) {
+	authMethod := v.config.AuthMethod
+	mountPath := authMethod.MountPath
+	if mountPath == "" {
+		mountPath = authMethod.Type
+	}
+
+	// Log in with a client that has no token so an expired one isn't sent.
+	loginClient, err := v.client.Clone()
+	if err != nil {
+		return nil, err
+	}
+	loginClient.ClearToken()
+
+	secret, err := loginClient.Logical().Write("auth/"+mountPath+"/login", authMethod.Params)
+	if err != nil {
+		err = fmt.Errorf("Error logging in to Vault with the %s auth method: %w", authMethod.Type, err)
+		var respErr *vaultapi.ResponseError
+		if errors.As(err, &respErr) && respErr.StatusCode < http.StatusInternalServerError {
+			// Vault rejected the login parameters.
+			return nil, WrapProviderError(ErrProviderMisconfigured, err)
+		}
+		return nil, vaultError(err, ErrProviderMisconfigured)
+	}
+	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+		return nil, fmt.Errorf("Vault %s auth method login did not return a token", authMethod.Type)
+	}
+
+	v.client.SetToken(secret.Auth.ClientToken)
+	return secret, nil
+}
+
+// renewAuthMethodToken renews the lease of a token obtained with login for as
+// long as Vault allows, then logs in again to get a new one.
+func (v *VaultProvider) renewAuthMethodToken(ctx context.Context, secret *vaultapi.Secret) {
+	for {
+		// Tokens without a lease never need replacing.
+		if secret.Auth.LeaseDuration == 0 {
+			return
+		}
+
+		watcher, err := v.client.NewLifetimeWatcher(&vaultapi.LifetimeWatcherInput{
+			Secret:        secret,
+			RenewBehavior: vaultapi.RenewBehaviorIgnoreErrors,
+		})
+		if err != nil {
+			v.logger.Error("Error beginning Vault provider token renewal", "error", err)
+			return
+		}
+		if !v.watchTokenLifetime(ctx, watcher) {
+			return
+		}
+
+		// The token can't be renewed any further, so log in again before it
+		// expires.
+		for {
+			secret, err = v.login()
+			if err == nil {
+				break
+			}
+			v.logger.Error("Error logging in to Vault for Vault provider", "error", err)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(vaultLoginRetryInterval):
+			}
+		}
+		v.logger.Info("Successfully logged in to Vault for Vault provider")
+	}
+}
+
+// watchTokenLifetime runs watcher until the token's lease can no longer be
+// extended. It returns false if ctx was cancelled first.
+func (v *VaultProvider) watchTokenLifetime(ctx context.Context, watcher *vaultapi.LifetimeWatcher) bool {
+	go watcher.Start()
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+
+		case err := <-watcher.DoneCh():
+			if err != nil {
+				v.logger.Error("Error renewing token for Vault provider", "error", err)
+			}
+			return true
+
+		case <-watcher.RenewCh():
+			v.logger.Info("Successfully renewed token for Vault provider")
+		}
+	}
+}
+
+// vaultError classifies an error returned by the Vault API. Requests Vault
+// rejected for lack of permission are classified as denied, while transport
+// failures and server errors, such as a sealed Vault, mean Vault is
+// unreachable.
+func vaultError(err error, denied error) error {
+	var respErr *vaultapi.ResponseError
+	if !errors.As(err, &respErr) {
+		return WrapProviderError(ErrProviderUnreachable, err)
+	}
+	switch {
+	case respErr.StatusCode == http.StatusUnauthorized || respErr.StatusCode == http.StatusForbidden:
+		return WrapProviderError(denied, err)
+	case respErr.StatusCode >= http.StatusInternalServerError:
+		return WrapProviderError(ErrProviderUnreachable, err)
+	}
+	return err
+}
+
 // State implements Provider. Vault provider needs no state other than the
 // user-provided config currently.
 func (v *VaultProvider) State() (map[string]string, error) {
@@ -179,7 +379,7 @@ func (v *VaultProvider) GenerateRoot() error {
 		})
 
 		if err != nil {
-			return err
+			return vaultError(err, ErrProviderMisconfigured)
 		}
 
 		fallthrough
@@ -195,8 +395,9 @@ func (v *VaultProvider) GenerateRoot() error {
 			"key_bits":    v.config.PrivateKeyBits,
 		})
 		if err != nil {
-			return err
+			return vaultError(err, ErrProviderMisconfigured)
 		}
+		v.externalRoot = false
 	default:
 		if err != nil {
 			return err
@@ -207,6 +408,7 @@ func (v *VaultProvider) GenerateRoot() error {
 			if err != nil {
 				return err
 			}
+			v.externalRoot = !isSelfSigned(rootCert)
 
 			// Vault PKI doesn't allow in-place cert/key regeneration. That
 			// means if you need to change either the key type or key bits then
@@ -220,10 +422,10 @@ func (v *VaultProvider) GenerateRoot() error {
 				return err
 			}
 			if v.config.PrivateKeyType != foundKeyType {
-				return fmt.Errorf("cannot update the PrivateKeyType field without choosing a new PKI mount for the root CA")
+				return WrapProviderError(ErrProviderMisconfigured, fmt.Errorf("cannot update the PrivateKeyType field without choosing a new PKI mount for the root CA"))
 			}
 			if v.config.PrivateKeyBits != foundKeyBits {
-				return fmt.Errorf("cannot update the PrivateKeyBits field without choosing a new PKI mount for the root CA")
+				return WrapProviderError(ErrProviderMisconfigured, fmt.Errorf("cannot update the PrivateKeyBits field without choosing a new PKI mount for the root CA"))
 			}
 		}
 	}
@@ -249,7 +451,7 @@ func (v *VaultProvider) setupIntermediatePKIPath() error {
 	}
 	mounts, err := v.client.Sys().ListMounts()
 	if err != nil {
-		return err
+		return vaultError(err, ErrProviderMisconfigured)
 	}
 
 	// Mount the backend if it isn't mounted already.
@@ -263,33 +465,84 @@ func (v *VaultProvider) setupIntermediatePKIPath() error {
 		})
 
 		if err != nil {
-			return err
+			return vaultError(err, ErrProviderMisconfigured)
 		}
 	}
 
-	// Create the role for issuing leaf certs if it doesn't exist yet
-	rolePath := v.config.IntermediatePKIPath + "roles/" + VaultCALeafCertRole
-	role, err := v.client.Logical().Read(rolePath)
-	if err != nil {
-		return err
+	// Create the roles for issuing leaf certs if they don't exist yet
+	roles := []struct {
+		name           string
+		server, client bool
+	}{
+		{name: VaultCALeafCertRole, server: true, client: true},
+		{name: VaultCALeafServerCertRole, server: true},
+		{name: VaultCALeafClientCertRole, client: true},
 	}
-	if role == nil {
-		_, err := v.client.Logical().Write(rolePath, map[string]interface{}{
-			"allow_any_name":   true,
-			"allowed_uri_sans": "spiffe://*",
-			"key_type":         "any",
-			"max_ttl":          v.config.LeafCertTTL.String(),
-			"no_store":         true,
-			"require_cn":       false,
-		})
+	for _, r := range roles {
+		rolePath := v.config.IntermediatePKIPath + "roles/" + r.name
+		role, err := v.client.Logical().Read(rolePath)
 		if err != nil {
 			return err
 		}
+		if role == nil || v.config.LeafRole != nil {
+			_, err := v.client.Logical().Write(rolePath, v.leafRoleParams(r.server, r.client))
+			if err != nil {
+				return err
+			}
+		}
 	}
+
+	// The URL config applies to every cert the mount issues, so it's only
+	// written when an OCSP server is configured to avoid clobbering URLs an
+	// operator may have set on the mount themselves.
+	if v.ocspServer != "" {
+		_, err := v.client.Logical().Write(v.config.IntermediatePKIPath+"config/urls", map[string]interface{}{
+			"ocsp_servers": []string{v.ocspServer},
+		})
+		if err != nil {
+			return vaultError(err, ErrProviderMisconfigured)
+		}
+	}
+
 	v.setupIntermediatePKIPathDone = true
 	return nil
 }
 
+// leafRoleParams returns the parameters of a PKI role for issuing leaf certs,
+// including any tuning from the LeafRole config.
+func (v *VaultProvider) leafRoleParams(server, client bool) map[string]interface{} {
+	params := map[string]interface{}{
+		"allow_any_name":      true,
+		"allowed_uri_sans":    "spiffe://*",
+		"key_type":            "any",
+		"max_ttl":             v.config.LeafCertTTL.String(),
+		"not_before_duration": LeafNotBeforeBackdate(v.config.CommonCAProviderConfig).String(),
+		"no_store":            true,
+		"require_cn":          false,
+		"server_flag":         server,
+		"client_flag":         client,
+	}
+
+	leafRole := v.config.LeafRole
+	if leafRole == nil {
+		return params
+	}
+	allowAnyName := len(leafRole.AllowedDomains) == 0
+	if leafRole.AllowAnyName != nil {
+		allowAnyName = *leafRole.AllowAnyName
+	}
+	params["allow_any_name"] = allowAnyName
+	if len(leafRole.AllowedDomains) > 0 {
+		params["allowed_domains"] = leafRole.AllowedDomains
+		params["allow_bare_domains"] = true
+		params["allow_subdomains"] = leafRole.AllowSubdomains
+	}
+	if leafRole.MaxTTL != 0 {
+		params["max_ttl"] = leafRole.MaxTTL.String()
+	}
+	return params
+}
+
 func (v *VaultProvider) generateIntermediateCSR() (string, error) {
 	err := v.setupIntermediatePKIPath()
 	if err != nil {
@@ -301,12 +554,17 @@ func (v *VaultProvider) generateIntermediateCSR() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	data, err := v.client.Logical().Write(v.config.IntermediatePKIPath+"intermediate/generate/internal", map[string]interface{}{
-		"common_name": connect.CACN("vault", uid, v.clusterID, v.isPrimary),
+	subject := v.intermediateSubject.apply(pkix.Name{CommonName: connect.CACN("vault", uid, v.clusterID, v.isPrimary)})
+	params := map[string]interface{}{
+		"common_name": subject.CommonName,
 		"key_type":    v.config.PrivateKeyType,
 		"key_bits":    v.config.PrivateKeyBits,
 		"uri_sans":    v.spiffeID.URI().String(),
-	})
+	}
+	if len(subject.Organization) > 0 {
+		params["organization"] = subject.Organization
+	}
+	data, err := v.client.Logical().Write(v.config.IntermediatePKIPath+"intermediate/generate/internal", params)
 	if err != nil {
 		return "", err
 	}
@@ -367,6 +625,37 @@ func (v *VaultProvider) ActiveIntermediate() (string, error) {
 	return cert, err
 }
 
+// IntermediateExpiry implements IntermediateExpirer. The intermediate is still
+// fetched from Vault but only parsed when it has changed.
+func (v *VaultProvider) IntermediateExpiry() (notBefore, notAfter time.Time, err error) {
+	pem, err := v.ActiveIntermediate()
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return v.intermediateExpiry.Validity(pem)
+}
+
+// HealthCheck makes sure Vault is reachable, the signing token is still valid
+// and the intermediate PKI backend has an unexpired signing cert.
+func (v *VaultProvider) HealthCheck() error {
+	if _, err := v.signingClient.Auth().Token().LookupSelf(); err != nil {
+		return vaultError(fmt.Errorf("error looking up Vault token: %w", err), ErrProviderMisconfigured)
+	}
+
+	intermediatePEM, err := v.getCA(v.config.IntermediatePKIPath)
+	if err != nil {
+		return fmt.Errorf("error reading intermediate cert: %w", err)
+	}
+	cert, err := connect.ParseCert(intermediatePEM)
+	if err != nil {
+		return fmt.Errorf("error parsing intermediate cert: %w", err)
+	}
+	if time.Now().After(cert.NotAfter) {
+		return fmt.Errorf("intermediate cert expired at %s", cert.NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
+
 // getCA returns the raw CA cert for the given endpoint if there is one.
 // We have to use the raw NewRequest call here instead of Logical().Read
 // because the endpoint only returns the raw PEM contents of the CA cert
@@ -381,7 +670,7 @@ func (v *VaultProvider) getCA(path string) (string, error) {
 		return "", ErrBackendNotMounted
 	}
 	if err != nil {
-		return "", err
+		return "", vaultError(err, ErrProviderMisconfigured)
 	}
 
 	bytes, err := ioutil.ReadAll(resp.Body)
@@ -408,10 +697,11 @@ func (v *VaultProvider) GenerateIntermediate() (string, error) {
 
 	// Sign the CSR with the root backend.
 	intermediate, err := v.client.Logical().Write(v.config.RootPKIPath+"root/sign-intermediate", map[string]interface{}{
-		"csr":            csr,
-		"use_csr_values": true,
-		"format":         "pem_bundle",
-		"ttl":            v.config.IntermediateCertTTL.String(),
+		"csr":                 csr,
+		"use_csr_values":      true,
+		"format":              "pem_bundle",
+		"ttl":                 v.config.IntermediateCertTTL.String(),
+		"not_before_duration": IntermediateNotBeforeBackdate(v.config.CommonCAProviderConfig).String(),
 	})
 	if err != nil {
 		return "", err
@@ -435,6 +725,11 @@ func (v *VaultProvider) GenerateIntermediate() (string, error) {
 // a new leaf certificate based on the provided CSR, with the issuing
 // intermediate CA cert attached.
 func (v *VaultProvider) Sign(csr *x509.CertificateRequest) (string, error) {
+	return v.SignWithTTL(csr, 0)
+}
+
+// SignWithTTL implements SignerWithTTL.
+func (v *VaultProvider) SignWithTTL(csr *x509.CertificateRequest, ttl time.Duration) (string, error) {
 	connect.HackSANExtensionForCSR(csr)
 
 	var pemBuf bytes.Buffer
@@ -442,13 +737,28 @@ func (v *VaultProvider) Sign(csr *x509.CertificateRequest) (string, error) {
 		return "", err
 	}
 
+	// Pick the leaf cert role matching the usage requested by the CSR.
+	extKeyUsage, err := connect.LeafExtKeyUsage(csr)
+	if err != nil {
+		return "", err
+	}
+	role := VaultCALeafCertRole
+	if len(extKeyUsage) == 1 {
+		switch extKeyUsage[0] {
+		case x509.ExtKeyUsageServerAuth:
+			role = VaultCALeafServerCertRole
+		case x509.ExtKeyUsageClientAuth:
+			role = VaultCALeafClientCertRole
+		}
+	}
+
 	// Use the leaf cert role to sign a new cert for this CSR.
-	response, err := v.client.Logical().Write(v.config.IntermediatePKIPath+"sign/"+VaultCALeafCertRole, map[string]interface{}{
+	response, err := v.signingClient.Logical().Write(v.config.IntermediatePKIPath+"sign/"+role, map[string]interface{}{
 		"csr": pemBuf.String(),
-		"ttl": v.config.LeafCertTTL.String(),
+		"ttl": structs.ClampLeafCertTTLWithMin(ttl, v.minLeafCertTTL, v.config.LeafCertTTL).String(),
 	})
 	if err != nil {
-		return "", fmt.Errorf("error issuing cert: %v", err)
+		return "", vaultError(fmt.Errorf("error issuing cert: %w", err), ErrSigningDenied)
 	}
 	if response == nil || response.Data["certificate"] == "" || response.Data["issuing_ca"] == "" {
 		return "", fmt.Errorf("certificate info returned from Vault was blank")
@@ -480,16 +790,33 @@ func (v *VaultProvider) SignIntermediate(csr *x509.CertificateRequest) (string,
 		return "", err
 	}
 
+	params := map[string]interface{}{
+		"csr":                 pemBuf.String(),
+		"use_csr_values":      true,
+		"format":              "pem_bundle",
+		"max_path_length":     0,
+		"ttl":                 v.config.IntermediateCertTTL.String(),
+		"not_before_duration": IntermediateNotBeforeBackdate(v.config.CommonCAProviderConfig).String(),
+	}
+
+	// Vault either takes the whole subject from the CSR or none of it, so to
+	// enforce the configured subject pass every value the CSR would have set.
+	if v.intermediateSubject.isSet() {
+		subject := v.intermediateSubject.apply(csr.Subject)
+		uriSANs := make([]string, 0, len(csr.URIs))
+		for _, u := range csr.URIs {
+			uriSANs = append(uriSANs, u.String())
+		}
+		params["use_csr_values"] = false
+		params["common_name"] = subject.CommonName
+		params["organization"] = subject.Organization
+		params["uri_sans"] = strings.Join(uriSANs, ",")
+	}
+
 	// Sign the CSR with the root backend.
-	data, err := v.client.Logical().Write(v.config.RootPKIPath+"root/sign-intermediate", map[string]interface{}{
-		"csr":             pemBuf.String(),
-		"use_csr_values":  true,
-		"format":          "pem_bundle",
-		"max_path_length": 0,
-		"ttl":             v.config.IntermediateCertTTL.String(),
-	})
+	data, err := v.client.Logical().Write(v.config.RootPKIPath+"root/sign-intermediate", params)
 	if err != nil {
-		return "", err
+		return "", vaultError(err, ErrSigningDenied)
 	}
 	if data == nil || data.Data["certificate"] == "" {
 		return "", fmt.Errorf("got empty value when generating intermediate certificate")
@@ -517,6 +844,9 @@ func (v *VaultProvider) CrossSignCA(cert *x509.Certificate) (string, error) {
 	if rootCert.NotAfter.Before(time.Now()) {
 		return "", fmt.Errorf("root certificate is expired")
 	}
+	if !isSelfSigned(rootCert) {
+		return "", fmt.Errorf("cannot cross-sign with a root PKI mount whose CA was issued by an external root")
+	}
 
 	var pemBuf bytes.Buffer
 	err = pem.Encode(&pemBuf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
@@ -529,7 +859,7 @@ func (v *VaultProvider) CrossSignCA(cert *x509.Certificate) (string, error) {
 		"certificate": pemBuf.String(),
 	})
 	if err != nil {
-		return "", fmt.Errorf("error having Vault cross-sign cert: %v", err)
+		return "", vaultError(fmt.Errorf("error having Vault cross-sign cert: %w", err), ErrSigningDenied)
 	}
 	if response == nil || response.Data["certificate"] == "" {
 		return "", fmt.Errorf("certificate info returned from Vault was blank")
@@ -543,9 +873,19 @@ func (v *VaultProvider) CrossSignCA(cert *x509.Certificate) (string, error) {
 	return EnsureTrailingNewline(xcCert), nil
 }
 
-// SupportsCrossSigning implements Provider
-func (v *VaultProvider) SupportsCrossSigning() (bool, error) {
-	return true, nil
+// Capabilities implements CapabilityReporter
+func (v *VaultProvider) Capabilities() ProviderCapabilities {
+	return ProviderCapabilities{
+		CrossSigning: !v.externalRoot,
+		ExternalRoot: true,
+		KeyTypes:     []string{"ec", "rsa"},
+	}
+}
+
+// isSelfSigned reports whether cert is a root that signed itself, as opposed
+// to an intermediate of some other CA.
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil
 }
 
 // Cleanup unmounts the configured intermediate PKI backend. It's fine to tear
@@ -578,7 +918,7 @@ func (v *VaultProvider) Cleanup(providerTypeChange bool, otherConfig map[string]
 	}
 }
 
-// Stop shuts down the token renew goroutine.
+// Stop shuts down the token renew goroutines.
 func (v *VaultProvider) Stop() {
 	v.shutdown()
 }
@@ -605,8 +945,15 @@ func ParseVaultCAConfig(raw map[string]interface{}) (*structs.VaultCAProviderCon
 		return nil, fmt.Errorf("error decoding config: %s", err)
 	}
 
-	if config.Token == "" {
-		return nil, fmt.Errorf("must provide a Vault token")
+	if config.AuthMethod != nil {
+		if config.Token != "" {
+			return nil, fmt.Errorf("only one of Vault token or Vault auth method can be provided, but not both")
+		}
+		if config.AuthMethod.Type == "" {
+			return nil, fmt.Errorf("must provide a type for the Vault auth method")
+		}
+	} else if config.Token == "" {
+		return nil, fmt.Errorf("must provide a Vault token or configure a Vault auth method")
 	}
 
 	if config.RootPKIPath == "" {
@@ -627,5 +974,15 @@ func ParseVaultCAConfig(raw map[string]interface{}) (*structs.VaultCAProviderCon
 		return nil, err
 	}
 
+	if config.LeafRole != nil {
+		if config.LeafRole.MaxTTL < 0 {
+			return nil, fmt.Errorf("LeafRole.MaxTTL must not be negative")
+		}
+		if config.LeafRole.MaxTTL != 0 && config.LeafRole.MaxTTL < config.LeafCertTTL {
+			return nil, fmt.Errorf("LeafRole.MaxTTL (%s) must be at least LeafCertTTL (%s)",
+				config.LeafRole.MaxTTL, config.LeafCertTTL)
+		}
+	}
+
 	return &config, nil
 }
@@ -3,6 +3,7 @@ package ca
 import (
 	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"testing"
@@ -36,6 +37,89 @@ func TestVaultCAProvider_VaultTLSConfig(t *testing.T) {
 	require.Equal(config.TLSSkipVerify, tlsConfig.Insecure)
 }
 
+func TestParseVaultCAConfig_AuthMethod(t *testing.T) {
+	base := func() map[string]interface{} {
+		return map[string]interface{}{
+			"Address":             "http://127.0.0.1:8200",
+			"RootPKIPath":         "pki-root/",
+			"IntermediatePKIPath": "pki-intermediate/",
+		}
+	}
+
+	conf := base()
+	conf["AuthMethod"] = map[string]interface{}{
+		"type":      "kubernetes",
+		"MountPath": "kube",
+		"params":    map[string]interface{}{"role": "consul-ca"},
+	}
+	config, err := ParseVaultCAConfig(conf)
+	require.NoError(t, err)
+	require.Equal(t, &structs.VaultAuthMethod{
+		Type:      "kubernetes",
+		MountPath: "kube",
+		Params:    map[string]interface{}{"role": "consul-ca"},
+	}, config.AuthMethod)
+
+	_, err = ParseVaultCAConfig(base())
+	require.EqualError(t, err, "must provide a Vault token or configure a Vault auth method")
+
+	conf = base()
+	conf["Token"] = "foo"
+	conf["AuthMethod"] = map[string]interface{}{"Type": "approle"}
+	_, err = ParseVaultCAConfig(conf)
+	require.EqualError(t, err, "only one of Vault token or Vault auth method can be provided, but not both")
+
+	conf = base()
+	conf["AuthMethod"] = map[string]interface{}{"MountPath": "approle"}
+	_, err = ParseVaultCAConfig(conf)
+	require.EqualError(t, err, "must provide a type for the Vault auth method")
+}
+
+func TestParseVaultCAConfig_LeafRole(t *testing.T) {
+	base := func() map[string]interface{} {
+		return map[string]interface{}{
+			"Address":             "http://127.0.0.1:8200",
+			"Token":               "foo",
+			"RootPKIPath":         "pki-root/",
+			"IntermediatePKIPath": "pki-intermediate/",
+			"LeafCertTTL":         "72h",
+		}
+	}
+
+	conf := base()
+	conf["LeafRole"] = map[string]interface{}{
+		"AllowedDomains":  []interface{}{"consul"},
+		"AllowSubdomains": true,
+		"AllowAnyName":    false,
+		"MaxTTL":          "96h",
+	}
+	config, err := ParseVaultCAConfig(conf)
+	require.NoError(t, err)
+	allowAnyName := false
+	require.Equal(t, &structs.VaultLeafRoleConfig{
+		AllowedDomains:  []string{"consul"},
+		AllowSubdomains: true,
+		AllowAnyName:    &allowAnyName,
+		MaxTTL:          96 * time.Hour,
+	}, config.LeafRole)
+
+	// HCL agent config decodes a single element list as a plain value.
+	conf = base()
+	conf["LeafRole"] = map[string]interface{}{"AllowedDomains": "consul"}
+	config, err = ParseVaultCAConfig(conf)
+	require.NoError(t, err)
+	require.Equal(t, []string{"consul"}, config.LeafRole.AllowedDomains)
+
+	config, err = ParseVaultCAConfig(base())
+	require.NoError(t, err)
+	require.Nil(t, config.LeafRole)
+
+	conf = base()
+	conf["LeafRole"] = map[string]interface{}{"MaxTTL": "1h"}
+	_, err = ParseVaultCAConfig(conf)
+	require.EqualError(t, err, "LeafRole.MaxTTL (1h0m0s) must be at least LeafCertTTL (72h0m0s)")
+}
+
 func TestVaultCAProvider_SecondaryActiveIntermediate(t *testing.T) {
 
 	SkipIfVaultNotPresent(t)
@@ -85,6 +169,178 @@ func TestVaultCAProvider_RenewToken(t *testing.T) {
 	})
 }
 
+func TestVaultCAProvider_AuthMethod(t *testing.T) {
+	SkipIfVaultNotPresent(t)
+
+	testVault, err := runTestVault(t)
+	require.NoError(t, err)
+	defer testVault.Stop()
+	testVault.WaitUntilReady(t)
+
+	// Mount AppRole somewhere other than its default path and hand out
+	// tokens that can only be renewed for a short time, so the provider has
+	// to log in again.
+	client := testVault.Client()
+	require.NoError(t, client.Sys().EnableAuthWithOptions("ca-approle", &vaultapi.EnableAuthOptions{Type: "approle"}))
+	require.NoError(t, client.Sys().PutPolicy("consul-ca", `
+path "*" {
+  capabilities = ["create", "read", "update", "delete", "list", "sudo"]
+}`))
+	_, err = client.Logical().Write("auth/ca-approle/role/consul-ca", map[string]interface{}{
+		"token_policies": "consul-ca",
+		"token_ttl":      "2s",
+		"token_max_ttl":  "4s",
+	})
+	require.NoError(t, err)
+	secret, err := client.Logical().Read("auth/ca-approle/role/consul-ca/role-id")
+	require.NoError(t, err)
+	roleID := secret.Data["role_id"]
+	secret, err = client.Logical().Write("auth/ca-approle/role/consul-ca/secret-id", nil)
+	require.NoError(t, err)
+	secretID := secret.Data["secret_id"]
+
+	provider, err := createVaultProvider(t, true, testVault.Addr, "", map[string]interface{}{
+		"AuthMethod": map[string]interface{}{
+			"Type":      "approle",
+			"MountPath": "ca-approle",
+			"Params": map[string]interface{}{
+				"role_id":   roleID,
+				"secret_id": secretID,
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer provider.Stop()
+
+	firstToken := provider.client.Token()
+	require.NotEmpty(t, firstToken)
+	require.NoError(t, provider.HealthCheck())
+
+	// Once the first token reaches its max TTL the provider logs in again and
+	// keeps working.
+	retry.Run(t, func(r *retry.R) {
+		require.NotEqual(r, firstToken, provider.client.Token())
+	})
+	require.NoError(t, provider.HealthCheck())
+	_, err = provider.GenerateIntermediate()
+	require.NoError(t, err)
+}
+
+func TestVaultCAProvider_HealthCheck(t *testing.T) {
+	SkipIfVaultNotPresent(t)
+
+	testVault, err := runTestVault(t)
+	require.NoError(t, err)
+	testVault.WaitUntilReady(t)
+
+	provider, err := createVaultProvider(t, true, testVault.Addr, testVault.RootToken, nil)
+	require.NoError(t, err)
+	defer provider.Stop()
+
+	require.NoError(t, provider.HealthCheck())
+
+	// Once Vault is unreachable the check must fail.
+	testVault.Stop()
+	require.Error(t, provider.HealthCheck())
+}
+
+func TestVaultCAProvider_IntermediateExpiry(t *testing.T) {
+	SkipIfVaultNotPresent(t)
+
+	testVault, err := runTestVault(t)
+	require.NoError(t, err)
+	defer testVault.Stop()
+	testVault.WaitUntilReady(t)
+
+	provider, err := createVaultProvider(t, true, testVault.Addr, testVault.RootToken, nil)
+	require.NoError(t, err)
+	defer provider.Stop()
+
+	pem, err := provider.ActiveIntermediate()
+	require.NoError(t, err)
+	cert, err := connect.ParseCert(pem)
+	require.NoError(t, err)
+
+	notBefore, expiry, err := provider.IntermediateExpiry()
+	require.NoError(t, err)
+	require.Equal(t, cert.NotBefore, notBefore)
+	require.Equal(t, cert.NotAfter, expiry)
+
+	// Generating a new intermediate is reflected in the next call.
+	pem, err = provider.GenerateIntermediate()
+	require.NoError(t, err)
+	cert, err = connect.ParseCert(pem)
+	require.NoError(t, err)
+
+	notBefore, expiry, err = provider.IntermediateExpiry()
+	require.NoError(t, err)
+	require.Equal(t, cert.NotBefore, notBefore)
+	require.Equal(t, cert.NotAfter, expiry)
+}
+
+func TestVaultCAProvider_SigningToken(t *testing.T) {
+	SkipIfVaultNotPresent(t)
+
+	testVault, err := runTestVault(t)
+	require.NoError(t, err)
+	defer testVault.Stop()
+	testVault.WaitUntilReady(t)
+
+	// The signing token may only issue leaf certs.
+	err = testVault.client.Sys().PutPolicy("leaf-signing", `
+path "pki-intermediate/sign/*" {
+  capabilities = ["create", "update"]
+}`)
+	require.NoError(t, err)
+
+	// Use a short TTL so we can check that it is renewed too.
+	ttl := 1 * time.Second
+	secret, err := testVault.client.Auth().Token().Create(&vaultapi.TokenCreateRequest{
+		Policies: []string{"leaf-signing"},
+		TTL:      ttl.String(),
+	})
+	require.NoError(t, err)
+	signingToken := secret.Auth.ClientToken
+
+	provider, err := createVaultProvider(t, true, testVault.Addr, testVault.RootToken, map[string]interface{}{
+		"SigningToken": signingToken,
+	})
+	require.NoError(t, err)
+	defer provider.Stop()
+	require.Equal(t, testVault.RootToken, provider.client.Token())
+	require.Equal(t, signingToken, provider.signingClient.Token())
+
+	spiffeService := &connect.SpiffeIDService{
+		Host:       "node1",
+		Namespace:  "default",
+		Datacenter: "dc1",
+		Service:    "foo",
+	}
+	csr, _ := connect.TestCSR(t, spiffeService)
+	req, err := connect.ParseCSR(csr)
+	require.NoError(t, err)
+	_, err = provider.Sign(req)
+	require.NoError(t, err)
+
+	// The signing token has no access to the root PKI path, so managing the
+	// intermediate must still be done with Token.
+	_, err = provider.GenerateIntermediate()
+	require.NoError(t, err)
+
+	secret, err = testVault.client.Auth().Token().Lookup(signingToken)
+	require.NoError(t, err)
+	firstRenewal, err := secret.Data["last_renewal_time"].(json.Number).Int64()
+	require.NoError(t, err)
+
+	retry.Run(t, func(r *retry.R) {
+		secret, err := testVault.client.Auth().Token().Lookup(signingToken)
+		require.NoError(r, err)
+		lastRenewal, err := secret.Data["last_renewal_time"].(json.Number).Int64()
+		require.NoError(r, err)
+		require.Greater(r, lastRenewal, firstRenewal)
+	})
+}
+
 func TestVaultCAProvider_Bootstrap(t *testing.T) {
 
 	SkipIfVaultNotPresent(t)
@@ -261,6 +517,142 @@ func TestVaultCAProvider_SignLeaf(t *testing.T) {
 	}
 }
 
+func TestVaultCAProvider_SignLeaf_ExtKeyUsage(t *testing.T) {
+	SkipIfVaultNotPresent(t)
+
+	provider, testVault := testVaultProvider(t)
+	defer testVault.Stop()
+
+	spiffeService := &connect.SpiffeIDService{
+		Host:       "node1",
+		Namespace:  "default",
+		Datacenter: "dc1",
+		Service:    "foo",
+	}
+
+	for name, tc := range leafExtKeyUsageCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			csr := testLeafCSRWithExtensions(t, spiffeService, tc.ext(t))
+
+			cert, err := provider.Sign(csr)
+			if tc.expectErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+
+			parsed, err := connect.ParseCert(cert)
+			require.NoError(t, err)
+			require.ElementsMatch(t, tc.expect, parsed.ExtKeyUsage)
+		})
+	}
+}
+
+func TestVaultCAProvider_SignLeaf_DNSSANs(t *testing.T) {
+	SkipIfVaultNotPresent(t)
+
+	provider, testVault := testVaultProvider(t)
+	defer testVault.Stop()
+
+	spiffeService := &connect.SpiffeIDService{
+		Host:       "node1",
+		Namespace:  "default",
+		Datacenter: "dc1",
+		Service:    "foo",
+	}
+	csr := testLeafCSRWithDNSNames(t, spiffeService, []string{"foo.ingress.consul", "*.ingress.dc1.consul"})
+
+	certPEM, err := provider.Sign(csr)
+	require.NoError(t, err)
+	cert, err := connect.ParseCert(certPEM)
+	require.NoError(t, err)
+	require.Len(t, cert.URIs, 1)
+	require.Equal(t, spiffeService.URI().String(), cert.URIs[0].String())
+	require.ElementsMatch(t, []string{"foo.ingress.consul", "*.ingress.dc1.consul"}, cert.DNSNames)
+}
+
+func TestVaultCAProvider_SignLeaf_LeafRole(t *testing.T) {
+	SkipIfVaultNotPresent(t)
+
+	provider, testVault := testVaultProviderWithConfig(t, true, map[string]interface{}{
+		"LeafRole": map[string]interface{}{
+			"AllowedDomains":  []interface{}{"ingress.consul"},
+			"AllowSubdomains": true,
+			"MaxTTL":          "96h",
+		},
+	})
+	defer testVault.Stop()
+
+	role, err := testVault.client.Logical().Read("pki-intermediate/roles/" + VaultCALeafCertRole)
+	require.NoError(t, err)
+	require.NotNil(t, role)
+	require.Equal(t, false, role.Data["allow_any_name"])
+	require.Equal(t, json.Number("345600"), role.Data["max_ttl"])
+
+	spiffeService := &connect.SpiffeIDService{
+		Host:       "node1",
+		Namespace:  "default",
+		Datacenter: "dc1",
+		Service:    "foo",
+	}
+
+	csr := testLeafCSRWithDNSNames(t, spiffeService, []string{"foo.ingress.consul"})
+	certPEM, err := provider.Sign(csr)
+	require.NoError(t, err)
+	cert, err := connect.ParseCert(certPEM)
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo.ingress.consul"}, cert.DNSNames)
+
+	csr = testLeafCSRWithDNSNames(t, spiffeService, []string{"foo.example.com"})
+	_, err = provider.Sign(csr)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not allowed by this role")
+}
+
+func TestVaultCAProvider_SignLeaf_OCSPResponderURL(t *testing.T) {
+	SkipIfVaultNotPresent(t)
+
+	testVault, err := runTestVault(t)
+	require.NoError(t, err)
+	defer testVault.Stop()
+	testVault.WaitUntilReady(t)
+
+	provider := NewVaultProvider(hclog.New(nil))
+	require.NoError(t, provider.Configure(ProviderConfig{
+		ClusterID:  connect.TestClusterID,
+		Datacenter: "dc1",
+		IsPrimary:  true,
+		RawConfig: map[string]interface{}{
+			"Address":             testVault.Addr,
+			"Token":               testVault.RootToken,
+			"RootPKIPath":         "pki-root/",
+			"IntermediatePKIPath": "pki-intermediate/",
+		},
+		OCSPResponderURL: "http://ocsp.example.com/ocsp",
+	}))
+	require.NoError(t, provider.GenerateRoot())
+	_, err = provider.GenerateIntermediate()
+	require.NoError(t, err)
+
+	spiffeService := &connect.SpiffeIDService{
+		Host:       "node1",
+		Namespace:  "default",
+		Datacenter: "dc1",
+		Service:    "foo",
+	}
+	csr, _ := connect.TestCSR(t, spiffeService)
+	req, err := connect.ParseCSR(csr)
+	require.NoError(t, err)
+
+	cert, err := provider.Sign(req)
+	require.NoError(t, err)
+	parsed, err := connect.ParseCert(cert)
+	require.NoError(t, err)
+	require.Equal(t, []string{"http://ocsp.example.com/ocsp"}, parsed.OCSPServer)
+}
+
 func TestVaultCAProvider_CrossSignCA(t *testing.T) {
 
 	SkipIfVaultNotPresent(t)
@@ -541,3 +933,43 @@ func createVaultProvider(t *testing.T, isPrimary bool, addr, token string, rawCo
 
 	return provider, nil
 }
+
+func TestVaultError(t *testing.T) {
+	cases := map[string]struct {
+		err    error
+		expect error
+	}{
+		"forbidden":        {err: &vaultapi.ResponseError{StatusCode: 403}, expect: ErrSigningDenied},
+		"unauthorized":     {err: &vaultapi.ResponseError{StatusCode: 401}, expect: ErrSigningDenied},
+		"server error":     {err: &vaultapi.ResponseError{StatusCode: 503}, expect: ErrProviderUnreachable},
+		"connection error": {err: fmt.Errorf("dial tcp 127.0.0.1:8200: connect: connection refused"), expect: ErrProviderUnreachable},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := vaultError(tc.err, ErrSigningDenied)
+			require.True(t, errors.Is(err, tc.expect), "expected %v, got %v", tc.expect, err)
+		})
+	}
+
+	t.Run("bad request", func(t *testing.T) {
+		orig := &vaultapi.ResponseError{StatusCode: 400}
+		require.Equal(t, error(orig), vaultError(orig, ErrSigningDenied))
+	})
+}
+
+func TestVaultCAProvider_Configure_Misconfigured(t *testing.T) {
+	provider := NewVaultProvider(hclog.New(nil))
+	err := provider.Configure(ProviderConfig{
+		ClusterID: connect.TestClusterID,
+		IsPrimary: true,
+		RawConfig: map[string]interface{}{
+			"Address":             "http://127.0.0.1:8200",
+			"RootPKIPath":         "pki-root/",
+			"IntermediatePKIPath": "pki-intermediate/",
+		},
+	})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrProviderMisconfigured))
+}
@@ -1,19 +1,28 @@
 package ca
 
 import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"os"
 	"os/exec"
 	"sync"
 
+	"github.com/gogo/protobuf/types"
 	"github.com/hashicorp/go-hclog"
 	vaultapi "github.com/hashicorp/vault/api"
 	"github.com/mitchellh/go-testing-interface"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/hashicorp/consul/agent/connect"
 	"github.com/hashicorp/consul/agent/consul/state"
 	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto/pbcaprovider"
 	"github.com/hashicorp/consul/sdk/freeport"
 	"github.com/hashicorp/consul/sdk/testutil/retry"
 )
@@ -260,6 +269,219 @@ func ApplyCARequestToStore(store *state.Store, req *structs.CARequest) (interfac
 		return nil, fmt.Errorf("Invalid CA operation '%s'", req.Op)
 	}
 }
+
+// TestGRPCProviderServer is an in-process implementation of the external CA
+// provider gRPC service for tests. It proxies to a ConsulProvider backed by
+// its own state store, so the CA survives the provider being reconfigured
+// like a real external process would.
+type TestGRPCProviderServer struct {
+	Addr string
+
+	server *grpc.Server
+	store  *state.Store
+
+	lock     sync.Mutex
+	provider *ConsulProvider
+}
+
+// NewTestGRPCProviderServer starts a TestGRPCProviderServer listening on a
+// local port. It is stopped when the test completes.
+func NewTestGRPCProviderServer(t testing.T) *TestGRPCProviderServer {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	s := &TestGRPCProviderServer{
+		Addr:   lis.Addr().String(),
+		server: grpc.NewServer(),
+		store:  state.NewStateStore(nil),
+	}
+	pbcaprovider.RegisterCAProviderServer(s.server, &testGRPCProviderService{s: s})
+	go s.server.Serve(lis)
+	t.Cleanup(s.server.Stop)
+	return s
+}
+
+// testGRPCProviderService implements pbcaprovider.CAProviderServer for
+// TestGRPCProviderServer.
+type testGRPCProviderService struct {
+	pbcaprovider.UnimplementedCAProviderServer
+	s *TestGRPCProviderServer
+}
+
+// testGRPCProviderDelegate is the ConsulProviderStateDelegate for the
+// ConsulProvider behind a TestGRPCProviderServer.
+type testGRPCProviderDelegate struct {
+	store *state.Store
+}
+
+func (d *testGRPCProviderDelegate) State() *state.Store {
+	return d.store
+}
+
+func (d *testGRPCProviderDelegate) ApplyCARequest(req *structs.CARequest) (interface{}, error) {
+	return ApplyCARequestToStore(d.store, req)
+}
+
+func (t *testGRPCProviderService) getProvider() (*ConsulProvider, error) {
+	t.s.lock.Lock()
+	defer t.s.lock.Unlock()
+	if t.s.provider == nil {
+		return nil, status.Error(codes.FailedPrecondition, "provider not configured")
+	}
+	return t.s.provider, nil
+}
+
+func (t *testGRPCProviderService) Configure(_ context.Context, req *pbcaprovider.ConfigureRequest) (*pbcaprovider.ConfigureResponse, error) {
+	var rawConfig map[string]interface{}
+	if err := json.Unmarshal(req.ConfigJSON, &rawConfig); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// The ConsulProvider reads the cluster ID from the CA config in its store.
+	idx, _, err := t.s.store.CAConfig(nil)
+	if err != nil {
+		return nil, err
+	}
+	caConfig := &structs.CAConfiguration{ClusterID: req.ClusterID, Provider: structs.ConsulCAProvider}
+	if err := t.s.store.CASetConfig(idx+1, caConfig); err != nil {
+		return nil, err
+	}
+
+	provider := TestConsulProvider(nil, &testGRPCProviderDelegate{store: t.s.store})
+	err = provider.Configure(ProviderConfig{
+		ClusterID:        req.ClusterID,
+		Datacenter:       req.Datacenter,
+		IsPrimary:        req.IsPrimary,
+		RawConfig:        rawConfig,
+		State:            req.State,
+		OCSPResponderURL: req.OCSPResponderURL,
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	t.s.lock.Lock()
+	t.s.provider = provider
+	t.s.lock.Unlock()
+	return &pbcaprovider.ConfigureResponse{SupportsCrossSigning: true}, nil
+}
+
+func (t *testGRPCProviderService) State(_ context.Context, _ *pbcaprovider.StateRequest) (*pbcaprovider.StateResponse, error) {
+	return &pbcaprovider.StateResponse{}, nil
+}
+
+func (t *testGRPCProviderService) GenerateRoot(_ context.Context, _ *pbcaprovider.GenerateRootRequest) (*pbcaprovider.GenerateRootResponse, error) {
+	p, err := t.getProvider()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.GenerateRoot(); err != nil {
+		return nil, err
+	}
+	root, err := p.ActiveRoot()
+	if err != nil {
+		return nil, err
+	}
+	return &pbcaprovider.GenerateRootResponse{RootPEM: root}, nil
+}
+
+func (t *testGRPCProviderService) ActiveIntermediate(_ context.Context, _ *pbcaprovider.ActiveIntermediateRequest) (*pbcaprovider.ActiveIntermediateResponse, error) {
+	p, err := t.getProvider()
+	if err != nil {
+		return nil, err
+	}
+	root, err := p.ActiveRoot()
+	if err != nil {
+		return nil, err
+	}
+	inter, err := p.ActiveIntermediate()
+	if err != nil {
+		return nil, err
+	}
+	return &pbcaprovider.ActiveIntermediateResponse{IntermediatePEM: inter, RootPEM: root}, nil
+}
+
+func (t *testGRPCProviderService) GenerateIntermediateCSR(_ context.Context, _ *pbcaprovider.GenerateIntermediateCSRRequest) (*pbcaprovider.GenerateIntermediateCSRResponse, error) {
+	p, err := t.getProvider()
+	if err != nil {
+		return nil, err
+	}
+	csr, err := p.GenerateIntermediateCSR()
+	if err != nil {
+		return nil, err
+	}
+	return &pbcaprovider.GenerateIntermediateCSRResponse{CSRPEM: csr}, nil
+}
+
+func (t *testGRPCProviderService) SetIntermediate(_ context.Context, req *pbcaprovider.SetIntermediateRequest) (*pbcaprovider.SetIntermediateResponse, error) {
+	p, err := t.getProvider()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.SetIntermediate(req.IntermediatePEM, req.RootPEM); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &pbcaprovider.SetIntermediateResponse{}, nil
+}
+
+func (t *testGRPCProviderService) SignLeaf(_ context.Context, req *pbcaprovider.SignLeafRequest) (*pbcaprovider.SignLeafResponse, error) {
+	p, err := t.getProvider()
+	if err != nil {
+		return nil, err
+	}
+	csr, err := x509.ParseCertificateRequest(req.CSR)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ttl, err := types.DurationFromProto(req.TTL)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	cert, err := p.SignWithTTL(csr, ttl)
+	if err != nil {
+		return nil, err
+	}
+	return &pbcaprovider.SignLeafResponse{CertPEM: cert}, nil
+}
+
+func (t *testGRPCProviderService) SignIntermediate(_ context.Context, req *pbcaprovider.SignIntermediateRequest) (*pbcaprovider.SignIntermediateResponse, error) {
+	p, err := t.getProvider()
+	if err != nil {
+		return nil, err
+	}
+	csr, err := x509.ParseCertificateRequest(req.CSR)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	cert, err := p.SignIntermediate(csr)
+	if err != nil {
+		return nil, err
+	}
+	return &pbcaprovider.SignIntermediateResponse{CertPEM: cert}, nil
+}
+
+func (t *testGRPCProviderService) CrossSignCA(_ context.Context, req *pbcaprovider.CrossSignCARequest) (*pbcaprovider.CrossSignCAResponse, error) {
+	p, err := t.getProvider()
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(req.Cert)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	xc, err := p.CrossSignCA(cert)
+	if err != nil {
+		return nil, err
+	}
+	return &pbcaprovider.CrossSignCAResponse{CertPEM: xc}, nil
+}
+
+func (t *testGRPCProviderService) Cleanup(_ context.Context, _ *pbcaprovider.CleanupRequest) (*pbcaprovider.CleanupResponse, error) {
+	return &pbcaprovider.CleanupResponse{}, nil
+}
+
 func requireTrailingNewline(t testing.T, leafPEM string) {
 	t.Helper()
 	if len(leafPEM) == 0 {
@@ -3,14 +3,19 @@ package connect
 import (
 	"bytes"
 	"crypto"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
 	"encoding/pem"
+	"fmt"
 	"net"
 	"net/url"
+	"strings"
+
+	"github.com/hashicorp/consul/agent/structs"
 )
 
 // SigAlgoForKey returns the preferred x509.SignatureAlgorithm for a given key
@@ -18,8 +23,13 @@ import (
 // ECDSAWithSHA256 on the basis that it will fail anyway and we've already type
 // checked keys by the time we call this in general.
 func SigAlgoForKey(key crypto.Signer) x509.SignatureAlgorithm {
-	if _, ok := key.(*rsa.PrivateKey); ok {
+	// Switch on the public key so that signers backed by an external key
+	// store, rather than an in-memory private key, get the right algorithm.
+	switch key.Public().(type) {
+	case *rsa.PublicKey:
 		return x509.SHA256WithRSA
+	case ed25519.PublicKey:
+		return x509.PureEd25519
 	}
 	// We default to ECDSA but don't bother detecting invalid key types as we do
 	// that in lots of other places and it will fail anyway if we try to sign with
@@ -35,6 +45,8 @@ func SigAlgoForKeyType(keyType string) x509.SignatureAlgorithm {
 	switch keyType {
 	case "rsa":
 		return x509.SHA256WithRSA
+	case "ed25519":
+		return x509.PureEd25519
 	case "ec":
 		fallthrough
 	default:
@@ -42,11 +54,53 @@ func SigAlgoForKeyType(keyType string) x509.SignatureAlgorithm {
 	}
 }
 
+// RSASigAlgo returns the x509.SignatureAlgorithm for one of the values allowed
+// for structs.CAConfiguration.SignatureAlgorithm.
+func RSASigAlgo(name string) (x509.SignatureAlgorithm, error) {
+	switch name {
+	case structs.SignatureAlgorithmSHA256WithRSA:
+		return x509.SHA256WithRSA, nil
+	case structs.SignatureAlgorithmSHA384WithRSA:
+		return x509.SHA384WithRSA, nil
+	case structs.SignatureAlgorithmSHA512WithRSA:
+		return x509.SHA512WithRSA, nil
+	default:
+		return x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported signature algorithm %q", name)
+	}
+}
+
 // CreateCSR returns a CSR to sign the given service with SAN entries
 // along with the PEM-encoded private key for this certificate.
 func CreateCSR(uri CertURI, privateKey crypto.Signer,
+	dnsNames []string, ipAddresses []net.IP, extensions ...pkix.Extension) (string, error) {
+	return createCSR(pkix.Name{}, uri, privateKey, dnsNames, ipAddresses, extensions...)
+}
+
+// CSRFromService returns a PEM-encoded CSR for a leaf certificate of the
+// given service, signed by signer. It carries the service's SPIFFE ID as its
+// only URI SAN, which is what the Connect CA expects when signing a leaf.
+func CSRFromService(svc *SpiffeIDService, signer crypto.Signer) (string, error) {
+	if svc == nil {
+		return "", fmt.Errorf("a SPIFFE ID is required")
+	}
+	if signer == nil {
+		return "", fmt.Errorf("a signer is required")
+	}
+	switch {
+	case svc.Host == "":
+		return "", fmt.Errorf("SPIFFE ID is missing the trust domain")
+	case svc.Datacenter == "":
+		return "", fmt.Errorf("SPIFFE ID is missing the datacenter")
+	case svc.Service == "":
+		return "", fmt.Errorf("SPIFFE ID is missing the service")
+	}
+	return CreateCSR(svc, signer, nil, nil)
+}
+
+func createCSR(subject pkix.Name, uri CertURI, privateKey crypto.Signer,
 	dnsNames []string, ipAddresses []net.IP, extensions ...pkix.Extension) (string, error) {
 	template := &x509.CertificateRequest{
+		Subject:            subject,
 		URIs:               []*url.URL{uri.URI()},
 		SignatureAlgorithm: SigAlgoForKey(privateKey),
 		ExtraExtensions:    extensions,
@@ -73,12 +127,18 @@ func CreateCSR(uri CertURI, privateKey crypto.Signer,
 // CreateCSR returns a CA CSR to sign the given service along with the PEM-encoded
 // private key for this certificate.
 func CreateCACSR(uri CertURI, privateKey crypto.Signer) (string, error) {
+	return CreateCACSRWithSubject(uri, privateKey, pkix.Name{})
+}
+
+// CreateCACSRWithSubject is like CreateCACSR but requests the given subject
+// for the CA certificate.
+func CreateCACSRWithSubject(uri CertURI, privateKey crypto.Signer, subject pkix.Name) (string, error) {
 	ext, err := CreateCAExtension()
 	if err != nil {
 		return "", err
 	}
 
-	return CreateCSR(uri, privateKey, nil, nil, ext)
+	return createCSR(subject, uri, privateKey, nil, nil, ext)
 }
 
 // CreateCAExtension creates a pkix.Extension for the x509 Basic Constraints
@@ -100,3 +160,206 @@ func CreateCAExtension() (pkix.Extension, error) {
 		Value:    bitstr,
 	}, nil
 }
+
+var (
+	oidExtensionBasicConstraints = asn1.ObjectIdentifier{2, 5, 29, 19}
+	oidExtensionKeyUsage         = asn1.ObjectIdentifier{2, 5, 29, 15}
+	oidExtensionExtendedKeyUsage = asn1.ObjectIdentifier{2, 5, 29, 37}
+	oidExtKeyUsageServerAuth     = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 1}
+	oidExtKeyUsageClientAuth     = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 2}
+)
+
+// ValidateCACSR checks that the given CSR requests a CA certificate. The CSR
+// must carry a Basic Constraints extension with IsCA set and, if it requests
+// any key usage, that usage must include certificate signing. Leaf-shaped CSRs
+// are rejected.
+func ValidateCACSR(csr *x509.CertificateRequest) error {
+	var isCA bool
+	for _, ext := range csr.Extensions {
+		switch {
+		case ext.Id.Equal(oidExtensionBasicConstraints):
+			var basicCon struct {
+				IsCA       bool `asn1:"optional"`
+				MaxPathLen int  `asn1:"optional,default:-1"`
+			}
+			if _, err := asn1.Unmarshal(ext.Value, &basicCon); err != nil {
+				return fmt.Errorf("error parsing CSR basic constraints: %v", err)
+			}
+			isCA = basicCon.IsCA
+
+		case ext.Id.Equal(oidExtensionKeyUsage):
+			var usage asn1.BitString
+			if _, err := asn1.Unmarshal(ext.Value, &usage); err != nil {
+				return fmt.Errorf("error parsing CSR key usage: %v", err)
+			}
+			// Bit 5 is keyCertSign, see RFC 5280 section 4.2.1.3.
+			if usage.At(5) == 0 {
+				return fmt.Errorf("CSR key usage must include certificate signing")
+			}
+		}
+	}
+
+	if !isCA {
+		return fmt.Errorf("CSR must request a CA certificate")
+	}
+	return nil
+}
+
+// CreateExtKeyUsageExtension creates a pkix.Extension requesting the given
+// extended key usages for a leaf certificate. Only client and server auth are
+// supported.
+func CreateExtKeyUsageExtension(usages ...x509.ExtKeyUsage) (pkix.Extension, error) {
+	oids := make([]asn1.ObjectIdentifier, 0, len(usages))
+	for _, usage := range usages {
+		switch usage {
+		case x509.ExtKeyUsageServerAuth:
+			oids = append(oids, oidExtKeyUsageServerAuth)
+		case x509.ExtKeyUsageClientAuth:
+			oids = append(oids, oidExtKeyUsageClientAuth)
+		default:
+			return pkix.Extension{}, fmt.Errorf("unsupported extended key usage: %d", usage)
+		}
+	}
+
+	bs, err := asn1.Marshal(oids)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+	return pkix.Extension{
+		Id:    oidExtensionExtendedKeyUsage,
+		Value: bs,
+	}, nil
+}
+
+// LeafExtKeyUsage returns the extended key usages a leaf certificate signed
+// for csr should carry. A CSR may request client auth, server auth or both;
+// CSRs that don't request any get both for backwards compatibility. Any other
+// usage is rejected.
+func LeafExtKeyUsage(csr *x509.CertificateRequest) ([]x509.ExtKeyUsage, error) {
+	for _, ext := range csr.Extensions {
+		if !ext.Id.Equal(oidExtensionExtendedKeyUsage) {
+			continue
+		}
+
+		var oids []asn1.ObjectIdentifier
+		if _, err := asn1.Unmarshal(ext.Value, &oids); err != nil {
+			return nil, fmt.Errorf("error parsing CSR extended key usage: %v", err)
+		}
+
+		var server, client bool
+		for _, oid := range oids {
+			switch {
+			case oid.Equal(oidExtKeyUsageServerAuth):
+				server = true
+			case oid.Equal(oidExtKeyUsageClientAuth):
+				client = true
+			default:
+				return nil, fmt.Errorf("unsupported extended key usage in CSR: %s", oid)
+			}
+		}
+
+		switch {
+		case server && client:
+			return []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth}, nil
+		case server:
+			return []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, nil
+		case client:
+			return []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, nil
+		default:
+			return nil, fmt.Errorf("CSR extended key usage must include client or server auth")
+		}
+	}
+	return []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth}, nil
+}
+
+// ValidateCSR returns an error if csr is not a well-formed request for
+// expected. The CSR must be self-signed by its key and carry exactly one URI
+// SAN, which must parse as the same kind of SPIFFE ID as expected. Service IDs
+// must name the same trust domain, partition, namespace, datacenter and
+// service, and agent IDs the same trust domain, partition, datacenter and
+// agent. Any additional URI would be copied into the signed leaf, so rejecting
+// it prevents a caller authorized for one identity from obtaining a cert for
+// another.
+func ValidateCSR(csr *x509.CertificateRequest, expected CertURI) error {
+	if err := csr.CheckSignature(); err != nil {
+		return fmt.Errorf("invalid CSR signature: %s", err)
+	}
+	if len(csr.URIs) != 1 {
+		return fmt.Errorf("CSR must have exactly one URI SAN, found %d", len(csr.URIs))
+	}
+
+	certURI, err := ParseCertURI(csr.URIs[0])
+	if err != nil {
+		return fmt.Errorf("CSR URI SAN is not a valid SPIFFE ID: %s", err)
+	}
+
+	switch expected := expected.(type) {
+	case *SpiffeIDService:
+		actual, ok := certURI.(*SpiffeIDService)
+		if !ok {
+			return fmt.Errorf("CSR URI SAN %q is not a service SPIFFE ID", csr.URIs[0])
+		}
+		switch {
+		case !strings.EqualFold(actual.Host, expected.Host):
+			return fmt.Errorf("CSR trust domain %q does not match %q", actual.Host, expected.Host)
+		case actual.PartitionOrDefault() != expected.PartitionOrDefault():
+			return fmt.Errorf("CSR partition %q does not match %q", actual.PartitionOrDefault(), expected.PartitionOrDefault())
+		case spiffeNamespace(actual.Namespace) != spiffeNamespace(expected.Namespace):
+			return fmt.Errorf("CSR namespace %q does not match %q", spiffeNamespace(actual.Namespace), spiffeNamespace(expected.Namespace))
+		case actual.Datacenter != expected.Datacenter:
+			return fmt.Errorf("CSR datacenter %q does not match %q", actual.Datacenter, expected.Datacenter)
+		case actual.Service != expected.Service:
+			return fmt.Errorf("CSR service %q does not match %q", actual.Service, expected.Service)
+		}
+
+	case *SpiffeIDAgent:
+		actual, ok := certURI.(*SpiffeIDAgent)
+		if !ok {
+			return fmt.Errorf("CSR URI SAN %q is not an agent SPIFFE ID", csr.URIs[0])
+		}
+		switch {
+		case !strings.EqualFold(actual.Host, expected.Host):
+			return fmt.Errorf("CSR trust domain %q does not match %q", actual.Host, expected.Host)
+		case actual.PartitionOrDefault() != expected.PartitionOrDefault():
+			return fmt.Errorf("CSR partition %q does not match %q", actual.PartitionOrDefault(), expected.PartitionOrDefault())
+		case actual.Datacenter != expected.Datacenter:
+			return fmt.Errorf("CSR datacenter %q does not match %q", actual.Datacenter, expected.Datacenter)
+		case actual.Agent != expected.Agent:
+			return fmt.Errorf("CSR agent %q does not match %q", actual.Agent, expected.Agent)
+		}
+
+	default:
+		return fmt.Errorf("cannot validate a CSR for SPIFFE ID type %T", expected)
+	}
+	return nil
+}
+
+// ValidateCSRDNSNames checks that every DNS SAN in a service CSR is permitted
+// by allowlist. Entries are exact names or "*.domain" patterns matching any
+// name below domain, compared case-insensitively. An empty allowlist doesn't
+// restrict DNS SANs.
+func ValidateCSRDNSNames(csr *x509.CertificateRequest, allowlist []string) error {
+	if len(allowlist) == 0 {
+		return nil
+	}
+	for _, name := range csr.DNSNames {
+		if !dnsSANAllowed(name, allowlist) {
+			return fmt.Errorf("CSR DNS SAN %q is not in the leaf DNS SAN allowlist", name)
+		}
+	}
+	return nil
+}
+
+func dnsSANAllowed(name string, allowlist []string) bool {
+	name = strings.ToLower(name)
+	for _, pattern := range allowlist {
+		pattern = strings.ToLower(pattern)
+		if name == pattern {
+			return true
+		}
+		if strings.HasPrefix(pattern, "*.") && strings.HasSuffix(name, pattern[1:]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package connect
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCSR(t *testing.T) {
+	host := TestClusterID + ".consul"
+	expected := TestSpiffeIDServiceWithHostDC(t, "web", host, "dc1")
+
+	parse := func(t *testing.T, uri CertURI) *x509.CertificateRequest {
+		csrPEM, _ := TestCSR(t, uri)
+		csr, err := ParseCSR(csrPEM)
+		require.NoError(t, err)
+		return csr
+	}
+
+	cases := []struct {
+		name string
+		uri  CertURI
+		err  string
+	}{
+		{"valid", TestSpiffeIDServiceWithHostDC(t, "web", host, "dc1"), ""},
+		{"other service", TestSpiffeIDServiceWithHostDC(t, "db", host, "dc1"), `CSR service "db" does not match "web"`},
+		{"other datacenter", TestSpiffeIDServiceWithHostDC(t, "web", host, "dc2"), `CSR datacenter "dc2" does not match "dc1"`},
+		{"other namespace", &SpiffeIDService{Host: host, Namespace: "other", Datacenter: "dc1", Service: "web"}, `CSR namespace "other" does not match "default"`},
+		{"other trust domain", TestSpiffeIDServiceWithHostDC(t, "web", "other.consul", "dc1"), "CSR trust domain"},
+		{"agent ID", &SpiffeIDAgent{Host: host, Datacenter: "dc1", Agent: "web"}, "is not a service SPIFFE ID"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateCSR(parse(t, tc.uri), expected)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+
+	t.Run("extra URI", func(t *testing.T) {
+		signer, _ := testPrivateKey(t, DefaultPrivateKeyType, DefaultPrivateKeyBits)
+		template := &x509.CertificateRequest{
+			URIs: []*url.URL{
+				expected.URI(),
+				TestSpiffeIDServiceWithHostDC(t, "db", host, "dc1").URI(),
+			},
+			SignatureAlgorithm: x509.ECDSAWithSHA256,
+		}
+		bs, err := x509.CreateCertificateRequest(rand.Reader, template, signer)
+		require.NoError(t, err)
+		csr, err := x509.ParseCertificateRequest(bs)
+		require.NoError(t, err)
+
+		err = ValidateCSR(csr, expected)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "exactly one URI SAN, found 2")
+	})
+
+	t.Run("tampered signature", func(t *testing.T) {
+		csr := parse(t, expected)
+		csr.Signature[len(csr.Signature)-1] ^= 0xff
+
+		err := ValidateCSR(csr, expected)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid CSR signature")
+	})
+}
+
+func TestValidateCSR_Agent(t *testing.T) {
+	host := TestClusterID + ".consul"
+	expected := &SpiffeIDAgent{Host: host, Datacenter: "dc1", Agent: "node1"}
+
+	cases := []struct {
+		name string
+		uri  CertURI
+		err  string
+	}{
+		{"valid", &SpiffeIDAgent{Host: host, Datacenter: "dc1", Agent: "node1"}, ""},
+		{"other agent", &SpiffeIDAgent{Host: host, Datacenter: "dc1", Agent: "node2"}, `CSR agent "node2" does not match "node1"`},
+		{"other datacenter", &SpiffeIDAgent{Host: host, Datacenter: "dc2", Agent: "node1"}, `CSR datacenter "dc2" does not match "dc1"`},
+		{"other trust domain", &SpiffeIDAgent{Host: "other.consul", Datacenter: "dc1", Agent: "node1"}, "CSR trust domain"},
+		{"service ID", TestSpiffeIDServiceWithHostDC(t, "node1", host, "dc1"), "is not an agent SPIFFE ID"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			csrPEM, _ := TestCSR(t, tc.uri)
+			csr, err := ParseCSR(csrPEM)
+			require.NoError(t, err)
+
+			err = ValidateCSR(csr, expected)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
+func TestValidateCSRDNSNames(t *testing.T) {
+	allowlist := []string{"*.ingress.consul", "web.example.com"}
+
+	cases := []struct {
+		name     string
+		dnsNames []string
+		err      string
+	}{
+		{"none", nil, ""},
+		{"exact", []string{"web.example.com"}, ""},
+		{"exact different case", []string{"Web.Example.com"}, ""},
+		{"below wildcard", []string{"web.ingress.consul", "a.b.ingress.consul"}, ""},
+		{"wildcard name", []string{"*.ingress.consul"}, ""},
+		{"wildcard parent", []string{"ingress.consul"}, `"ingress.consul" is not in`},
+		{"other name", []string{"web.ingress.consul", "api.example.com"}, `"api.example.com" is not in`},
+		{"suffix without dot", []string{"evilingress.consul"}, `"evilingress.consul" is not in`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			csr := &x509.CertificateRequest{DNSNames: tc.dnsNames}
+			err := ValidateCSRDNSNames(csr, allowlist)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+
+	t.Run("empty allowlist", func(t *testing.T) {
+		require.NoError(t, ValidateCSRDNSNames(&x509.CertificateRequest{}, nil))
+		require.NoError(t, ValidateCSRDNSNames(&x509.CertificateRequest{DNSNames: []string{"web.example.com"}}, nil))
+		require.NoError(t, ValidateCSRDNSNames(&x509.CertificateRequest{DNSNames: []string{"web.example.com"}}, []string{}))
+	})
+}
+
+func TestCSRFromService(t *testing.T) {
+	svc := TestSpiffeIDService(t, "web")
+	signer, _, err := GeneratePrivateKey()
+	require.NoError(t, err)
+
+	csrPEM, err := CSRFromService(svc, signer)
+	require.NoError(t, err)
+
+	csr, err := ParseCSR(csrPEM)
+	require.NoError(t, err)
+	require.NoError(t, csr.CheckSignature())
+	require.Len(t, csr.URIs, 1)
+	require.Equal(t, svc.URI().String(), csr.URIs[0].String())
+	require.NoError(t, ValidateCSR(csr, svc))
+
+	t.Run("missing arguments", func(t *testing.T) {
+		_, err := CSRFromService(nil, signer)
+		require.Error(t, err)
+
+		_, err = CSRFromService(svc, nil)
+		require.Error(t, err)
+
+		_, err = CSRFromService(&SpiffeIDService{Host: svc.Host, Datacenter: "dc1"}, signer)
+		require.EqualError(t, err, "SPIFFE ID is missing the service")
+	})
+}
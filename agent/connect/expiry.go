@@ -0,0 +1,26 @@
+package connect
+
+import (
+	"crypto/x509"
+	"time"
+)
+
+// LeafExpiringWithin reports whether at least fraction of the lifetime of
+// cert, from NotBefore to NotAfter, has passed, meaning it is due for renewal.
+// For example a fraction of 0.5 renews a cert once half its lifetime has
+// passed. This is the same calculation the servers use to decide when to renew
+// their CA certificates.
+func LeafExpiringWithin(cert *x509.Certificate, fraction float64) bool {
+	return leafExpiringWithin(cert, fraction, time.Now())
+}
+
+func leafExpiringWithin(cert *x509.Certificate, fraction float64, now time.Time) bool {
+	return FractionTimePassed(now, cert.NotBefore, cert.NotAfter, fraction)
+}
+
+// FractionTimePassed decides if at least fraction of the time between start
+// and end has passed relative to now.
+func FractionTimePassed(now, start, end time.Time, fraction float64) bool {
+	t := start.Add(time.Duration(fraction * float64(end.Sub(start))))
+	return t.Sub(now) <= 0
+}
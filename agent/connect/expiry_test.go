@@ -0,0 +1,61 @@
+package connect
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testCertWithLifetime(t *testing.T, notBefore, notAfter time.Time) *x509.Certificate {
+	signer, _ := testPrivateKey(t, DefaultPrivateKeyType, DefaultPrivateKeyBits)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	bs, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(bs)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestLeafExpiringWithin(t *testing.T) {
+	// Certificates only have second precision.
+	now := time.Now().Truncate(time.Second)
+	tests := []struct {
+		name      string
+		notBefore time.Time
+		notAfter  time.Time
+		fraction  float64
+		want      bool
+	}{
+		{"half, expired", now.Add(-10 * time.Second), now.Add(-5 * time.Second), 0.5, true},
+		{"half, ends now", now.Add(-10 * time.Second), now, 0.5, true},
+		{"half, past", now.Add(-10 * time.Second), now.Add(5 * time.Second), 0.5, true},
+		{"half, exactly", now.Add(-10 * time.Second), now.Add(10 * time.Second), 0.5, true},
+		{"half, not yet", now.Add(-10 * time.Second), now.Add(20 * time.Second), 0.5, false},
+
+		{"three quarters, exactly", now.Add(-30 * time.Second), now.Add(10 * time.Second), 0.75, true},
+		{"three quarters, not yet", now.Add(-10 * time.Second), now.Add(10 * time.Second), 0.75, false},
+		{"three quarters, just started", now, now.Add(10 * time.Second), 0.75, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cert := testCertWithLifetime(t, tc.notBefore, tc.notAfter)
+			require.Equal(t, tc.want, leafExpiringWithin(cert, tc.fraction, now))
+		})
+	}
+
+	t.Run("uses the current time", func(t *testing.T) {
+		fresh := testCertWithLifetime(t, time.Now().Add(-time.Minute), time.Now().Add(time.Hour))
+		require.False(t, LeafExpiringWithin(fresh, 0.5))
+
+		old := testCertWithLifetime(t, time.Now().Add(-time.Hour), time.Now().Add(time.Minute))
+		require.True(t, LeafExpiringWithin(old, 0.5))
+	})
+}
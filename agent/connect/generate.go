@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
@@ -12,6 +13,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/hashicorp/consul/agent/structs"
 )
 
 const (
@@ -81,6 +84,25 @@ func generateECDSAKey(keyBits int) (crypto.Signer, string, error) {
 	return pk, pemBlock, nil
 }
 
+func generateEd25519Key() (crypto.Signer, string, error) {
+	_, pk, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, "", fmt.Errorf("error generating Ed25519 private key: %s", err)
+	}
+
+	bs, err := x509.MarshalPKCS8PrivateKey(pk)
+	if err != nil {
+		return nil, "", fmt.Errorf("error marshaling Ed25519 private key: %s", err)
+	}
+
+	pemBlock, err := pemEncodeKey(bs, "PRIVATE KEY")
+	if err != nil {
+		return nil, "", err
+	}
+
+	return pk, pemBlock, nil
+}
+
 // GeneratePrivateKey generates a new Private key
 func GeneratePrivateKeyWithConfig(keyType string, keyBits int) (crypto.Signer, string, error) {
 	switch strings.ToLower(keyType) {
@@ -88,11 +110,25 @@ func GeneratePrivateKeyWithConfig(keyType string, keyBits int) (crypto.Signer, s
 		return generateRSAKey(keyBits)
 	case "ec":
 		return generateECDSAKey(keyBits)
+	case "ed25519":
+		// Ed25519 keys have a fixed size so keyBits is ignored.
+		return generateEd25519Key()
 	default:
 		return nil, "", fmt.Errorf("unknown private key type requested: %s", keyType)
 	}
 }
 
+// GeneratePrivateKeyWithType generates a new private key of the given type and
+// length. Unlike GeneratePrivateKeyWithConfig it first checks that keyType and
+// keyBits are a combination accepted in the CA configuration, so for example
+// an Ed25519 key must be requested with 256 bits.
+func GeneratePrivateKeyWithType(keyType string, keyBits int) (crypto.Signer, string, error) {
+	if err := structs.ValidateCAKeyType(keyType, keyBits); err != nil {
+		return nil, "", err
+	}
+	return GeneratePrivateKeyWithConfig(keyType, keyBits)
+}
+
 func GeneratePrivateKey() (crypto.Signer, string, error) {
 	// TODO: find any calls to this func, replace with calls to GeneratePrivateKeyWithConfig()
 	// using prefs `private_key_type` and `private_key_bits`
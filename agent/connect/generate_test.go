@@ -1,6 +1,9 @@
 package connect
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
 	"fmt"
 	"testing"
 	"time"
@@ -24,6 +27,7 @@ var goodParams = []KeyConfig{
 	{keyType: "ec", keyBits: 256},
 	{keyType: "ec", keyBits: 384},
 	{keyType: "ec", keyBits: 521},
+	{keyType: "ed25519", keyBits: 256},
 }
 var badParams = []KeyConfig{
 	{keyType: "rsa", keyBits: 0},
@@ -33,6 +37,7 @@ var badParams = []KeyConfig{
 	{keyType: "ec", keyBits: 512},
 	{keyType: "ec", keyBits: 321},
 	{keyType: "ecdsa", keyBits: 256}, // test for "ecdsa" instead of "ec"
+	{keyType: "ed25519", keyBits: 512},
 	{keyType: "aes", keyBits: 128},
 }
 
@@ -75,6 +80,20 @@ func testGenerateECDSAKey(t *testing.T, bits int) {
 	r.Equal(bits, pk.Curve.Params().BitSize)
 }
 
+func testGenerateEd25519Key(t *testing.T) {
+	r := require.New(t)
+	_, pemBlock, err := GeneratePrivateKeyWithConfig("ed25519", 256)
+	r.NoError(err)
+	r.Contains(pemBlock, "PRIVATE KEY")
+
+	block, _ := pem.Decode([]byte(pemBlock))
+	r.NotNil(block)
+
+	pk, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	r.NoError(err)
+	r.IsType(ed25519.PrivateKey{}, pk)
+}
+
 // Tests to make sure we are able to generate every type of private key supported by the x509 lib.
 func TestGenerateKeys(t *testing.T) {
 	if testing.Short() {
@@ -90,6 +109,8 @@ func TestGenerateKeys(t *testing.T) {
 					testGenerateRSAKey(t, params.keyBits)
 				case "ec":
 					testGenerateECDSAKey(t, params.keyBits)
+				case "ed25519":
+					testGenerateEd25519Key(t)
 				default:
 					t.Fatalf("unknown key type: %s", params.keyType)
 				}
@@ -151,3 +172,49 @@ func TestSignatureMismatches(t *testing.T) {
 		}
 	}
 }
+
+func TestGeneratePrivateKeyWithType(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+	for _, params := range goodParams {
+		params := params
+		t.Run(fmt.Sprintf("%s-%d", params.keyType, params.keyBits), func(t *testing.T) {
+			signer, pemBlock, err := GeneratePrivateKeyWithType(params.keyType, params.keyBits)
+			require.NoError(t, err)
+			require.NotNil(t, signer)
+
+			parsed, err := ParseSigner(pemBlock)
+			require.NoError(t, err)
+			require.Equal(t, signer.Public(), parsed.Public())
+
+			switch k := signer.(type) {
+			case *rsa.PrivateKey:
+				require.Equal(t, "rsa", params.keyType)
+				require.Equal(t, params.keyBits, k.N.BitLen())
+			case *ecdsa.PrivateKey:
+				require.Equal(t, "ec", params.keyType)
+				require.Equal(t, params.keyBits, k.Curve.Params().BitSize)
+			case ed25519.PrivateKey:
+				require.Equal(t, "ed25519", params.keyType)
+			default:
+				t.Fatalf("unexpected key type %T", signer)
+			}
+		})
+	}
+}
+
+func TestGeneratePrivateKeyWithType_BadParams(t *testing.T) {
+	t.Parallel()
+	for _, params := range badParams {
+		params := params
+		t.Run(fmt.Sprintf("%s-%d", params.keyType, params.keyBits), func(t *testing.T) {
+			signer, pemBlock, err := GeneratePrivateKeyWithType(params.keyType, params.keyBits)
+			require.Error(t, err)
+			require.Nil(t, signer)
+			require.Empty(t, pemBlock)
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package connect
 
 import (
+	"bytes"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"crypto/sha1"
 	"crypto/sha256"
@@ -35,7 +37,7 @@ func ParseCert(pemValue string) (*x509.Certificate, error) {
 //
 // If no certificates are found this returns an error.
 func ParseLeafCerts(pemValue string) (*x509.Certificate, *x509.CertPool, error) {
-	certs, err := parseCerts(pemValue)
+	certs, err := ParseCerts(pemValue)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -56,11 +58,11 @@ func ParseLeafCerts(pemValue string) (*x509.Certificate, *x509.CertPool, error)
 	return leaf, intermediates, nil
 }
 
-// ParseCerts parses the all x509 certificates from a PEM-encoded value.
-// The first returned cert is a leaf cert and any other ones are intermediates.
+// ParseCerts parses all of the x509 certificates from a PEM-encoded value, in
+// the order they appear.
 //
 // If no certificates are found this returns an error.
-func parseCerts(pemValue string) ([]*x509.Certificate, error) {
+func ParseCerts(pemValue string) ([]*x509.Certificate, error) {
 	var out []*x509.Certificate
 
 	rest := []byte(pemValue)
@@ -90,6 +92,44 @@ func parseCerts(pemValue string) ([]*x509.Certificate, error) {
 	return out, nil
 }
 
+// ParseCertChain parses every x509 certificate in a PEM bundle, in the order
+// they appear. Unlike ParseCerts, it only skips whitespace between the
+// blocks, so anything else in the bundle, such as trailing garbage or a
+// truncated block, is an error.
+//
+// If no certificates are found this returns an error.
+func ParseCertChain(pemValue string) ([]*x509.Certificate, error) {
+	var out []*x509.Certificate
+
+	rest := bytes.TrimSpace([]byte(pemValue))
+	for len(rest) > 0 {
+		if !bytes.HasPrefix(rest, []byte("-----BEGIN ")) {
+			return nil, fmt.Errorf("unexpected data after certificate %d", len(out))
+		}
+		block, remaining := pem.Decode(rest)
+		if block == nil {
+			return nil, fmt.Errorf("malformed PEM-block after certificate %d", len(out))
+		}
+		rest = bytes.TrimSpace(remaining)
+
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("PEM-block should be CERTIFICATE type")
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing certificate %d: %v", len(out)+1, err)
+		}
+		out = append(out, cert)
+	}
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no PEM-encoded data found")
+	}
+
+	return out, nil
+}
+
 // CalculateCertFingerprint parses the x509 certificate from a PEM-encoded value
 // and calculates the SHA-1 fingerprint.
 func CalculateCertFingerprint(pemValue string) (string, error) {
@@ -157,11 +197,12 @@ func ParseCSR(pemValue string) (*x509.CertificateRequest, error) {
 }
 
 // KeyId returns a x509 KeyId from the given signing key. The key must be
-// an *ecdsa.PublicKey currently, but may support more types in the future.
+// an *ecdsa.PublicKey, *rsa.PublicKey or ed25519.PublicKey.
 func KeyId(raw interface{}) ([]byte, error) {
 	switch raw.(type) {
 	case *ecdsa.PublicKey:
 	case *rsa.PublicKey:
+	case ed25519.PublicKey:
 	default:
 		return nil, fmt.Errorf("invalid key type: %T", raw)
 	}
@@ -184,6 +225,19 @@ func EncodeSerialNumber(serial *big.Int) string {
 	return HexString(serial.Bytes())
 }
 
+// ParseSerialNumber parses a colon-hex encoded serial number as returned by
+// EncodeSerialNumber.
+func ParseSerialNumber(s string) (*big.Int, error) {
+	bs, err := hex.DecodeString(strings.Replace(s, ":", "", -1))
+	if err != nil {
+		return nil, fmt.Errorf("invalid serial number %q: %s", s, err)
+	}
+	if len(bs) == 0 {
+		return nil, fmt.Errorf("serial number must not be empty")
+	}
+	return new(big.Int).SetBytes(bs), nil
+}
+
 // EncodeSigningKeyID encodes the given AuthorityKeyId or SubjectKeyId into a
 // colon-hex encoded string suitable for using as a SigningKeyID value.
 func EncodeSigningKeyID(keyID []byte) string { return HexString(keyID) }
@@ -215,6 +269,9 @@ func KeyInfoFromCert(cert *x509.Certificate) (keyType string, keyBits int, err e
 		return "ec", k.Curve.Params().BitSize, nil
 	case *rsa.PublicKey:
 		return "rsa", k.N.BitLen(), nil
+	case ed25519.PublicKey:
+		// Ed25519 keys are always 256 bits.
+		return "ed25519", len(k) * 8, nil
 	default:
 		return "", 0, fmt.Errorf("unsupported key type")
 	}
@@ -0,0 +1,82 @@
+package connect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCertChain(t *testing.T) {
+	root := TestCA(t, nil)
+	intermediate := TestCA(t, root)
+	leaf, _ := TestLeaf(t, "web", intermediate)
+
+	t.Run("single cert", func(t *testing.T) {
+		certs, err := ParseCertChain(root.RootCert)
+		require.NoError(t, err)
+		require.Len(t, certs, 1)
+		require.Equal(t, root.Name, certs[0].Subject.CommonName)
+	})
+
+	t.Run("multiple certs in order", func(t *testing.T) {
+		bundle := leaf + intermediate.RootCert + root.RootCert
+		certs, err := ParseCertChain(bundle)
+		require.NoError(t, err)
+		require.Len(t, certs, 3)
+		require.False(t, certs[0].IsCA)
+		require.Equal(t, intermediate.Name, certs[1].Subject.CommonName)
+		require.Equal(t, root.Name, certs[2].Subject.CommonName)
+	})
+
+	t.Run("blank lines between certs", func(t *testing.T) {
+		bundle := "\n\n" + intermediate.RootCert + "\n \n\t\n" + root.RootCert + "\n\n"
+		certs, err := ParseCertChain(bundle)
+		require.NoError(t, err)
+		require.Len(t, certs, 2)
+		require.Equal(t, intermediate.Name, certs[0].Subject.CommonName)
+		require.Equal(t, root.Name, certs[1].Subject.CommonName)
+	})
+
+	cases := map[string]struct {
+		pem    string
+		expect string
+	}{
+		"empty": {
+			pem:    "",
+			expect: "no PEM-encoded data found",
+		},
+		"trailing garbage": {
+			pem:    root.RootCert + "garbage\n",
+			expect: "unexpected data after certificate 1",
+		},
+		"leading garbage": {
+			pem:    "garbage\n" + root.RootCert,
+			expect: "unexpected data after certificate 0",
+		},
+		"garbage between certs": {
+			pem:    intermediate.RootCert + "garbage\n" + root.RootCert,
+			expect: "unexpected data after certificate 1",
+		},
+		"truncated cert": {
+			pem:    root.RootCert + intermediate.RootCert[:len(intermediate.RootCert)/2],
+			expect: "malformed PEM-block after certificate 1",
+		},
+		"wrong block type": {
+			pem:    root.SigningKey,
+			expect: "PEM-block should be CERTIFICATE type",
+		},
+		"invalid certificate": {
+			pem:    "-----BEGIN CERTIFICATE-----\nZm9v\n-----END CERTIFICATE-----\n",
+			expect: "error parsing certificate 1",
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseCertChain(tc.pem)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.expect)
+		})
+	}
+}
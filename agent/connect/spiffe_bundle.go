@@ -0,0 +1,44 @@
+package connect
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// spiffeBundleUseX509SVID is the JWK "use" value SPIFFE trust bundles give to
+// the roots that X.509 SVIDs chain to.
+const spiffeBundleUseX509SVID = "x509-svid"
+
+// spiffeBundle is the JSON encoding of a SPIFFE trust bundle as described by
+// the SPIFFE Trust Domain and Bundle specification.
+type spiffeBundle struct {
+	Keys     []jose.JSONWebKey `json:"keys"`
+	Sequence uint64            `json:"spiffe_sequence,omitempty"`
+}
+
+// SpiffeBundle encodes roots as a SPIFFE trust bundle, with one x509-svid key
+// per root, so that SPIFFE implementations such as SPIRE can federate with the
+// trust domain. sequence should increase whenever the set of roots changes.
+func SpiffeBundle(roots structs.CARoots, sequence uint64) ([]byte, error) {
+	bundle := spiffeBundle{
+		Keys:     make([]jose.JSONWebKey, 0, len(roots)),
+		Sequence: sequence,
+	}
+	for _, root := range roots {
+		cert, err := ParseCert(root.RootCert)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing root %q: %w", root.ID, err)
+		}
+		bundle.Keys = append(bundle.Keys, jose.JSONWebKey{
+			Key:          cert.PublicKey,
+			Certificates: []*x509.Certificate{cert},
+			Use:          spiffeBundleUseX509SVID,
+		})
+	}
+	return json.Marshal(bundle)
+}
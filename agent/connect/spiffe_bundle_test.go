@@ -0,0 +1,48 @@
+package connect
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestSpiffeBundle(t *testing.T) {
+	ecRoot := TestCAWithKeyType(t, nil, "ec", 256)
+	rsaRoot := TestCAWithKeyType(t, nil, "rsa", 2048)
+
+	raw, err := SpiffeBundle(structs.CARoots{ecRoot, rsaRoot}, 42)
+	require.NoError(t, err)
+
+	var bundle struct {
+		Keys []struct {
+			Kty string   `json:"kty"`
+			Use string   `json:"use"`
+			X5C []string `json:"x5c"`
+		} `json:"keys"`
+		Sequence uint64 `json:"spiffe_sequence"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &bundle))
+	require.Equal(t, uint64(42), bundle.Sequence)
+	require.Len(t, bundle.Keys, 2)
+	require.Equal(t, "EC", bundle.Keys[0].Kty)
+	require.Equal(t, "RSA", bundle.Keys[1].Kty)
+	for _, key := range bundle.Keys {
+		require.Equal(t, "x509-svid", key.Use)
+		require.Len(t, key.X5C, 1)
+	}
+
+	t.Run("no roots", func(t *testing.T) {
+		raw, err := SpiffeBundle(nil, 0)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"keys":[]}`, string(raw))
+	})
+
+	t.Run("invalid root", func(t *testing.T) {
+		_, err := SpiffeBundle(structs.CARoots{{ID: "bad", RootCert: "nope"}}, 1)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `error parsing root "bad"`)
+	})
+}
@@ -10,6 +10,8 @@ import (
 	"fmt"
 	"math/big"
 	"net/url"
+	"reflect"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -31,30 +33,232 @@ var testCACounter uint64
 // ValidateLeaf is a convenience helper that returns an error if the certificate
 // provided in leadPEM does not validate against the CAs provided. If there is
 // an intermediate CA then it's cert must be in caPEMs as well as the root.
-func ValidateLeaf(caPEM string, leafPEM string, intermediatePEMs []string) error {
+func ValidateLeaf(caPEM string, leafPEM string, intermediatePEMs []string, opts ...ValidateLeafOption) error {
+	var options validateLeafOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	roots := x509.NewCertPool()
-	ok := roots.AppendCertsFromPEM([]byte(caPEM))
-	if !ok {
-		return fmt.Errorf("Failed to add root CA")
+	rootCerts, err := ParseCertChain(caPEM)
+	if err != nil {
+		return fmt.Errorf("Failed to add root CA: %v", err)
+	}
+	for _, cert := range rootCerts {
+		roots.AddCert(cert)
 	}
 
 	intermediates := x509.NewCertPool()
+	var intermediateCerts []*x509.Certificate
 	for idx, ca := range intermediatePEMs {
-		ok := intermediates.AppendCertsFromPEM([]byte(ca))
-		if !ok {
-			return fmt.Errorf("Failed to add intermediate CA at index %d to pool", idx)
+		certs, err := ParseCertChain(ca)
+		if err != nil {
+			return fmt.Errorf("Failed to add intermediate CA at index %d to pool: %v", idx, err)
 		}
+		for _, cert := range certs {
+			intermediates.AddCert(cert)
+		}
+		intermediateCerts = append(intermediateCerts, certs...)
 	}
 
 	leaf, err := ParseCert(leafPEM)
 	if err != nil {
 		return err
 	}
-	_, err = leaf.Verify(x509.VerifyOptions{
+
+	if options.checkAuthorityKeyID {
+		if err := checkAuthorityKeyID(leaf, append(intermediateCerts, rootCerts...)); err != nil {
+			return err
+		}
+	}
+
+	if len(options.trustDomains) > 0 {
+		if err := checkTrustDomain(leaf, options.trustDomains); err != nil {
+			return err
+		}
+	}
+
+	if options.checkNamespace {
+		if err := checkNamespace(leaf, options.namespace); err != nil {
+			return err
+		}
+	}
+
+	if options.certURI != nil {
+		if err := checkCertURI(leaf, options.certURI); err != nil {
+			return err
+		}
+	}
+
+	chains, err := leaf.Verify(x509.VerifyOptions{
 		Roots:         roots,
 		Intermediates: intermediates,
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	if options.crlPEM != "" {
+		// A leaf that is itself one of the roots has no issuer to check the
+		// CRL signature against.
+		if len(chains[0]) < 2 {
+			return fmt.Errorf("cannot check revocation: the leaf is a trusted root")
+		}
+		return checkRevoked(leaf, chains[0][1], options.crlPEM)
+	}
+	return nil
+}
+
+// ValidateLeafOption configures optional checks performed by ValidateLeaf.
+type ValidateLeafOption func(*validateLeafOptions)
+
+type validateLeafOptions struct {
+	crlPEM              string
+	checkAuthorityKeyID bool
+	trustDomains        []string
+	checkNamespace      bool
+	namespace           string
+	certURI             CertURI
+}
+
+// WithAuthorityKeyIDCheck makes ValidateLeaf check that the leaf's
+// AuthorityKeyId matches the SubjectKeyId of one of the given intermediates or
+// roots before verifying the chain. This gives a descriptive error for
+// mis-chained certs rather than the generic one from x509.Verify.
+func WithAuthorityKeyIDCheck() ValidateLeafOption {
+	return func(o *validateLeafOptions) {
+		o.checkAuthorityKeyID = true
+	}
+}
+
+// checkAuthorityKeyID returns an error if the leaf's AuthorityKeyId does not
+// match the SubjectKeyId of any of the candidate issuers. Intermediates are
+// kept oldest first and may include cross-signed certs, so the issuer can be
+// any of them.
+func checkAuthorityKeyID(leaf *x509.Certificate, issuers []*x509.Certificate) error {
+	var keyIDs []string
+	for _, issuer := range issuers {
+		if bytes.Equal(leaf.AuthorityKeyId, issuer.SubjectKeyId) {
+			return nil
+		}
+		keyIDs = append(keyIDs, HexString(issuer.SubjectKeyId))
+	}
+	return fmt.Errorf("leaf AuthorityKeyId %q does not match the SubjectKeyId of any issuer: %s",
+		HexString(leaf.AuthorityKeyId), strings.Join(keyIDs, ", "))
+}
+
+// WithTrustDomains makes ValidateLeaf check that the host of the leaf's URI
+// SAN is one of the given trust domains. Pass the roots' TrustDomain followed
+// by any AdditionalTrustDomains to accept leaves issued before a trust domain
+// migration.
+func WithTrustDomains(domains ...string) ValidateLeafOption {
+	return func(o *validateLeafOptions) {
+		o.trustDomains = append(o.trustDomains, domains...)
+	}
+}
+
+// checkTrustDomain returns an error if the leaf has no URI SAN in one of the
+// given trust domains.
+func checkTrustDomain(leaf *x509.Certificate, domains []string) error {
+	for _, uri := range leaf.URIs {
+		for _, domain := range domains {
+			if strings.EqualFold(uri.Host, domain) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("leaf is not in any accepted trust domain %v", domains)
+}
+
+// WithNamespace makes ValidateLeaf check that the leaf is a service
+// certificate for the given namespace, where an empty namespace means the
+// default one. The namespace in the leaf's SPIFFE ID is compared as is, even
+// where namespaces aren't supported.
+func WithNamespace(namespace string) ValidateLeafOption {
+	return func(o *validateLeafOptions) {
+		o.checkNamespace = true
+		o.namespace = namespace
+	}
+}
+
+// checkNamespace returns an error if the leaf's SPIFFE ID isn't a service ID
+// in the given namespace.
+func checkNamespace(leaf *x509.Certificate, namespace string) error {
+	if len(leaf.URIs) == 0 {
+		return fmt.Errorf("leaf has no URI SAN")
+	}
+	certURI, err := ParseCertURI(leaf.URIs[0])
+	if err != nil {
+		return fmt.Errorf("leaf URI SAN is not a valid SPIFFE ID: %s", err)
+	}
+	svc, ok := certURI.(*SpiffeIDService)
+	if !ok {
+		return fmt.Errorf("leaf URI SAN %q is not a service SPIFFE ID", leaf.URIs[0])
+	}
+	if spiffeNamespace(svc.Namespace) != spiffeNamespace(namespace) {
+		return fmt.Errorf("leaf namespace %q does not match %q", spiffeNamespace(svc.Namespace), spiffeNamespace(namespace))
+	}
+	return nil
+}
+
+// WithCertURI makes ValidateLeaf check that the leaf's URI SAN parses as the
+// same kind of SPIFFE ID as id, such as a *SpiffeIDAgent, and encodes the same
+// URI. This lets callers validate agent leaves without treating them as
+// services.
+func WithCertURI(id CertURI) ValidateLeafOption {
+	return func(o *validateLeafOptions) {
+		o.certURI = id
+	}
+}
+
+// checkCertURI returns an error if the leaf's SPIFFE ID isn't of the same type
+// as expected or doesn't encode the same URI.
+func checkCertURI(leaf *x509.Certificate, expected CertURI) error {
+	if len(leaf.URIs) == 0 {
+		return fmt.Errorf("leaf has no URI SAN")
+	}
+	certURI, err := ParseCertURI(leaf.URIs[0])
+	if err != nil {
+		return fmt.Errorf("leaf URI SAN is not a valid SPIFFE ID: %s", err)
+	}
+	if reflect.TypeOf(certURI) != reflect.TypeOf(expected) {
+		return fmt.Errorf("leaf URI SAN %q is a %T, expected a %T", leaf.URIs[0], certURI, expected)
+	}
+	if actual, want := certURI.URI().String(), expected.URI().String(); actual != want {
+		return fmt.Errorf("leaf URI SAN %q does not match %q", actual, want)
+	}
+	return nil
+}
+
+// WithCRL makes ValidateLeaf reject the leaf if its serial number is listed
+// in the given PEM-encoded CRL. The CRL must be signed by the leaf's issuer.
+func WithCRL(crlPEM string) ValidateLeafOption {
+	return func(o *validateLeafOptions) {
+		o.crlPEM = crlPEM
+	}
+}
+
+// checkRevoked returns an error if leaf is listed in the CRL, or if the CRL
+// was not signed by issuer.
+func checkRevoked(leaf, issuer *x509.Certificate, crlPEM string) error {
+	block, _ := pem.Decode([]byte(crlPEM))
+	if block == nil {
+		return fmt.Errorf("no PEM-encoded data found in CRL")
+	}
+	crl, err := x509.ParseCRL(block.Bytes) //nolint:staticcheck
+	if err != nil {
+		return fmt.Errorf("error parsing CRL: %s", err)
+	}
+	if err := issuer.CheckCRLSignature(crl); err != nil { //nolint:staticcheck
+		return fmt.Errorf("CRL was not signed by the leaf's issuer: %s", err)
+	}
+
+	for _, revoked := range crl.TBSCertList.RevokedCertificates {
+		if revoked.SerialNumber.Cmp(leaf.SerialNumber) == 0 {
+			return fmt.Errorf("certificate %s has been revoked", EncodeSerialNumber(leaf.SerialNumber))
+		}
+	}
+	return nil
 }
 
 func testCA(t testing.T, xc *structs.CARoot, keyType string, keyBits int, ttl time.Duration) *structs.CARoot {
@@ -223,7 +427,7 @@ func testLeafWithID(t testing.T, spiffeId CertURI, root *structs.CARoot, keyType
 	// Cert template for generation
 	template := x509.Certificate{
 		SerialNumber:          sn,
-		URIs:                  []*url.URL{spiffeId.URI()},
+		URIs:                  []*url.URL{testCertURI(spiffeId)},
 		SignatureAlgorithm:    SigAlgoForKeyType(rootKeyType),
 		BasicConstraintsValid: true,
 		KeyUsage: x509.KeyUsageDataEncipherment |
@@ -297,11 +501,29 @@ func TestLeafWithNamespace(t testing.T, service, namespace string, root *structs
 	return certPEM, keyPEM
 }
 
+// testCertURI returns the URI for id. Service IDs keep their namespace even
+// where URI would replace it with the default one because namespaces aren't
+// supported, so tests can issue certificates for any namespace.
+func testCertURI(id CertURI) *url.URL {
+	uri := id.URI()
+	svc, ok := id.(*SpiffeIDService)
+	if !ok || svc.Namespace == "" {
+		return uri
+	}
+
+	from := "/ns/" + svc.NamespaceOrDefault() + "/"
+	uri.Path = strings.Replace(uri.Path, from, "/ns/"+svc.Namespace+"/", 1)
+	if escaped := url.PathEscape(svc.Namespace); escaped != svc.Namespace {
+		uri.RawPath = strings.Replace(uri.EscapedPath(), url.PathEscape(from), "/ns/"+escaped+"/", 1)
+	}
+	return uri
+}
+
 // TestCSR returns a CSR to sign the given service along with the PEM-encoded
 // private key for this certificate.
 func TestCSR(t testing.T, uri CertURI) (string, string) {
 	template := &x509.CertificateRequest{
-		URIs:               []*url.URL{uri.URI()},
+		URIs:               []*url.URL{testCertURI(uri)},
 		SignatureAlgorithm: x509.ECDSAWithSHA256,
 	}
 
@@ -1,12 +1,17 @@
 package connect
 
 import (
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -130,3 +135,117 @@ func TestTestCAAndLeaf_xc(t *testing.T) {
 			})
 	}
 }
+
+func TestValidateLeaf_AuthorityKeyIDCheck(t *testing.T) {
+	ca1 := TestCA(t, nil)
+	ca2 := TestCA(t, nil)
+	leaf, _ := TestLeaf(t, "web", ca1)
+
+	// The leaf chains directly to its own root.
+	require.NoError(t, ValidateLeaf(ca1.RootCert, leaf, nil, WithAuthorityKeyIDCheck()))
+
+	// An unrelated intermediate doesn't matter as long as one of the certs
+	// issued the leaf.
+	require.NoError(t, ValidateLeaf(ca1.RootCert, leaf, []string{ca2.RootCert}, WithAuthorityKeyIDCheck()))
+
+	// ca2 did not sign the leaf.
+	err := ValidateLeaf(ca2.RootCert, leaf, nil, WithAuthorityKeyIDCheck())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not match the SubjectKeyId of any issuer")
+	require.Contains(t, err.Error(), ca1.SigningKeyID)
+	require.Contains(t, err.Error(), ca2.SigningKeyID)
+}
+
+func TestValidateLeaf_AuthorityKeyIDCheck_SecondIntermediate(t *testing.T) {
+	root := TestCA(t, nil)
+	inter1 := TestCA(t, root)
+	inter2 := TestCA(t, root)
+
+	// Intermediates are kept oldest first, so the active one that signed the
+	// leaf is last.
+	leaf, _ := TestLeaf(t, "web", inter2)
+	intermediates := []string{inter1.SigningCert, inter2.SigningCert}
+	require.NoError(t, ValidateLeaf(root.RootCert, leaf, intermediates, WithAuthorityKeyIDCheck()))
+}
+
+func TestValidateLeaf_CRLWithRootAsLeaf(t *testing.T) {
+	ca := TestCA(t, nil)
+	caCert, err := ParseCert(ca.RootCert)
+	require.NoError(t, err)
+	signer, err := ParseSigner(ca.SigningKey)
+	require.NoError(t, err)
+	crl, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: time.Now(),
+		NextUpdate: time.Now().Add(time.Hour),
+	}, caCert, signer)
+	require.NoError(t, err)
+	crlPEM := string(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl}))
+
+	// Verifying a root against itself yields a chain without an issuer.
+	err = ValidateLeaf(ca.RootCert, ca.RootCert, nil, WithCRL(crlPEM))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the leaf is a trusted root")
+}
+
+func TestValidateLeaf_TrustDomains(t *testing.T) {
+	ca := TestCA(t, nil)
+	leaf, _ := TestLeaf(t, "web", ca)
+	domain := TestClusterID + ".consul"
+
+	require.NoError(t, ValidateLeaf(ca.RootCert, leaf, nil, WithTrustDomains(domain)))
+
+	// Any listed domain is accepted.
+	require.NoError(t, ValidateLeaf(ca.RootCert, leaf, nil, WithTrustDomains("other.consul", domain)))
+
+	err := ValidateLeaf(ca.RootCert, leaf, nil, WithTrustDomains("other.consul"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not in any accepted trust domain")
+}
+
+func TestValidateLeaf_Namespace(t *testing.T) {
+	ca := TestCA(t, nil)
+
+	leaf, _ := TestLeafWithNamespace(t, "web", "other", ca)
+	require.NoError(t, ValidateLeaf(ca.RootCert, leaf, nil, WithNamespace("other")))
+
+	err := ValidateLeaf(ca.RootCert, leaf, nil, WithNamespace("default"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `leaf namespace "other" does not match "default"`)
+
+	// An empty namespace means the default one.
+	leaf, _ = TestLeaf(t, "web", ca)
+	require.NoError(t, ValidateLeaf(ca.RootCert, leaf, nil, WithNamespace("")))
+	require.NoError(t, ValidateLeaf(ca.RootCert, leaf, nil, WithNamespace("default")))
+}
+
+func TestValidateLeaf_CertURI(t *testing.T) {
+	ca := TestCA(t, nil)
+	agentID := &SpiffeIDAgent{
+		Host:       TestClusterID + ".consul",
+		Datacenter: "dc1",
+		Agent:      "node1",
+	}
+
+	leaf, _, err := TestAgentLeaf(t, "node1", "dc1", ca, 0)
+	require.NoError(t, err)
+	require.NoError(t, ValidateLeaf(ca.RootCert, leaf, nil, WithCertURI(agentID)))
+
+	err = ValidateLeaf(ca.RootCert, leaf, nil, WithCertURI(&SpiffeIDAgent{
+		Host:       TestClusterID + ".consul",
+		Datacenter: "dc2",
+		Agent:      "node1",
+	}))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not match")
+
+	err = ValidateLeaf(ca.RootCert, leaf, nil, WithCertURI(TestSpiffeIDService(t, "node1")))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is a *connect.SpiffeIDAgent, expected a *connect.SpiffeIDService")
+
+	// A service leaf is not accepted as an agent.
+	leaf, _ = TestLeaf(t, "web", ca)
+	err = ValidateLeaf(ca.RootCert, leaf, nil, WithCertURI(agentID))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "expected a *connect.SpiffeIDAgent")
+}
@@ -19,6 +19,16 @@ func (id SpiffeIDService) NamespaceOrDefault() string {
 	return structs.NamespaceOrDefault(id.Namespace)
 }
 
+// spiffeNamespace returns ns, or the default namespace if it is empty. Unlike
+// NamespaceOrDefault it keeps a non-default namespace even where namespaces
+// aren't supported, so the namespace in a SPIFFE ID can always be checked.
+func spiffeNamespace(ns string) string {
+	if ns == "" {
+		return structs.IntentionDefaultNamespace
+	}
+	return ns
+}
+
 func (id SpiffeIDService) MatchesPartition(partition string) bool {
 	return id.PartitionOrDefault() == structs.PartitionOrDefault(partition)
 }
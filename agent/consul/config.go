@@ -381,6 +381,52 @@ type Config struct {
 	// bootstrapping.
 	CAConfig *structs.CAConfiguration
 
+	// ConnectAllowedCAKeyTypes restricts the private key types and lengths a
+	// CA configuration update may use. An empty list allows all key types.
+	ConnectAllowedCAKeyTypes []structs.CAKeyType
+
+	// ConnectMinPrimaryRootKeyTypes is the weakest key a secondary datacenter
+	// accepts for each key type of the primary datacenter's root. An empty
+	// list accepts any root.
+	ConnectMinPrimaryRootKeyTypes []structs.CAKeyType
+
+	// ConnectSecondaryCARetryMinBackoff, ConnectSecondaryCARetryMaxBackoff and
+	// ConnectSecondaryCARetryBackoffMultiplier tune how a secondary datacenter
+	// backs off between failed attempts to initialize its CA and replicate
+	// roots from the primary. Zero values use the defaults of 2s, 256s and 2.
+	ConnectSecondaryCARetryMinBackoff        time.Duration
+	ConnectSecondaryCARetryMaxBackoff        time.Duration
+	ConnectSecondaryCARetryBackoffMultiplier float64
+
+	// ConnectCAConfigureAttempts is how many times a CA configuration update
+	// tries to configure the new provider before rejecting the configuration,
+	// so that a transient backend failure doesn't reject a good configuration.
+	// Values below 1 mean a single attempt.
+	ConnectCAConfigureAttempts int
+
+	// ConnectMinLeafCertTTL is the shortest leaf cert lifetime this
+	// datacenter signs when a shorter TTL is requested. Zero uses
+	// structs.MinLeafCertTTL.
+	ConnectMinLeafCertTTL time.Duration
+
+	// ConnectCAManualSecondaryInit leaves a secondary datacenter's CA
+	// uninitialized until ConnectCA.InitializeSecondary is called, rather than
+	// pulling the primary's roots as soon as a leader is elected. It only
+	// applies until the roots have been replicated for the first time.
+	ConnectCAManualSecondaryInit bool
+
+	// ConnectLeafSignCacheTTL is how long the leader remembers a signed leaf
+	// cert so that an identical CSR received in that window gets the same
+	// cert instead of being signed again. Zero disables the cache.
+	ConnectLeafSignCacheTTL time.Duration
+
+	// CARootPruneHook, if set, is called with each CA root the leader is about
+	// to prune, before the removal is committed to Raft. It can be used to
+	// archive roots elsewhere. Returning an error keeps that root until the
+	// next pruning pass. The hook may see the same root again if committing the
+	// removal fails.
+	CARootPruneHook func(root *structs.CARoot) error
+
 	// ConfigEntryBootstrap contains a list of ConfigEntries to ensure are created
 	// If entries of the same Kind/Name exist already these will not update them.
 	ConfigEntryBootstrap []structs.ConfigEntry
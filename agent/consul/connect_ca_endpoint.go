@@ -1,8 +1,11 @@
 package consul
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
@@ -10,6 +13,7 @@ import (
 
 	"github.com/hashicorp/consul/acl"
 	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/agent/connect/ca"
 	"github.com/hashicorp/consul/agent/consul/state"
 	"github.com/hashicorp/consul/agent/structs"
 )
@@ -101,7 +105,113 @@ func (s *ConnectCA) ConfigurationSet(
 		return acl.ErrPermissionDenied
 	}
 
-	return s.srv.caManager.UpdateConfiguration(args)
+	if args.WaitForPropagation && s.srv.config.Datacenter != s.srv.config.PrimaryDatacenter {
+		return fmt.Errorf("waiting for CA root propagation is only supported in the primary datacenter")
+	}
+
+	if args.DryRun {
+		result, err := s.srv.caManager.DryRunConfiguration(args)
+		if err != nil {
+			return err
+		}
+		*reply = result
+		return nil
+	}
+
+	if err := s.srv.caManager.UpdateConfiguration(args); err != nil {
+		return err
+	}
+	if !args.WaitForPropagation {
+		return nil
+	}
+
+	_, root, err := s.srv.fsm.State().CARootActive(nil)
+	if err != nil {
+		return err
+	}
+	if root == nil {
+		return fmt.Errorf("no active CA root to wait for")
+	}
+	timeout := args.PropagationTimeout
+	if timeout <= 0 {
+		timeout = structs.DefaultCAPropagationTimeout
+	}
+	return s.srv.waitForCARootPropagation(root.ID, timeout)
+}
+
+// RotateRoot triggers a rotation of the active CA root using the current
+// provider configuration and a newly generated private key. The ID of the new
+// active root is returned.
+func (s *ConnectCA) RotateRoot(
+	args *structs.CARotateRootRequest,
+	reply *string) error {
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	if done, err := s.srv.ForwardRPC("ConnectCA.RotateRoot", args, reply); done {
+		return err
+	}
+
+	// Roots are only ever generated in the primary datacenter.
+	if s.srv.config.PrimaryDatacenter != s.srv.config.Datacenter {
+		return ErrNotPrimaryDatacenter
+	}
+
+	// This action requires operator write access.
+	authz, err := s.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if authz.OperatorWrite(nil) != acl.Allow {
+		return acl.ErrPermissionDenied
+	}
+
+	rootID, err := s.srv.caManager.RotateRoot(args)
+	if err != nil {
+		return err
+	}
+	*reply = rootID
+
+	return nil
+}
+
+// RollbackRoot makes the most recently rotated out CA root active again, if
+// Consul still holds its private key. The ID of the active root is returned.
+func (s *ConnectCA) RollbackRoot(
+	args *structs.CARotateRootRequest,
+	reply *string) error {
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	if done, err := s.srv.ForwardRPC("ConnectCA.RollbackRoot", args, reply); done {
+		return err
+	}
+
+	// Roots are only ever generated in the primary datacenter.
+	if s.srv.config.PrimaryDatacenter != s.srv.config.Datacenter {
+		return ErrNotPrimaryDatacenter
+	}
+
+	// This action requires operator write access.
+	authz, err := s.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if authz.OperatorWrite(nil) != acl.Allow {
+		return acl.ErrPermissionDenied
+	}
+
+	rootID, err := s.srv.caManager.RollbackRoot(args)
+	if err != nil {
+		return err
+	}
+	*reply = rootID
+
+	return nil
 }
 
 // Roots returns the currently trusted root certificates.
@@ -132,6 +242,170 @@ func (s *ConnectCA) Roots(
 	)
 }
 
+// ActiveRoot is like Roots but only returns the active root, which saves
+// clients that poll for it from receiving every root. The other fields are the
+// same as returned by Roots, so AdditionalTrustDomains still covers the
+// inactive roots. Roots is empty if the CA isn't initialized yet.
+func (s *ConnectCA) ActiveRoot(
+	args *structs.DCSpecificRequest,
+	reply *structs.IndexedCARoots) error {
+	if done, err := s.srv.ForwardRPC("ConnectCA.ActiveRoot", args, reply); done {
+		return err
+	}
+
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	return s.srv.blockingQuery(
+		&args.QueryOptions, &reply.QueryMeta,
+		func(ws memdb.WatchSet, state *state.Store) error {
+			roots, err := s.srv.getCARoots(ws, state)
+			if err != nil {
+				return err
+			}
+
+			active := make(structs.CARoots, 0, 1)
+			for _, root := range roots.Roots {
+				if root.Active {
+					active = append(active, root)
+					break
+				}
+			}
+			roots.Roots = active
+
+			*reply = *roots
+			return nil
+		},
+	)
+}
+
+// CABundle returns the active root and its intermediates as a single PEM
+// bundle ordered from leaf to root.
+func (s *ConnectCA) CABundle(
+	args *structs.DCSpecificRequest,
+	reply *structs.CABundle) error {
+	if done, err := s.srv.ForwardRPC("ConnectCA.CABundle", args, reply); done {
+		return err
+	}
+
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	return s.srv.blockingQuery(
+		&args.QueryOptions, &reply.QueryMeta,
+		func(ws memdb.WatchSet, state *state.Store) error {
+			index, root, err := state.CARootActive(ws)
+			if err != nil {
+				return err
+			}
+			reply.Index = index
+			if root == nil {
+				reply.ActiveRootID, reply.PEM = "", ""
+				return nil
+			}
+
+			// Intermediates are appended as they are renewed, so the last
+			// one is the one currently signing leaf certificates.
+			var buf strings.Builder
+			for i := len(root.IntermediateCerts) - 1; i >= 0; i-- {
+				buf.WriteString(ca.EnsureTrailingNewline(root.IntermediateCerts[i]))
+			}
+			buf.WriteString(ca.EnsureTrailingNewline(root.RootCert))
+
+			reply.ActiveRootID = root.ID
+			reply.PEM = buf.String()
+			return nil
+		},
+	)
+}
+
+// SpiffeBundle returns the trusted roots as a SPIFFE trust bundle, so that
+// SPIFFE implementations other than Consul can federate with its trust domain.
+func (s *ConnectCA) SpiffeBundle(
+	args *structs.DCSpecificRequest,
+	reply *structs.CASpiffeBundle) error {
+	if done, err := s.srv.ForwardRPC("ConnectCA.SpiffeBundle", args, reply); done {
+		return err
+	}
+
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	return s.srv.blockingQuery(
+		&args.QueryOptions, &reply.QueryMeta,
+		func(ws memdb.WatchSet, state *state.Store) error {
+			roots, err := s.srv.getCARoots(ws, state)
+			if err != nil {
+				return err
+			}
+			reply.Index = roots.Index
+			reply.TrustDomain = roots.TrustDomain
+
+			bundle, err := connect.SpiffeBundle(roots.Roots, roots.Index)
+			if err != nil {
+				return err
+			}
+			reply.Bundle = string(bundle)
+			return nil
+		},
+	)
+}
+
+// DescribeRoot returns the decoded fields of one of the CA roots known to
+// this datacenter.
+func (s *ConnectCA) DescribeRoot(
+	args *structs.CADescribeRootRequest,
+	reply *structs.CARootDescription) error {
+	if done, err := s.srv.ForwardRPC("ConnectCA.DescribeRoot", args, reply); done {
+		return err
+	}
+
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	if args.RootID == "" {
+		return fmt.Errorf("a root ID is required")
+	}
+
+	return s.srv.blockingQuery(
+		&args.QueryOptions, &reply.QueryMeta,
+		func(ws memdb.WatchSet, state *state.Store) error {
+			index, roots, err := state.CARoots(ws)
+			if err != nil {
+				return err
+			}
+
+			var root *structs.CARoot
+			for _, r := range roots {
+				if r.ID == args.RootID {
+					root = r
+					break
+				}
+			}
+			if root == nil {
+				return fmt.Errorf("CA root %q not found", args.RootID)
+			}
+
+			desc, err := describeCARoot(root.RootCert)
+			if err != nil {
+				return err
+			}
+			desc.QueryMeta = reply.QueryMeta
+			desc.Index = index
+			*reply = *desc
+			return nil
+		},
+	)
+}
+
 // Sign signs a certificate for a service.
 func (s *ConnectCA) Sign(
 	args *structs.CASignRequest,
@@ -145,59 +419,138 @@ func (s *ConnectCA) Sign(
 		return err
 	}
 
-	// Parse the CSR
-	csr, err := connect.ParseCSR(args.CSR)
+	// Verify that the ACL token provided has permission to act as this service
+	authz, err := s.srv.ResolveToken(args.Token)
 	if err != nil {
 		return err
 	}
 
-	// Parse the SPIFFE ID
-	spiffeID, err := connect.ParseCertURI(csr.URIs[0])
+	csr, spiffeID, err := s.authorizeCSR(authz, args.CSR)
 	if err != nil {
 		return err
 	}
 
-	// Verify that the ACL token provided has permission to act as this service
+	cert, err := s.srv.caManager.SignCertificateWithTTL(csr, spiffeID, args.TTL)
+	if err != nil {
+		return err
+	}
+	*reply = *cert
+	return nil
+}
+
+// maxSignBatchSize is the most CSRs ConnectCA.SignBatch accepts in one
+// request.
+const maxSignBatchSize = 100
+
+// SignBatch signs several service or agent certificates in one RPC. Each CSR
+// is authorized and signed on its own, so a CSR that fails, for example
+// because it is malformed or the token can't write its service, gets an
+// error in its result while the others are still signed. The RPC only fails
+// as a whole when the request can't be processed at all, such as an invalid
+// token, too many CSRs or a CA that can't sign yet.
+func (s *ConnectCA) SignBatch(
+	args *structs.CASignBatchRequest,
+	reply *structs.CASignBatchResponse) error {
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	if done, err := s.srv.ForwardRPC("ConnectCA.SignBatch", args, reply); done {
+		return err
+	}
+
+	if len(args.CSRs) > maxSignBatchSize {
+		return fmt.Errorf("too many CSRs in batch: %d, the maximum is %d", len(args.CSRs), maxSignBatchSize)
+	}
+
 	authz, err := s.srv.ResolveToken(args.Token)
 	if err != nil {
 		return err
 	}
 
+	results := make([]structs.CASignBatchResult, len(args.CSRs))
+	var (
+		csrs      []*x509.CertificateRequest
+		spiffeIDs []connect.CertURI
+		indexes   []int
+	)
+	for i, pem := range args.CSRs {
+		csr, spiffeID, err := s.authorizeCSR(authz, pem)
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		csrs = append(csrs, csr)
+		spiffeIDs = append(spiffeIDs, spiffeID)
+		indexes = append(indexes, i)
+	}
+
+	if len(csrs) > 0 {
+		certs, errs, err := s.srv.caManager.SignCertificatesWithTTL(csrs, spiffeIDs, args.TTL)
+		if err != nil {
+			return err
+		}
+		for j, i := range indexes {
+			if errs[j] != nil {
+				results[i].Error = errs[j].Error()
+				continue
+			}
+			results[i].Cert = certs[j]
+		}
+	}
+
+	reply.Results = results
+	return nil
+}
+
+// authorizeCSR parses a PEM-encoded leaf CSR and checks that authz may
+// request a certificate for its SPIFFE ID in this datacenter.
+func (s *ConnectCA) authorizeCSR(authz acl.Authorizer, pem string) (*x509.CertificateRequest, connect.CertURI, error) {
+	// Parse the CSR
+	csr, err := connect.ParseCSR(pem)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Parse the SPIFFE ID
+	if len(csr.URIs) == 0 {
+		return nil, nil, fmt.Errorf("CSR must have a SPIFFE ID URI SAN")
+	}
+	spiffeID, err := connect.ParseCertURI(csr.URIs[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var authzContext acl.AuthorizerContext
 	var entMeta structs.EnterpriseMeta
 
 	serviceID, isService := spiffeID.(*connect.SpiffeIDService)
 	agentID, isAgent := spiffeID.(*connect.SpiffeIDAgent)
 	if !isService && !isAgent {
-		return fmt.Errorf("SPIFFE ID in CSR must be a service or agent ID")
+		return nil, nil, fmt.Errorf("SPIFFE ID in CSR must be a service or agent ID")
 	}
 
 	if isService {
 		entMeta.Merge(serviceID.GetEnterpriseMeta())
 		entMeta.FillAuthzContext(&authzContext)
 		if authz.ServiceWrite(serviceID.Service, &authzContext) != acl.Allow {
-			return acl.ErrPermissionDenied
+			return nil, nil, acl.ErrPermissionDenied
 		}
 
 		// Verify that the DC in the service URI matches us. We might relax this
 		// requirement later but being restrictive for now is safer.
 		if serviceID.Datacenter != s.srv.config.Datacenter {
-			return fmt.Errorf("SPIFFE ID in CSR from a different datacenter: %s, "+
+			return nil, nil, fmt.Errorf("SPIFFE ID in CSR from a different datacenter: %s, "+
 				"we are %s", serviceID.Datacenter, s.srv.config.Datacenter)
 		}
 	} else if isAgent {
 		agentID.GetEnterpriseMeta().FillAuthzContext(&authzContext)
 		if authz.NodeWrite(agentID.Agent, &authzContext) != acl.Allow {
-			return acl.ErrPermissionDenied
+			return nil, nil, acl.ErrPermissionDenied
 		}
 	}
-
-	cert, err := s.srv.caManager.SignCertificate(csr, spiffeID)
-	if err != nil {
-		return err
-	}
-	*reply = *cert
-	return nil
+	return csr, spiffeID, nil
 }
 
 // SignIntermediate signs an intermediate certificate for a remote datacenter.
@@ -227,7 +580,7 @@ func (s *ConnectCA) SignIntermediate(
 		return acl.ErrPermissionDenied
 	}
 
-	provider, _ := s.srv.caManager.getCAProvider()
+	provider, root := s.srv.caManager.getCAProvider()
 	if provider == nil {
 		return fmt.Errorf("internal error: CA provider is nil")
 	}
@@ -242,7 +595,542 @@ func (s *ConnectCA) SignIntermediate(
 		return err
 	}
 
+	// Secondaries older than this version don't identify themselves.
+	if args.SourceDatacenter != "" && root != nil {
+		s.srv.caManager.recordSecondary(args.SourceDatacenter, root.ID)
+	}
+
 	*reply = cert
 
 	return nil
 }
+
+// SignIntermediateCSR signs an externally generated intermediate CA CSR using
+// the active root. Unlike SignIntermediate, the CSR must request a CA
+// certificate with certificate signing usage.
+func (s *ConnectCA) SignIntermediateCSR(
+	args *structs.CASignRequest,
+	reply *string) error {
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	if done, err := s.srv.ForwardRPC("ConnectCA.SignIntermediateCSR", args, reply); done {
+		return err
+	}
+
+	// Verify we are allowed to serve this request
+	if s.srv.config.PrimaryDatacenter != s.srv.config.Datacenter {
+		return ErrNotPrimaryDatacenter
+	}
+
+	// This action requires operator write access.
+	authz, err := s.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if authz.OperatorWrite(nil) != acl.Allow {
+		return acl.ErrPermissionDenied
+	}
+
+	csr, err := connect.ParseCSR(args.CSR)
+	if err != nil {
+		return err
+	}
+	if err := connect.ValidateCACSR(csr); err != nil {
+		return err
+	}
+
+	provider, _ := s.srv.caManager.getCAProvider()
+	if provider == nil {
+		return fmt.Errorf("internal error: CA provider is nil")
+	}
+
+	cert, err := provider.SignIntermediate(csr)
+	if err != nil {
+		return err
+	}
+
+	*reply = cert
+
+	return nil
+}
+
+// Revoke adds a leaf certificate issued in this datacenter to the CA's
+// certificate revocation list.
+func (s *ConnectCA) Revoke(
+	args *structs.CARevokeRequest,
+	reply *interface{}) error {
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	if done, err := s.srv.ForwardRPC("ConnectCA.Revoke", args, reply); done {
+		return err
+	}
+
+	// This action requires operator write access.
+	authz, err := s.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if authz.OperatorWrite(nil) != acl.Allow {
+		return acl.ErrPermissionDenied
+	}
+
+	// Normalize the serial so it matches IssuedCert.SerialNumber.
+	sn, err := connect.ParseSerialNumber(args.SerialNumber)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	notAfter := args.NotAfter.UTC()
+	if args.NotAfter.IsZero() {
+		notAfter = now.Add(structs.MaxLeafCertTTL)
+	}
+
+	req := &structs.CARequest{
+		Op:         structs.CAOpRevokeCert,
+		Datacenter: args.Datacenter,
+		RevokedCert: &structs.CARevokedCert{
+			SerialNumber: connect.EncodeSerialNumber(sn),
+			RevokedAt:    now,
+			NotAfter:     notAfter,
+		},
+	}
+	resp, err := s.srv.raftApply(structs.ConnectCARequestType, req)
+	if err != nil {
+		return err
+	}
+	if respErr, ok := resp.(error); ok {
+		return respErr
+	}
+
+	// Don't hand out a cached copy of the revoked leaf.
+	s.srv.caManager.leafCache.flush()
+	return nil
+}
+
+// CRL returns the certificate revocation list for leaf certificates issued
+// in this datacenter, signed by the active signing certificate.
+func (s *ConnectCA) CRL(
+	args *structs.DCSpecificRequest,
+	reply *structs.CARevocationList) error {
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	// Only the leader has a running provider to sign the CRL.
+	args.AllowStale = false
+	if done, err := s.srv.ForwardRPC("ConnectCA.CRL", args, reply); done {
+		return err
+	}
+
+	provider, _ := s.srv.caManager.getCAProvider()
+	if provider == nil {
+		return fmt.Errorf("internal error: CA provider is nil")
+	}
+	crlSigner, ok := provider.(ca.CRLSigner)
+	if !ok {
+		return fmt.Errorf("the current CA provider does not support certificate revocation lists")
+	}
+
+	idx, revoked, err := s.srv.fsm.State().CARevokedCerts(nil)
+	if err != nil {
+		return err
+	}
+
+	// The table index only moves when a cert is revoked, so it makes a
+	// suitable CRL number.
+	crl, err := crlSigner.GenerateCRL(revoked, idx)
+	if err != nil {
+		return err
+	}
+
+	reply.CRL = crl
+	reply.CRLPEM = string(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl}))
+	reply.RevokedCerts = revoked
+	reply.Index = idx
+	return nil
+}
+
+// OCSP reports whether a leaf certificate issued in this datacenter has been
+// revoked. It only consults the revocation list, so any serial number that
+// hasn't been revoked is reported as good.
+func (s *ConnectCA) OCSP(
+	args *structs.CAOCSPRequest,
+	reply *structs.CAOCSPResponse) error {
+	if done, err := s.srv.ForwardRPC("ConnectCA.OCSP", args, reply); done {
+		return err
+	}
+
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	// Normalize the serial so it matches CARevokedCert.SerialNumber.
+	sn, err := connect.ParseSerialNumber(args.SerialNumber)
+	if err != nil {
+		return err
+	}
+	serial := connect.EncodeSerialNumber(sn)
+
+	return s.srv.blockingQuery(
+		&args.QueryOptions, &reply.QueryMeta,
+		func(ws memdb.WatchSet, state *state.Store) error {
+			index, revoked, err := state.CARevokedCert(ws, serial)
+			if err != nil {
+				return err
+			}
+
+			reply.Index = index
+			reply.SerialNumber = serial
+			if revoked == nil {
+				reply.Status = structs.CAOCSPStatusGood
+				reply.RevokedAt = time.Time{}
+				return nil
+			}
+			reply.Status = structs.CAOCSPStatusRevoked
+			reply.RevokedAt = revoked.RevokedAt
+			return nil
+		},
+	)
+}
+
+// ValidateChain verifies a leaf certificate against the current roots the same
+// way the mesh does, and reports why it was rejected if it doesn't verify.
+func (s *ConnectCA) ValidateChain(
+	args *structs.CAValidateChainRequest,
+	reply *structs.CAValidateChainResponse) error {
+	if done, err := s.srv.ForwardRPC("ConnectCA.ValidateChain", args, reply); done {
+		return err
+	}
+
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	certs, err := connect.ParseCertChain(args.LeafCert)
+	if err != nil {
+		return fmt.Errorf("error parsing leaf cert: %v", err)
+	}
+	for _, pemValue := range args.IntermediateCerts {
+		intermediates, err := connect.ParseCertChain(pemValue)
+		if err != nil {
+			return fmt.Errorf("error parsing intermediate cert: %v", err)
+		}
+		certs = append(certs, intermediates...)
+	}
+
+	var expected connect.CertURI
+	if args.ExpectedURI != "" {
+		if expected, err = connect.ParseCertURIFromString(args.ExpectedURI); err != nil {
+			return fmt.Errorf("error parsing expected URI: %v", err)
+		}
+	}
+
+	return s.srv.blockingQuery(
+		&args.QueryOptions, &reply.QueryMeta,
+		func(ws memdb.WatchSet, state *state.Store) error {
+			index, roots, err := state.CARoots(ws)
+			if err != nil {
+				return err
+			}
+
+			result, err := validateLeafChain(roots, certs, expected, s.srv.caManager.timeNow())
+			if err != nil {
+				return err
+			}
+			result.QueryMeta = reply.QueryMeta
+			result.Index = index
+			*reply = *result
+			return nil
+		},
+	)
+}
+
+// validateLeafChain verifies certs[0] against roots, using the rest of certs
+// as intermediates. If expected is non-nil the leaf's URI SAN must also match
+// it, ignoring the trust domain like the mesh does.
+func validateLeafChain(roots structs.CARoots, certs []*x509.Certificate, expected connect.CertURI, now time.Time) (*structs.CAValidateChainResponse, error) {
+	pool := x509.NewCertPool()
+	rootIDs := make(map[string]string, len(roots))
+	for _, root := range roots {
+		cert, err := connect.ParseCert(root.RootCert)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing root %s: %v", root.ID, err)
+		}
+		pool.AddCert(cert)
+		rootIDs[string(cert.Raw)] = root.ID
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	leaf := certs[0]
+	chains, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         pool,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		kind := structs.CAChainErrorInvalid
+		var invalidErr x509.CertificateInvalidError
+		var unknownErr x509.UnknownAuthorityError
+		switch {
+		case errors.As(err, &invalidErr) && invalidErr.Reason == x509.Expired:
+			kind = structs.CAChainErrorExpired
+		case errors.As(err, &unknownErr):
+			kind = structs.CAChainErrorUnknownAuthority
+		}
+		return &structs.CAValidateChainResponse{ErrorKind: kind, Error: err.Error()}, nil
+	}
+
+	if expected != nil {
+		if len(leaf.URIs) == 0 {
+			return &structs.CAValidateChainResponse{
+				ErrorKind: structs.CAChainErrorSANMismatch,
+				Error:     "leaf certificate has no URI SAN",
+			}, nil
+		}
+		got := leaf.URIs[0]
+		want := *expected.URI()
+		want.Host = got.Host
+		if !strings.EqualFold(got.String(), want.String()) {
+			return &structs.CAValidateChainResponse{
+				ErrorKind: structs.CAChainErrorSANMismatch,
+				Error:     fmt.Sprintf("leaf certificate URI is %s, want %s", got, expected.URI()),
+			}, nil
+		}
+	}
+
+	chain := chains[0]
+	return &structs.CAValidateChainResponse{
+		Valid:  true,
+		RootID: rootIDs[string(chain[len(chain)-1].Raw)],
+	}, nil
+}
+
+// Health returns the health of the CA as seen by the leader.
+func (s *ConnectCA) Health(
+	args *structs.DCSpecificRequest,
+	reply *structs.CAHealth) error {
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	// Only the leader runs the CA provider.
+	args.AllowStale = false
+	if done, err := s.srv.ForwardRPC("ConnectCA.Health", args, reply); done {
+		return err
+	}
+
+	// This action requires operator read access.
+	authz, err := s.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if authz.OperatorRead(nil) != acl.Allow {
+		return acl.ErrPermissionDenied
+	}
+
+	idx, config, err := s.srv.fsm.State().CAConfig(nil)
+	if err != nil {
+		return err
+	}
+	if config != nil {
+		reply.Provider = config.Provider
+	}
+
+	status, err := s.srv.caManager.Health()
+	reply.Status = status
+	if err != nil {
+		reply.LastError = err.Error()
+	}
+	reply.LastInitAt, reply.LastInitDuration = s.srv.caManager.LastProviderInit()
+	reply.Index = idx
+	return nil
+}
+
+// Secondaries returns the secondary datacenters the primary's leader has
+// signed an intermediate for, along with the root they were signed by.
+func (s *ConnectCA) Secondaries(
+	args *structs.DCSpecificRequest,
+	reply *structs.IndexedCASecondaries) error {
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	// Only the leader tracks secondaries.
+	args.AllowStale = false
+	if done, err := s.srv.ForwardRPC("ConnectCA.Secondaries", args, reply); done {
+		return err
+	}
+
+	// Verify we are allowed to serve this request
+	if s.srv.config.PrimaryDatacenter != s.srv.config.Datacenter {
+		return ErrNotPrimaryDatacenter
+	}
+
+	// This action requires operator read access.
+	authz, err := s.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if authz.OperatorRead(nil) != acl.Allow {
+		return acl.ErrPermissionDenied
+	}
+
+	reply.Secondaries = s.srv.caManager.Secondaries()
+	return nil
+}
+
+// FederationStatus queries the CA roots of each secondary datacenter known to
+// the primary and reports any that are missing the primary's active root or
+// still use another root as their active one.
+func (s *ConnectCA) FederationStatus(
+	args *structs.DCSpecificRequest,
+	reply *structs.CAFederationStatus) error {
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	if done, err := s.srv.ForwardRPC("ConnectCA.FederationStatus", args, reply); done {
+		return err
+	}
+
+	// Verify we are allowed to serve this request
+	if s.srv.config.PrimaryDatacenter != s.srv.config.Datacenter {
+		return ErrNotPrimaryDatacenter
+	}
+
+	// This action requires operator read access.
+	authz, err := s.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if authz.OperatorRead(nil) != acl.Allow {
+		return acl.ErrPermissionDenied
+	}
+
+	roots, err := s.srv.getCARoots(nil, s.srv.fsm.State())
+	if err != nil {
+		return err
+	}
+	if roots.ActiveRootID == "" {
+		return fmt.Errorf("CA is uninitialized: the primary datacenter has no active root")
+	}
+
+	*reply = *s.srv.caFederationStatus(roots)
+	return nil
+}
+
+// InitializeSecondary initializes the CA of a secondary datacenter that was
+// left uninitialized because ConnectCAManualSecondaryInit is set, pulling the
+// primary's roots and requesting an intermediate.
+func (s *ConnectCA) InitializeSecondary(
+	args *structs.DCSpecificRequest,
+	reply *struct{}) error {
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	// Only the leader runs the CA.
+	args.AllowStale = false
+	if done, err := s.srv.ForwardRPC("ConnectCA.InitializeSecondary", args, reply); done {
+		return err
+	}
+
+	// This action requires operator write access.
+	authz, err := s.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if authz.OperatorWrite(nil) != acl.Allow {
+		return acl.ErrPermissionDenied
+	}
+
+	return s.srv.caManager.InitializeSecondary()
+}
+
+// StateHistory returns the provider state that was in use with each root
+// before it was rotated out.
+func (s *ConnectCA) StateHistory(
+	args *structs.DCSpecificRequest,
+	reply *structs.IndexedCAProviderStateHistory) error {
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	if done, err := s.srv.ForwardRPC("ConnectCA.StateHistory", args, reply); done {
+		return err
+	}
+
+	// Provider state is visible to anyone with operator read access.
+	authz, err := s.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if authz.OperatorRead(nil) != acl.Allow {
+		return acl.ErrPermissionDenied
+	}
+
+	return s.srv.blockingQuery(
+		&args.QueryOptions, &reply.QueryMeta,
+		func(ws memdb.WatchSet, state *state.Store) error {
+			index, history, err := state.CAProviderStateHistory(ws)
+			if err != nil {
+				return err
+			}
+
+			reply.Index = index
+			reply.History = history
+			return nil
+		},
+	)
+}
+
+// RefreshIntermediate requests a new intermediate for a secondary datacenter
+// from the primary without rotating the root.
+func (s *ConnectCA) RefreshIntermediate(
+	args *structs.CARefreshIntermediateRequest,
+	reply *structs.CARefreshIntermediateResponse) error {
+	// Exit early if Connect hasn't been enabled.
+	if !s.srv.config.ConnectEnabled {
+		return ErrConnectNotEnabled
+	}
+
+	if done, err := s.srv.ForwardRPC("ConnectCA.RefreshIntermediate", args, reply); done {
+		return err
+	}
+
+	// This action requires operator write access.
+	authz, err := s.srv.ResolveToken(args.Token)
+	if err != nil {
+		return err
+	}
+	if authz.OperatorWrite(nil) != acl.Allow {
+		return acl.ErrPermissionDenied
+	}
+
+	serial, err := s.srv.caManager.RefreshSecondaryIntermediate()
+	if err != nil {
+		return err
+	}
+	reply.SerialNumber = serial
+	return nil
+}
@@ -431,6 +431,24 @@ func (c *FSM) applyConnectCAOperation(buf []byte, index uint64) interface{} {
 		}
 
 		return sn
+	case structs.CAOpRevokeCert:
+		if err := c.state.CARevokeCert(index, req.RevokedCert); err != nil {
+			return err
+		}
+
+		return true
+	case structs.CAOpPruneRevokedCerts:
+		if err := c.state.CAPruneRevokedCerts(index, req.RevokedCertsExpiredBefore); err != nil {
+			return err
+		}
+
+		return true
+	case structs.CAOpAddProviderStateSnapshot:
+		if err := c.state.CAAddProviderStateSnapshot(index, req.ProviderStateSnapshot); err != nil {
+			return err
+		}
+
+		return true
 	default:
 		c.logger.Warn("Invalid CA operation", "operation", req.Op)
 		return fmt.Errorf("Invalid CA operation '%s'", req.Op)
@@ -1309,6 +1309,111 @@ func TestFSM_CABuiltinProvider(t *testing.T) {
 	}
 }
 
+func TestFSM_CARevokeCert(t *testing.T) {
+	t.Parallel()
+
+	logger := testutil.Logger(t)
+	fsm, err := New(nil, logger)
+	require.NoError(t, err)
+
+	req := structs.CARequest{
+		Op:          structs.CAOpRevokeCert,
+		RevokedCert: &structs.CARevokedCert{SerialNumber: "0a:0b"},
+	}
+	buf, err := structs.Encode(structs.ConnectCARequestType, req)
+	require.NoError(t, err)
+	require.True(t, fsm.Apply(makeLog(buf)).(bool))
+
+	// Verify it's in the state store.
+	_, certs, err := fsm.state.CARevokedCerts(nil)
+	require.NoError(t, err)
+	require.Len(t, certs, 1)
+	require.Equal(t, "0a:0b", certs[0].SerialNumber)
+}
+
+func TestFSM_CAPruneRevokedCerts(t *testing.T) {
+	t.Parallel()
+
+	logger := testutil.Logger(t)
+	fsm, err := New(nil, logger)
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.NoError(t, fsm.state.CARevokeCert(1, &structs.CARevokedCert{SerialNumber: "01", NotAfter: now.Add(-time.Hour)}))
+	require.NoError(t, fsm.state.CARevokeCert(2, &structs.CARevokedCert{SerialNumber: "02", NotAfter: now.Add(time.Hour)}))
+
+	req := structs.CARequest{
+		Op:                        structs.CAOpPruneRevokedCerts,
+		RevokedCertsExpiredBefore: now,
+	}
+	buf, err := structs.Encode(structs.ConnectCARequestType, req)
+	require.NoError(t, err)
+	require.True(t, fsm.Apply(makeLog(buf)).(bool))
+
+	// Only the unexpired cert is left.
+	_, certs, err := fsm.state.CARevokedCerts(nil)
+	require.NoError(t, err)
+	require.Len(t, certs, 1)
+	require.Equal(t, "02", certs[0].SerialNumber)
+}
+
+func TestFSM_CAAddProviderStateSnapshot(t *testing.T) {
+	t.Parallel()
+
+	logger := testutil.Logger(t)
+	fsm, err := New(nil, logger)
+	require.NoError(t, err)
+
+	// Rotating the root and config doesn't record any history on its own.
+	oldRoot := connect.TestCA(t, nil)
+	oldRoot.Active = true
+	_, err = fsm.state.CARootSetCAS(1, 0, []*structs.CARoot{oldRoot})
+	require.NoError(t, err)
+	require.NoError(t, fsm.state.CASetConfig(2, &structs.CAConfiguration{
+		Provider: "consul",
+		State:    map[string]string{"foo": "bar"},
+	}))
+	_, config, err := fsm.state.CAConfig(nil)
+	require.NoError(t, err)
+
+	rotatedOut := *oldRoot
+	rotatedOut.Active = false
+	newRoot := connect.TestCA(t, nil)
+	newRoot.Active = true
+	req := structs.CARequest{
+		Op:     structs.CAOpSetRootsAndConfig,
+		Index:  1,
+		Roots:  []*structs.CARoot{&rotatedOut, newRoot},
+		Config: config,
+	}
+	buf, err := structs.Encode(structs.ConnectCARequestType, req)
+	require.NoError(t, err)
+	require.True(t, fsm.Apply(makeLog(buf)).(bool))
+
+	_, history, err := fsm.state.CAProviderStateHistory(nil)
+	require.NoError(t, err)
+	require.Empty(t, history)
+
+	snap := &structs.CAProviderStateSnapshot{
+		RootID:   oldRoot.ID,
+		Provider: config.Provider,
+		State:    config.State,
+	}
+	req = structs.CARequest{
+		Op:                    structs.CAOpAddProviderStateSnapshot,
+		ProviderStateSnapshot: snap,
+	}
+	buf, err = structs.Encode(structs.ConnectCARequestType, req)
+	require.NoError(t, err)
+	require.True(t, fsm.Apply(makeLog(buf)).(bool))
+
+	_, history, err = fsm.state.CAProviderStateHistory(nil)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, oldRoot.ID, history[0].RootID)
+	require.Equal(t, snap.State, history[0].State)
+}
+
 func TestFSM_ConfigEntry(t *testing.T) {
 	t.Parallel()
 
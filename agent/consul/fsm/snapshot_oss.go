@@ -24,6 +24,8 @@ func init() {
 	registerRestorer(structs.ConnectCARequestType, restoreConnectCA)
 	registerRestorer(structs.ConnectCAProviderStateType, restoreConnectCAProviderState)
 	registerRestorer(structs.ConnectCAConfigType, restoreConnectCAConfig)
+	registerRestorer(structs.ConnectCARevokedCertType, restoreConnectCARevokedCert)
+	registerRestorer(structs.ConnectCAStateHistoryType, restoreConnectCAStateHistory)
 	registerRestorer(structs.IndexRequestType, restoreIndex)
 	registerRestorer(structs.ACLTokenSetRequestType, restoreToken)
 	registerRestorer(structs.ACLPolicySetRequestType, restorePolicy)
@@ -69,6 +71,12 @@ func persistOSS(s *snapshot, sink raft.SnapshotSink, encoder *codec.Encoder) err
 	if err := s.persistConnectCAConfig(sink, encoder); err != nil {
 		return err
 	}
+	if err := s.persistConnectCARevokedCerts(sink, encoder); err != nil {
+		return err
+	}
+	if err := s.persistConnectCAStateHistory(sink, encoder); err != nil {
+		return err
+	}
 	if err := s.persistConfigEntries(sink, encoder); err != nil {
 		return err
 	}
@@ -406,6 +414,42 @@ func (s *snapshot) persistConnectCAProviderState(sink raft.SnapshotSink,
 	return nil
 }
 
+func (s *snapshot) persistConnectCARevokedCerts(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	certs, err := s.state.CARevokedCerts()
+	if err != nil {
+		return err
+	}
+
+	for _, r := range certs {
+		if _, err := sink.Write([]byte{byte(structs.ConnectCARevokedCertType)}); err != nil {
+			return err
+		}
+		if err := encoder.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *snapshot) persistConnectCAStateHistory(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+	history, err := s.state.CAProviderStateHistory()
+	if err != nil {
+		return err
+	}
+
+	for _, r := range history {
+		if _, err := sink.Write([]byte{byte(structs.ConnectCAStateHistoryType)}); err != nil {
+			return err
+		}
+		if err := encoder.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *snapshot) persistLegacyIntentions(sink raft.SnapshotSink,
 	encoder *codec.Encoder) error {
 	//nolint:staticcheck
@@ -707,6 +751,28 @@ func restoreConnectCAConfig(header *SnapshotHeader, restore *state.Restore, deco
 	return nil
 }
 
+func restoreConnectCARevokedCert(header *SnapshotHeader, restore *state.Restore, decoder *codec.Decoder) error {
+	var req structs.CARevokedCert
+	if err := decoder.Decode(&req); err != nil {
+		return err
+	}
+	if err := restore.CARevokedCert(&req); err != nil {
+		return err
+	}
+	return nil
+}
+
+func restoreConnectCAStateHistory(header *SnapshotHeader, restore *state.Restore, decoder *codec.Decoder) error {
+	var req structs.CAProviderStateSnapshot
+	if err := decoder.Decode(&req); err != nil {
+		return err
+	}
+	if err := restore.CAProviderStateSnapshot(&req); err != nil {
+		return err
+	}
+	return nil
+}
+
 func restoreIndex(header *SnapshotHeader, restore *state.Restore, decoder *codec.Decoder) error {
 	var req state.IndexEntry
 	if err := decoder.Decode(&req); err != nil {
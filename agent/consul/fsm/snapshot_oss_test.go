@@ -212,6 +212,18 @@ func TestFSM_SnapshotRestore_OSS(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, ok)
 
+	// Revoked leaf certs
+	revokedCert := &structs.CARevokedCert{SerialNumber: "0a:0b"}
+	require.NoError(t, fsm.state.CARevokeCert(16, revokedCert))
+
+	// CA provider state history
+	stateSnap := &structs.CAProviderStateSnapshot{
+		RootID:   "old-root",
+		Provider: "consul",
+		State:    map[string]string{"foo": "bar"},
+	}
+	require.NoError(t, fsm.state.CAAddProviderStateSnapshot(16, stateSnap))
+
 	// CA Config
 	caConfig := &structs.CAConfiguration{
 		ClusterID: "foo",
@@ -657,6 +669,16 @@ func TestFSM_SnapshotRestore_OSS(t *testing.T) {
 	require.Equal(t, "foo", state.PrivateKey)
 	require.Equal(t, "bar", state.RootCert)
 
+	// Verify revoked certs are restored.
+	_, revokedCerts, err := fsm2.state.CARevokedCerts(nil)
+	require.NoError(t, err)
+	require.Equal(t, []*structs.CARevokedCert{revokedCert}, revokedCerts)
+
+	// Verify CA provider state history is restored.
+	_, stateHistory, err := fsm2.state.CAProviderStateHistory(nil)
+	require.NoError(t, err)
+	require.Equal(t, []*structs.CAProviderStateSnapshot{stateSnap}, stateHistory)
+
 	// Verify CA configuration is restored.
 	_, caConf, err := fsm2.state.CAConfig(nil)
 	require.NoError(t, err)
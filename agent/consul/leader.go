@@ -49,9 +49,6 @@ const (
 )
 
 var (
-	// caRootPruneInterval is how often we check for stale CARoots to remove.
-	caRootPruneInterval = time.Hour
-
 	// minCentralizedConfigVersion is the minimum Consul version in which centralized
 	// config is supported
 	minCentralizedConfigVersion = version.Must(version.NewVersion("1.5.0"))
@@ -1,11 +1,19 @@
 package consul
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"fmt"
+	"sort"
 	"time"
 
+	"github.com/hashicorp/go-memdb"
 	"golang.org/x/time/rate"
 
+	"github.com/hashicorp/consul/agent/connect"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/logging"
 )
@@ -50,18 +58,34 @@ func (s *Server) stopConnectLeader() {
 }
 
 func (s *Server) runCARootPruning(ctx context.Context) error {
-	ticker := time.NewTicker(caRootPruneInterval)
-	defer ticker.Stop()
-
 	for {
+		// Watch the CA config so that changes to the prune interval take effect
+		// without waiting for the previous interval to elapse.
+		ws := memdb.NewWatchSet()
+		_, caConf, err := s.fsm.State().CAConfig(ws)
+		if err != nil {
+			return err
+		}
+
+		watchCtx, cancel := context.WithCancel(ctx)
+		timer := time.NewTimer(caConf.GetRootPruneInterval())
 		select {
 		case <-ctx.Done():
+			timer.Stop()
+			cancel()
 			return nil
-		case <-ticker.C:
+		case <-ws.WatchCh(watchCtx):
+			// The config changed, reset the timer using the latest interval.
+		case <-timer.C:
 			if err := s.pruneCARoots(); err != nil {
 				s.loggers.Named(logging.Connect).Error("error pruning CA roots", "error", err)
 			}
+			if err := s.pruneCARevokedCerts(); err != nil {
+				s.loggers.Named(logging.Connect).Error("error pruning revoked CA certificates", "error", err)
+			}
 		}
+		timer.Stop()
+		cancel()
 	}
 }
 
@@ -87,18 +111,64 @@ func (s *Server) pruneCARoots() error {
 		return err
 	}
 
+	// Replaced intermediates must outlive every leaf they signed, so never keep
+	// them for less than the leaf TTL.
+	keepIntermediatesFor := caConf.IntermediateGracePeriod
+	if keepIntermediatesFor != 0 && keepIntermediatesFor < common.LeafCertTTL {
+		keepIntermediatesFor = common.LeafCertTTL
+	}
+
+	now := time.Now()
+	changed := false
 	var newRoots structs.CARoots
 	for _, r := range roots {
-		if !r.Active && !r.RotatedOutAt.IsZero() && time.Now().Sub(r.RotatedOutAt) > common.LeafCertTTL*2 {
+		if !r.Active && !r.RotatedOutAt.IsZero() && now.Sub(r.RotatedOutAt) > common.LeafCertTTL*2 &&
+			s.runCARootPruneHook(r) {
 			s.loggers.Named(logging.Connect).Info("pruning old unused root CA", "id", r.ID)
+			changed = true
 			continue
 		}
 		newRoot := *r
+		if r.Active && keepIntermediatesFor > 0 {
+			intermediates, err := pruneExpiredIntermediates(r, keepIntermediatesFor, intermediateNotBeforeBackdate(caConf), now)
+			if err != nil {
+				return err
+			}
+			if len(intermediates) != len(r.IntermediateCerts) {
+				s.loggers.Named(logging.Connect).Info("pruning replaced intermediate certificates",
+					"id", r.ID,
+					"count", len(r.IntermediateCerts)-len(intermediates),
+				)
+				newRoot.IntermediateCerts = intermediates
+				changed = true
+			}
+		}
 		newRoots = append(newRoots, &newRoot)
 	}
 
+	if caConf.MaxRetainedRoots > 0 && len(newRoots) > caConf.MaxRetainedRoots {
+		retained := pruneOldestRoots(newRoots, caConf.MaxRetainedRoots)
+		var kept structs.CARoots
+		for _, r := range newRoots {
+			if containsRoot(retained, r.ID) {
+				kept = append(kept, r)
+				continue
+			}
+			if !s.runCARootPruneHook(r) {
+				kept = append(kept, r)
+				continue
+			}
+			s.loggers.Named(logging.Connect).Info("pruning old root CA beyond the retained roots limit",
+				"id", r.ID,
+				"max_retained_roots", caConf.MaxRetainedRoots,
+			)
+			changed = true
+		}
+		newRoots = kept
+	}
+
 	// Return early if there's nothing to remove.
-	if len(newRoots) == len(roots) {
+	if !changed {
 		return nil
 	}
 
@@ -107,21 +177,211 @@ func (s *Server) pruneCARoots() error {
 	args.Op = structs.CAOpSetRoots
 	args.Index = idx
 	args.Roots = newRoots
-	_, err = s.raftApply(structs.ConnectCARequestType, args)
-	return err
+	if _, err := s.raftApply(structs.ConnectCARequestType, args); err != nil {
+		return err
+	}
+
+	// The private keys retained for rolling back to pruned roots are no
+	// longer needed.
+	for _, r := range roots {
+		if !containsRoot(newRoots, r.ID) {
+			if err := s.caManager.releaseRootKey(r.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pruneCARevokedCerts removes revoked leaf certs that have expired, since a
+// CRL only needs to list certs that would otherwise still be valid.
+func (s *Server) pruneCARevokedCerts() error {
+	if !s.config.ConnectEnabled {
+		return nil
+	}
+
+	_, revoked, err := s.fsm.State().CARevokedCerts(nil)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	expired := 0
+	for _, r := range revoked {
+		if r.ExpiresAt().Before(now) {
+			expired++
+		}
+	}
+
+	// Return early if there's nothing to remove.
+	if expired == 0 {
+		return nil
+	}
+
+	s.loggers.Named(logging.Connect).Info("pruning expired revoked certificates", "count", expired)
+	args := structs.CARequest{
+		Op:                        structs.CAOpPruneRevokedCerts,
+		RevokedCertsExpiredBefore: now,
+	}
+	resp, err := s.raftApply(structs.ConnectCARequestType, args)
+	if err != nil {
+		return err
+	}
+	if respErr, ok := resp.(error); ok {
+		return respErr
+	}
+	return nil
+}
+
+// runCARootPruneHook calls the configured CARootPruneHook for a root that is
+// about to be pruned and reports whether the root may be removed.
+func (s *Server) runCARootPruneHook(root *structs.CARoot) bool {
+	if s.config.CARootPruneHook == nil {
+		return true
+	}
+	if err := s.config.CARootPruneHook(root); err != nil {
+		s.loggers.Named(logging.Connect).Warn("CA root prune hook failed, keeping root until the next pass",
+			"id", root.ID,
+			"error", err,
+		)
+		return false
+	}
+	return true
+}
+
+// pruneOldestRoots drops the oldest inactive roots until at most max remain.
+// The active root is always kept.
+func pruneOldestRoots(roots structs.CARoots, max int) structs.CARoots {
+	var inactive structs.CARoots
+	for _, r := range roots {
+		if !r.Active {
+			inactive = append(inactive, r)
+		}
+	}
+	// Roots are only ever added by rotation, so creation order is the order
+	// they were rotated in.
+	sort.Slice(inactive, func(i, j int) bool {
+		return inactive[i].CreateIndex < inactive[j].CreateIndex
+	})
+
+	drop := len(roots) - max
+	if drop > len(inactive) {
+		drop = len(inactive)
+	}
+	dropped := make(map[string]bool, drop)
+	for _, r := range inactive[:drop] {
+		dropped[r.ID] = true
+	}
+
+	var keep structs.CARoots
+	for _, r := range roots {
+		if !dropped[r.ID] {
+			keep = append(keep, r)
+		}
+	}
+	return keep
+}
+
+func containsRoot(roots structs.CARoots, id string) bool {
+	for _, r := range roots {
+		if r.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// pruneExpiredIntermediates returns the intermediates of root that should be
+// retained. An intermediate is dropped once it is no longer the signing
+// certificate and its replacement was issued more than keepFor ago, so that
+// no leaf signed by it can still be valid. driftBuffer is how far the
+// provider backdates NotBefore. Cross-signed certificates for the root itself
+// are always retained.
+func pruneExpiredIntermediates(root *structs.CARoot, keepFor, driftBuffer time.Duration, now time.Time) ([]string, error) {
+	if len(root.IntermediateCerts) < 2 {
+		return root.IntermediateCerts, nil
+	}
+
+	rootCert, err := connect.ParseCert(root.RootCert)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing root cert: %v", err)
+	}
+
+	certs := make([]*x509.Certificate, len(root.IntermediateCerts))
+	for i, pem := range root.IntermediateCerts {
+		if certs[i], err = connect.ParseCert(pem); err != nil {
+			return nil, fmt.Errorf("error parsing intermediate cert: %v", err)
+		}
+	}
+
+	isIntermediate := func(cert *x509.Certificate) bool {
+		return !bytes.Equal(cert.SubjectKeyId, rootCert.SubjectKeyId)
+	}
+
+	var keep []string
+	for i, cert := range certs {
+		if !isIntermediate(cert) || connect.EncodeSigningKeyID(cert.SubjectKeyId) == root.SigningKeyID {
+			keep = append(keep, root.IntermediateCerts[i])
+			continue
+		}
+
+		// Find the intermediate that replaced this one. Intermediates are always
+		// appended, so it is the next one in the list. NotBefore is backdated by
+		// the drift buffer so add it back to get the time it was issued.
+		var replacedAt time.Time
+		for _, next := range certs[i+1:] {
+			if isIntermediate(next) {
+				replacedAt = next.NotBefore.Add(driftBuffer)
+				break
+			}
+		}
+		if replacedAt.IsZero() || now.Sub(replacedAt) <= keepFor {
+			keep = append(keep, root.IntermediateCerts[i])
+		}
+	}
+	return keep, nil
+}
+
+// retryBackoff controls how long retryLoopBackoff waits after each failed
+// attempt. The first wait is Min and every further consecutive failure
+// multiplies it by Multiplier, up to Max.
+type retryBackoff struct {
+	Min        time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+// defaultRetryBackoff doubles from 2s up to maxRetryBackoff seconds.
+var defaultRetryBackoff = retryBackoff{
+	Min:        2 * time.Second,
+	Max:        time.Duration(maxRetryBackoff) * time.Second,
+	Multiplier: 2,
+}
+
+// wait returns the time to wait after the given number of consecutive
+// failures, which must be at least one.
+func (b retryBackoff) wait(failedAttempts uint) time.Duration {
+	wait := float64(b.Min)
+	for i := uint(1); i < failedAttempts && wait < float64(b.Max); i++ {
+		wait *= b.Multiplier
+	}
+	if wait > float64(b.Max) {
+		return b.Max
+	}
+	return time.Duration(wait)
 }
 
 // retryLoopBackoff loops a given function indefinitely, backing off exponentially
 // upon errors up to a maximum of maxRetryBackoff seconds.
 func retryLoopBackoff(ctx context.Context, loopFn func() error, errFn func(error)) {
-	retryLoopBackoffHandleSuccess(ctx, loopFn, errFn, false)
+	retryLoopBackoffHandleSuccess(ctx, defaultRetryBackoff, loopFn, errFn, false)
 }
 
 func retryLoopBackoffAbortOnSuccess(ctx context.Context, loopFn func() error, errFn func(error)) {
-	retryLoopBackoffHandleSuccess(ctx, loopFn, errFn, true)
+	retryLoopBackoffHandleSuccess(ctx, defaultRetryBackoff, loopFn, errFn, true)
 }
 
-func retryLoopBackoffHandleSuccess(ctx context.Context, loopFn func() error, errFn func(error), abortOnSuccess bool) {
+func retryLoopBackoffHandleSuccess(ctx context.Context, backoff retryBackoff, loopFn func() error, errFn func(error), abortOnSuccess bool) {
 	var failedAttempts uint
 	limiter := rate.NewLimiter(loopRateLimit, retryBucketSize)
 	for {
@@ -132,15 +392,12 @@ func retryLoopBackoffHandleSuccess(ctx context.Context, loopFn func() error, err
 			return
 		default:
 		}
-		if (1 << failedAttempts) < maxRetryBackoff {
-			failedAttempts++
-		}
-		retryTime := (1 << failedAttempts) * time.Second
 
 		if err := loopFn(); err != nil {
 			errFn(err)
 
-			timer := time.NewTimer(retryTime)
+			failedAttempts++
+			timer := time.NewTimer(backoff.wait(failedAttempts))
 			select {
 			case <-ctx.Done():
 				timer.Stop()
@@ -166,18 +423,38 @@ func nextIndexVal(prevIdx, idx uint64) uint64 {
 	return idx
 }
 
-// halfTime returns a duration that is half the time between notBefore and
-// notAfter.
-func halfTime(notBefore, notAfter time.Time) time.Duration {
-	interval := notAfter.Sub(notBefore)
-	return interval / 2
-}
-
 // lessThanHalfTimePassed decides if half the time between notBefore and
 // notAfter has passed relative to now.
 // lessThanHalfTimePassed is being called while holding caProviderReconfigurationLock
 // which means it must never take that lock itself or call anything that does.
 func lessThanHalfTimePassed(now, notBefore, notAfter time.Time) bool {
-	t := notBefore.Add(halfTime(notBefore, notAfter))
-	return t.Sub(now) > 0
+	return !fractionTimePassed(now, notBefore, notAfter, 0.5)
+}
+
+// fractionTimePassed decides if at least fraction of the time between start
+// and end has passed relative to now.
+func fractionTimePassed(now, start, end time.Time, fraction float64) bool {
+	return connect.FractionTimePassed(now, start, end, fraction)
+}
+
+// lessThanRenewTimePassed decides if the renewal point, fraction of the time
+// between notBefore and notAfter, has not yet passed relative to now. The
+// renewal point is moved later by up to jitter times the time between
+// notBefore and notAfter. The offset is derived from seed, such as the serial
+// number of the cert being renewed, so every check for the same cert uses the
+// same point while different certs are spread across the window.
+func lessThanRenewTimePassed(now, notBefore, notAfter time.Time, fraction, jitter float64, seed []byte) bool {
+	var offset time.Duration
+	if lifetime := notAfter.Sub(notBefore); jitter > 0 && lifetime > 0 {
+		offset = renewJitterOffset(seed, time.Duration(jitter*float64(lifetime)))
+	}
+	return !fractionTimePassed(now.Add(-offset), notBefore, notAfter, fraction)
+}
+
+// renewJitterOffset returns an offset in [0, max) that is always the same for
+// the same seed.
+func renewJitterOffset(seed []byte, max time.Duration) time.Duration {
+	sum := sha256.Sum256(seed)
+	frac := float64(binary.BigEndian.Uint64(sum[:8])>>11) / (1 << 53)
+	return time.Duration(frac * float64(max))
 }
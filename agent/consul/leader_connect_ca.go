@@ -1,17 +1,25 @@
 package consul
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/url"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/armon/go-metrics"
+	"github.com/armon/go-metrics/prometheus"
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-memdb"
 	uuid "github.com/hashicorp/go-uuid"
 	"golang.org/x/time/rate"
 
@@ -20,10 +28,38 @@ import (
 	"github.com/hashicorp/consul/agent/connect"
 	"github.com/hashicorp/consul/agent/connect/ca"
 	"github.com/hashicorp/consul/agent/consul/state"
+	"github.com/hashicorp/consul/agent/consul/stream"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/lib/routine"
 )
 
+var metricsKeyConnectCALeafSigned = []string{"connect", "ca", "leaf", "signed"}
+var metricsKeyConnectCALeafSignTime = []string{"connect", "ca", "leaf", "sign_time"}
+var metricsKeyConnectCARootRotated = []string{"connect", "ca", "root", "rotated"}
+var metricsKeyConnectCAProviderStateMutated = []string{"connect", "ca", "provider", "state_mutated"}
+
+var CAManagerCounters = []prometheus.CounterDefinition{
+	{
+		Name: metricsKeyConnectCALeafSigned,
+		Help: "Increments whenever a leaf certificate is signed by the Connect CA.",
+	},
+	{
+		Name: metricsKeyConnectCARootRotated,
+		Help: "Increments whenever the primary datacenter rotates its active CA root.",
+	},
+	{
+		Name: metricsKeyConnectCAProviderStateMutated,
+		Help: "Increments whenever a CA provider modifies the provider state it was configured with.",
+	},
+}
+
+var CAManagerSummaries = []prometheus.SummaryDefinition{
+	{
+		Name: metricsKeyConnectCALeafSignTime,
+		Help: "Measures the time spent by the CA provider signing a leaf certificate.",
+	},
+}
+
 type caState string
 
 const (
@@ -56,6 +92,8 @@ type CAManager struct {
 	logger     hclog.Logger
 	// rate limiter to use when signing leaf certificates
 	caLeafLimiter connectSignRateLimiter
+	// leafCache deduplicates identical CSRs when ConnectLeafSignCacheTTL is set.
+	leafCache leafSignCache
 
 	providerLock sync.RWMutex
 	// provider is the current CA provider in use for Connect. This is
@@ -72,6 +110,24 @@ type CAManager struct {
 	state             caState
 	primaryRoots      structs.IndexedCARoots // The most recently seen state of the root CAs from the primary datacenter.
 	actingSecondaryCA bool                   // True if this datacenter has been initialized as a secondary CA.
+	initErr           error                  // The error from the most recent failed attempt to initialize the CA.
+	lastInitAt        time.Time              // When the provider was last successfully initialized or reconfigured.
+	lastInitDuration  time.Duration          // How long that initialization took.
+
+	// secondaries are the secondary datacenters this primary has signed an
+	// intermediate for since becoming leader, by datacenter. It is also
+	// protected by stateLock.
+	secondaries map[string]structs.CASecondary
+
+	// secondaryInitCh hands requests from InitializeSecondary to the routine
+	// waiting to initialize a secondary datacenter's CA when
+	// ConnectCAManualSecondaryInit is set. The result of the attempt is sent
+	// back on the request's channel.
+	secondaryInitCh chan chan error
+
+	// leaderCtx is the context passed to Start, which is canceled when this
+	// server loses leadership. It is also protected by stateLock.
+	leaderCtx context.Context
 
 	leaderRoutineManager *routine.Manager
 	// providerShim is used to test CAManager with a fake provider.
@@ -121,9 +177,71 @@ func (c *caDelegateWithState) ApplyCALeafRequest() (uint64, error) {
 
 func (c *caDelegateWithState) generateCASignRequest(csr string) *structs.CASignRequest {
 	return &structs.CASignRequest{
-		Datacenter:   c.Server.config.PrimaryDatacenter,
-		CSR:          csr,
-		WriteRequest: structs.WriteRequest{Token: c.Server.tokens.ReplicationToken()},
+		Datacenter:       c.Server.config.PrimaryDatacenter,
+		CSR:              csr,
+		SourceDatacenter: c.Server.config.Datacenter,
+		WriteRequest:     structs.WriteRequest{Token: c.Server.tokens.ReplicationToken()},
+	}
+}
+
+// caDryRunDelegate is a ca.ConsulProviderStateDelegate that applies provider
+// state changes to a private copy of the state store instead of Raft, so the
+// built-in provider can be configured during a dry run without side effects.
+type caDryRunDelegate struct {
+	store *state.Store
+	index uint64
+}
+
+// newCADryRunDelegate returns a caDryRunDelegate seeded with the CA config and
+// built-in provider state from src.
+func newCADryRunDelegate(src *state.Store) (*caDryRunDelegate, error) {
+	snap := src.Snapshot()
+	defer snap.Close()
+
+	config, err := snap.CAConfig()
+	if err != nil {
+		return nil, err
+	}
+	providerStates, err := snap.CAProviderState()
+	if err != nil {
+		return nil, err
+	}
+
+	store := state.NewStateStore(nil)
+	restore := store.Restore()
+	defer restore.Abort()
+	if config != nil {
+		if err := restore.CAConfig(config); err != nil {
+			return nil, err
+		}
+	}
+	for _, providerState := range providerStates {
+		if err := restore.CAProviderState(providerState); err != nil {
+			return nil, err
+		}
+	}
+	if err := restore.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &caDryRunDelegate{store: store, index: snap.LastIndex()}, nil
+}
+
+func (d *caDryRunDelegate) State() *state.Store {
+	return d.store
+}
+
+func (d *caDryRunDelegate) ApplyCARequest(req *structs.CARequest) (interface{}, error) {
+	d.index++
+	switch req.Op {
+	case structs.CAOpSetProviderState:
+		return d.store.CASetProviderState(d.index, req.ProviderState)
+	case structs.CAOpDeleteProviderState:
+		return true, d.store.CADeleteProviderState(d.index, req.ProviderState.ID)
+	case structs.CAOpIncrementProviderSerialNumber:
+		return d.store.CAIncrementProviderSerialNumber(d.index)
+	default:
+		return nil, fmt.Errorf("Invalid CA operation '%s' during dry run", req.Op)
 	}
 }
 
@@ -135,6 +253,7 @@ func NewCAManager(delegate caServerDelegate, leaderRoutineManager *routine.Manag
 		state:                caStateUninitialized,
 		leaderRoutineManager: leaderRoutineManager,
 		timeNow:              time.Now,
+		secondaryInitCh:      make(chan chan error),
 	}
 }
 
@@ -249,11 +368,23 @@ func parseCARoot(pemValue, provider, clusterID string) (*structs.CARoot, error)
 	if err != nil {
 		return nil, fmt.Errorf("error extracting root key info: %v", err)
 	}
+	certs, err := connect.ParseCerts(pemValue)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing root cert chain: %v", err)
+	}
+	var signingKeyIDChain []string
+	if len(certs) > 1 {
+		for _, cert := range certs {
+			signingKeyIDChain = append(signingKeyIDChain, connect.EncodeSigningKeyID(cert.SubjectKeyId))
+		}
+	}
 	return &structs.CARoot{
 		ID:                  id,
 		Name:                fmt.Sprintf("%s CA Root Cert", strings.Title(provider)),
 		SerialNumber:        rootCert.SerialNumber.Uint64(),
+		SerialTruncated:     !rootCert.SerialNumber.IsUint64(),
 		SigningKeyID:        connect.EncodeSigningKeyID(rootCert.SubjectKeyId),
+		SigningKeyIDChain:   signingKeyIDChain,
 		ExternalTrustDomain: clusterID,
 		NotBefore:           rootCert.NotBefore,
 		NotAfter:            rootCert.NotAfter,
@@ -264,6 +395,84 @@ func parseCARoot(pemValue, provider, clusterID string) (*structs.CARoot, error)
 	}, nil
 }
 
+// warnIfSerialTruncated logs a warning if root's serial number had to be
+// truncated to fit in CARoot.SerialNumber, which is likely for externally
+// supplied root certs.
+func (c *CAManager) warnIfSerialTruncated(root *structs.CARoot) {
+	if !root.SerialTruncated {
+		return
+	}
+	c.logger.Warn("CA root serial number is longer than 64 bits, so the root's reported SerialNumber is truncated; use ConnectCA.DescribeRoot to see the full serial",
+		"id", root.ID,
+		"serial", root.SerialNumber,
+	)
+}
+
+// describeCARoot decodes the first certificate in pemValue into the fields
+// returned by ConnectCA.DescribeRoot.
+func describeCARoot(pemValue string) (*structs.CARootDescription, error) {
+	id, err := connect.CalculateCertFingerprint(pemValue)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing root fingerprint: %v", err)
+	}
+	rootCert, err := connect.ParseCert(pemValue)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing root cert: %v", err)
+	}
+	keyType, keyBits, err := connect.KeyInfoFromCert(rootCert)
+	if err != nil {
+		return nil, fmt.Errorf("error extracting root key info: %v", err)
+	}
+
+	var trustDomain string
+	for _, uri := range rootCert.URIs {
+		if uri.Scheme == "spiffe" {
+			trustDomain = uri.Host
+			break
+		}
+	}
+
+	return &structs.CARootDescription{
+		ID:             id,
+		SerialNumber:   connect.EncodeSerialNumber(rootCert.SerialNumber),
+		Subject:        rootCert.Subject.String(),
+		Issuer:         rootCert.Issuer.String(),
+		NotBefore:      rootCert.NotBefore,
+		NotAfter:       rootCert.NotAfter,
+		KeyType:        keyType,
+		KeyBits:        keyBits,
+		SubjectKeyID:   connect.EncodeSigningKeyID(rootCert.SubjectKeyId),
+		AuthorityKeyID: connect.EncodeSigningKeyID(rootCert.AuthorityKeyId),
+		TrustDomain:    trustDomain,
+	}, nil
+}
+
+// rootKeyType returns the key type of the given root, falling back to parsing
+// the root cert for roots persisted before PrivateKeyType was recorded.
+func rootKeyType(root *structs.CARoot) string {
+	if root.PrivateKeyType != "" {
+		return root.PrivateKeyType
+	}
+	cert, err := connect.ParseCert(root.RootCert)
+	if err != nil {
+		return ""
+	}
+	keyType, _, err := connect.KeyInfoFromCert(cert)
+	if err != nil {
+		return ""
+	}
+	return keyType
+}
+
+// crossSignKeyTypeMismatch returns true if exactly one of the given roots uses
+// an Ed25519 key, in which case the new root should not be cross-signed by the
+// old one.
+func crossSignKeyTypeMismatch(oldRoot, newRoot *structs.CARoot) bool {
+	oldIsEd25519 := rootKeyType(oldRoot) == "ed25519"
+	newIsEd25519 := rootKeyType(newRoot) == "ed25519"
+	return oldIsEd25519 != newIsEd25519
+}
+
 // getCAProvider returns the currently active instance of the CA Provider,
 // as well as the active root.
 func (c *CAManager) getCAProvider() (ca.Provider, *structs.CARoot) {
@@ -287,6 +496,20 @@ func (c *CAManager) getCAProvider() (ca.Provider, *structs.CARoot) {
 	}
 }
 
+// incrRootRotated counts a rotation of the active root from oldRoot to
+// newRoot, labeled with the key of each root and what triggered it.
+func (c *CAManager) incrRootRotated(oldRoot, newRoot *structs.CARoot, provider string, trigger rootRotationTrigger) {
+	metrics.IncrCounterWithLabels(metricsKeyConnectCARootRotated, 1, []metrics.Label{
+		{Name: "datacenter", Value: c.serverConf.Datacenter},
+		{Name: "provider", Value: provider},
+		{Name: "old_key_type", Value: oldRoot.PrivateKeyType},
+		{Name: "old_key_bits", Value: strconv.Itoa(oldRoot.PrivateKeyBits)},
+		{Name: "new_key_type", Value: newRoot.PrivateKeyType},
+		{Name: "new_key_bits", Value: strconv.Itoa(newRoot.PrivateKeyBits)},
+		{Name: "trigger", Value: string(trigger)},
+	})
+}
+
 // setCAProvider is being called while holding the stateLock
 // which means it must never take that lock itself or call anything that does.
 func (c *CAManager) setCAProvider(newProvider ca.Provider, root *structs.CARoot) {
@@ -296,7 +519,45 @@ func (c *CAManager) setCAProvider(newProvider ca.Provider, root *structs.CARoot)
 	c.providerLock.Unlock()
 }
 
+// publishActiveRootChanged publishes an event on state.TopicCAActiveRoot
+// recording that the active root changed from oldID to newID. It must be
+// called after the new root has been committed to raft.
+func (c *CAManager) publishActiveRootChanged(oldID, newID string, reason structs.CARootChangeReason) {
+	store := c.delegate.State()
+	idx, _, err := store.CARoots(nil)
+	if err != nil {
+		c.logger.Warn("failed to publish active CA root change", "error", err)
+		return
+	}
+
+	c.logger.Info("active CA root changed", "previous", oldID, "current", newID, "reason", reason)
+	store.EventPublisher().Publish([]stream.Event{{
+		Topic: state.TopicCAActiveRoot,
+		Index: idx,
+		Payload: state.EventPayloadCAActiveRootChanged{
+			OldRootID: oldID,
+			NewRootID: newID,
+			Reason:    reason,
+		},
+	}})
+}
+
 func (c *CAManager) Start(ctx context.Context) {
+	c.stateLock.Lock()
+	c.leaderCtx = ctx
+	c.stateLock.Unlock()
+
+	if c.waitsForManualSecondaryInit() {
+		c.logger.Info("waiting for ConnectCA.InitializeSecondary before initializing the secondary datacenter CA")
+		c.leaderRoutineManager.Start(ctx, secondaryCAManualInitRoutineName, c.secondaryManualInitialization)
+		return
+	}
+	c.initialize(ctx)
+}
+
+// initialize initializes the CA and starts the routines that maintain it,
+// retrying in the background if the first attempt fails.
+func (c *CAManager) initialize(ctx context.Context) {
 	// Attempt to initialize the Connect CA now. This will
 	// happen during leader establishment and it would be great
 	// if the CA was ready to go once that process was finished.
@@ -314,9 +575,71 @@ func (c *CAManager) Start(ctx context.Context) {
 	}
 }
 
+// waitsForManualSecondaryInit returns true when this is a secondary
+// datacenter with ConnectCAManualSecondaryInit set that has never pulled the
+// primary's roots. Once the roots have been replicated, later leaders
+// initialize the CA without waiting.
+func (c *CAManager) waitsForManualSecondaryInit() bool {
+	if !c.serverConf.ConnectEnabled || !c.serverConf.ConnectCAManualSecondaryInit ||
+		c.serverConf.Datacenter == c.serverConf.PrimaryDatacenter {
+		return false
+	}
+	_, root, err := c.delegate.State().CARootActive(nil)
+	return err == nil && root == nil
+}
+
+// secondaryManualInitialization waits for InitializeSecondary to be called
+// and then initializes the CA as Start would have.
+func (c *CAManager) secondaryManualInitialization(ctx context.Context) error {
+	var resultCh chan error
+	select {
+	case <-ctx.Done():
+		return nil
+	case resultCh = <-c.secondaryInitCh:
+	}
+
+	c.logger.Info("initializing the secondary datacenter CA on request")
+	err := c.InitializeCA()
+	resultCh <- err
+	if err != nil {
+		c.logger.Error("Failed to initialize Connect CA", "error", err)
+		c.leaderRoutineManager.Start(ctx, backgroundCAInitializationRoutineName, c.backgroundCAInitialization)
+		return nil
+	}
+	c.startPostInitializeRoutines(ctx)
+	return nil
+}
+
+// InitializeSecondary initializes a secondary datacenter's CA that is waiting
+// for it because ConnectCAManualSecondaryInit is set, and returns the result
+// of that attempt. A failed attempt keeps being retried in the background. It
+// does nothing if the CA has already been initialized.
+func (c *CAManager) InitializeSecondary() error {
+	if c.serverConf.Datacenter == c.serverConf.PrimaryDatacenter {
+		return fmt.Errorf("the primary datacenter's CA is always initialized automatically")
+	}
+
+	resultCh := make(chan error, 1)
+	select {
+	case c.secondaryInitCh <- resultCh:
+		return <-resultCh
+	default:
+	}
+
+	c.stateLock.Lock()
+	state := c.state
+	c.stateLock.Unlock()
+	if state == caStateInitialized {
+		return nil
+	}
+	return fmt.Errorf("the secondary datacenter CA is not waiting to be initialized")
+}
+
 func (c *CAManager) Stop() {
+	c.leaderRoutineManager.Stop(secondaryCAManualInitRoutineName)
 	c.leaderRoutineManager.Stop(secondaryCARootWatchRoutineName)
 	c.leaderRoutineManager.Stop(intermediateCertRenewWatchRoutineName)
+	c.leaderRoutineManager.Stop(rootCertRenewWatchRoutineName)
 	c.leaderRoutineManager.Stop(backgroundCAInitializationRoutineName)
 
 	if provider, _ := c.getCAProvider(); provider != nil {
@@ -326,9 +649,103 @@ func (c *CAManager) Stop() {
 	}
 
 	c.setState(caStateUninitialized, false)
+	c.setInitError(nil)
+	c.stateLock.Lock()
+	c.lastInitAt, c.lastInitDuration = time.Time{}, 0
+	c.secondaries = nil
+	c.leaderCtx = nil
+	c.stateLock.Unlock()
 	c.primaryRoots = structs.IndexedCARoots{}
 	c.actingSecondaryCA = false
 	c.setCAProvider(nil, nil)
+	c.leafCache.flush()
+}
+
+// getLeaderCtx returns the context passed to Start, or a background context if
+// the CA manager hasn't been started.
+func (c *CAManager) getLeaderCtx() context.Context {
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+	if c.leaderCtx == nil {
+		return context.Background()
+	}
+	return c.leaderCtx
+}
+
+func (c *CAManager) setInitError(err error) {
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+	c.initErr = err
+}
+
+// recordProviderInit notes that the provider finished initializing, having
+// started at start.
+func (c *CAManager) recordProviderInit(start time.Time) {
+	now := c.timeNow()
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+	c.lastInitAt = now
+	c.lastInitDuration = now.Sub(start)
+}
+
+// LastProviderInit returns when the provider was last successfully
+// initialized or reconfigured and how long it took, or the zero time if it
+// hasn't been since this server became leader.
+func (c *CAManager) LastProviderInit() (time.Time, time.Duration) {
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+	return c.lastInitAt, c.lastInitDuration
+}
+
+// recordSecondary notes that the secondary datacenter dc was just signed an
+// intermediate by the root with the given ID.
+func (c *CAManager) recordSecondary(dc, rootID string) {
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+	if c.secondaries == nil {
+		c.secondaries = make(map[string]structs.CASecondary)
+	}
+	c.secondaries[dc] = structs.CASecondary{
+		Datacenter: dc,
+		LastSeen:   c.timeNow(),
+		RootID:     rootID,
+	}
+}
+
+// Secondaries returns the secondary datacenters that have requested an
+// intermediate since this server became leader, sorted by datacenter.
+func (c *CAManager) Secondaries() []structs.CASecondary {
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+	result := make([]structs.CASecondary, 0, len(c.secondaries))
+	for _, s := range c.secondaries {
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Datacenter < result[j].Datacenter
+	})
+	return result
+}
+
+// Health reports whether the CA has been initialized and the active provider
+// passes its health check, along with the error explaining why not.
+func (c *CAManager) Health() (structs.CAHealthStatus, error) {
+	c.stateLock.Lock()
+	state, initErr := c.state, c.initErr
+	c.stateLock.Unlock()
+
+	provider, _ := c.getCAProvider()
+	if provider == nil || state == caStateUninitialized || state == caStateInitializing {
+		return structs.CAHealthUninitialized, initErr
+	}
+
+	// Providers that can't check their backend are assumed to be healthy.
+	if checker, ok := provider.(ca.HealthChecker); ok {
+		if err := checker.HealthCheck(); err != nil {
+			return structs.CAHealthDegraded, err
+		}
+	}
+	return structs.CAHealthHealthy, nil
 }
 
 func (c *CAManager) startPostInitializeRoutines(ctx context.Context) {
@@ -338,18 +755,51 @@ func (c *CAManager) startPostInitializeRoutines(ctx context.Context) {
 	}
 
 	c.leaderRoutineManager.Start(ctx, intermediateCertRenewWatchRoutineName, c.intermediateCertRenewalWatch)
+
+	if c.serverConf.Datacenter == c.serverConf.PrimaryDatacenter {
+		c.leaderRoutineManager.Start(ctx, rootCertRenewWatchRoutineName, c.rootCertRenewalWatch)
+	}
 }
 
 func (c *CAManager) backgroundCAInitialization(ctx context.Context) error {
-	retryLoopBackoffAbortOnSuccess(ctx, c.InitializeCA, func(err error) {
-		c.logger.Error("Failed to initialize Connect CA",
+	backoff := defaultRetryBackoff
+	if c.serverConf.Datacenter != c.serverConf.PrimaryDatacenter {
+		backoff = c.secondaryRetryBackoff()
+	}
+	for {
+		var configIndex uint64
+		var misconfiguredErr error
+		retryLoopBackoffHandleSuccess(ctx, backoff, func() error {
+			err := c.InitializeCA()
+			if errors.Is(err, ca.ErrProviderMisconfigured) {
+				// Retrying won't help until the CA configuration changes, so
+				// stop this loop and wait for an update instead.
+				configIndex, _, _ = c.delegate.State().CAConfig(nil)
+				misconfiguredErr = err
+				return nil
+			}
+			return err
+		}, func(err error) {
+			c.logger.Error("Failed to initialize Connect CA",
+				"routine", backgroundCAInitializationRoutineName,
+				"error", err,
+			)
+		}, true)
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if misconfiguredErr == nil {
+			break
+		}
+
+		c.logger.Error("Connect CA provider is misconfigured, waiting for the CA configuration to change",
 			"routine", backgroundCAInitializationRoutineName,
-			"error", err,
+			"error", misconfiguredErr,
 		)
-	})
-
-	if err := ctx.Err(); err != nil {
-		return err
+		if err := c.waitForCAConfigChange(ctx, configIndex, backoff.Max); err != nil {
+			return err
+		}
 	}
 
 	c.logger.Info("Successfully initialized the Connect CA")
@@ -358,6 +808,32 @@ func (c *CAManager) backgroundCAInitialization(ctx context.Context) error {
 	return nil
 }
 
+// waitForCAConfigChange blocks until the CA configuration index moves past
+// index, the timeout elapses or ctx is cancelled. The timeout guards against
+// missing an update that landed before index was read.
+func (c *CAManager) waitForCAConfigChange(ctx context.Context, index uint64, timeout time.Duration) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		ws := memdb.NewWatchSet()
+		ws.Add(c.delegate.State().AbandonCh())
+		current, _, err := c.delegate.State().CAConfig(ws)
+		if err != nil {
+			return err
+		}
+		if current > index {
+			return nil
+		}
+
+		if err := ws.WatchCtx(waitCtx); err != nil {
+			// Only a cancelled parent context is an error; hitting the
+			// timeout just means it's time to try again.
+			return ctx.Err()
+		}
+	}
+}
+
 // InitializeCA sets up the CA provider when gaining leadership, either bootstrapping
 // the CA if this is the primary DC or making a remote RPC for intermediate signing
 // if this is a secondary DC.
@@ -387,8 +863,11 @@ func (c *CAManager) InitializeCA() (reterr error) {
 		} else {
 			c.setState(caStateUninitialized, false)
 		}
+		c.setInitError(reterr)
 	}()
 
+	start := c.timeNow()
+
 	// Initialize the provider based on the current config.
 	conf, err := c.initializeCAConfig()
 	if err != nil {
@@ -402,9 +881,20 @@ func (c *CAManager) InitializeCA() (reterr error) {
 	c.setCAProvider(provider, nil)
 
 	if c.serverConf.PrimaryDatacenter == c.serverConf.Datacenter {
-		return c.primaryInitialize(provider, conf)
+		err = c.primaryInitialize(provider, conf)
+	} else {
+		err = c.secondaryInitialize(provider, conf)
+	}
+	if err != nil {
+		return err
 	}
-	return c.secondaryInitialize(provider, conf)
+
+	// A secondary may defer initialization until the primary is reachable, in
+	// which case the provider has no root yet.
+	if _, root := c.getCAProvider(); root != nil {
+		c.recordProviderInit(start)
+	}
+	return nil
 }
 
 func (c *CAManager) secondaryInitialize(provider ca.Provider, conf *structs.CAConfiguration) error {
@@ -412,6 +902,9 @@ func (c *CAManager) secondaryInitialize(provider ca.Provider, conf *structs.CACo
 	versionOk, foundPrimary := ServersInDCMeetMinimumVersion(c.delegate, c.serverConf.PrimaryDatacenter, minMultiDCConnectVersion)
 	if !foundPrimary {
 		c.logger.Warn("primary datacenter is configured but unreachable - deferring initialization of the secondary datacenter CA")
+		if err := c.secondaryInitializeFromCache(provider); err != nil {
+			c.logger.Warn("unable to sign with the cached intermediate until the primary datacenter is reachable", "error", err)
+		}
 		// return nil because we will initialize the secondary CA later
 		return nil
 	} else if !versionOk {
@@ -428,6 +921,15 @@ func (c *CAManager) secondaryInitialize(provider ca.Provider, conf *structs.CACo
 	}
 	var roots structs.IndexedCARoots
 	if err := c.delegate.forwardDC("ConnectCA.Roots", c.serverConf.PrimaryDatacenter, &args, &roots); err != nil {
+		// Keep signing with the cached intermediate, if there is one, while
+		// the secondary roots watch retries reaching the primary.
+		if cacheErr := c.secondaryInitializeFromCache(provider); cacheErr != nil {
+			return err
+		}
+		c.logger.Warn("failed to fetch roots from the primary datacenter, signing with the cached intermediate until it is reachable", "error", err)
+		return nil
+	}
+	if err := c.checkPrimaryRootKeyStrength(roots); err != nil {
 		return err
 	}
 	if err := c.secondarySetPrimaryRoots(roots); err != nil {
@@ -436,7 +938,7 @@ func (c *CAManager) secondaryInitialize(provider ca.Provider, conf *structs.CACo
 
 	// Configure the CA provider and initialize the intermediate certificate if necessary.
 	if err := c.secondaryInitializeProvider(provider, roots); err != nil {
-		return fmt.Errorf("error configuring provider: %v", err)
+		return fmt.Errorf("error configuring provider: %w", err)
 	}
 	if err := c.secondaryInitializeIntermediateCA(provider, nil); err != nil {
 		return err
@@ -446,6 +948,75 @@ func (c *CAManager) secondaryInitialize(provider ca.Provider, conf *structs.CACo
 	return nil
 }
 
+// secondaryInitializeFromCache configures provider from the roots, config and
+// provider state replicated to this datacenter, so that it can keep signing
+// leaves with the intermediate it last got from the primary while the primary
+// is unreachable. It returns an error, and leaves the provider without a root,
+// if there is no cached intermediate or it is expired or doesn't chain to the
+// active root.
+func (c *CAManager) secondaryInitializeFromCache(provider ca.Provider) error {
+	state := c.delegate.State()
+	_, activeRoot, err := state.CARootActive(nil)
+	if err != nil {
+		return err
+	}
+	_, conf, err := state.CAConfig(nil)
+	if err != nil {
+		return err
+	}
+	if activeRoot == nil || conf == nil || conf.ClusterID == "" {
+		return fmt.Errorf("no CA roots have been replicated from the primary datacenter")
+	}
+
+	pCfg := c.providerConfig(conf, false)
+	if err := c.configureProvider(conf.Provider, provider, pCfg); err != nil {
+		return fmt.Errorf("error configuring provider: %w", err)
+	}
+
+	intermediatePEM, err := provider.ActiveIntermediate()
+	if err != nil {
+		return err
+	}
+	var found bool
+	for _, pem := range activeRoot.IntermediateCerts {
+		if strings.TrimSpace(pem) == strings.TrimSpace(intermediatePEM) {
+			found = true
+			break
+		}
+	}
+	if intermediatePEM == "" || !found {
+		return fmt.Errorf("no intermediate for the active root is cached")
+	}
+	if err := c.checkExpired(intermediatePEM); err != nil {
+		return fmt.Errorf("cached intermediate is not valid: %v", err)
+	}
+	intermediateCert, err := connect.ParseCert(intermediatePEM)
+	if err != nil {
+		return fmt.Errorf("error parsing cached intermediate: %v", err)
+	}
+	rootPool := x509.NewCertPool()
+	if !rootPool.AppendCertsFromPEM([]byte(activeRoot.RootCert)) {
+		return fmt.Errorf("error parsing active root")
+	}
+	_, err = intermediateCert.Verify(x509.VerifyOptions{
+		Roots:       rootPool,
+		CurrentTime: c.timeNow(),
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("cached intermediate does not chain to the active root: %v", err)
+	}
+
+	if err := c.secondarySetCAConfigured(); err != nil {
+		return err
+	}
+	c.setCAProvider(provider, activeRoot.Clone())
+	c.logger.Info("signing with the cached intermediate until the primary datacenter is reachable",
+		"root", activeRoot.ID,
+	)
+	return nil
+}
+
 // createProvider returns a connect CA provider from the given config.
 func (c *CAManager) newProvider(conf *structs.CAConfiguration) (ca.Provider, error) {
 	logger := c.logger.Named(conf.Provider)
@@ -456,6 +1027,10 @@ func (c *CAManager) newProvider(conf *structs.CAConfiguration) (ca.Provider, err
 		return ca.NewVaultProvider(logger), nil
 	case structs.AWSCAProvider:
 		return ca.NewAWSProvider(logger), nil
+	case structs.AzureKeyVaultCAProvider:
+		return ca.NewAzureKeyVaultProvider(logger), nil
+	case structs.GRPCCAProvider:
+		return ca.NewGRPCProvider(logger), nil
 	default:
 		if c.providerShim != nil {
 			return c.providerShim, nil
@@ -467,34 +1042,30 @@ func (c *CAManager) newProvider(conf *structs.CAConfiguration) (ca.Provider, err
 // primaryInitialize runs the initialization logic for a root CA. It should only
 // be called while the state lock is held by setting the state to non-ready.
 func (c *CAManager) primaryInitialize(provider ca.Provider, conf *structs.CAConfiguration) error {
-	pCfg := ca.ProviderConfig{
-		ClusterID:  conf.ClusterID,
-		Datacenter: c.serverConf.Datacenter,
-		IsPrimary:  true,
-		RawConfig:  conf.Config,
-		State:      conf.State,
-	}
-	if err := provider.Configure(pCfg); err != nil {
-		return fmt.Errorf("error configuring provider: %v", err)
+	pCfg := c.providerConfig(conf, true)
+	if err := c.configureProvider(conf.Provider, provider, pCfg); err != nil {
+		return fmt.Errorf("error configuring provider: %w", err)
 	}
 	if err := provider.GenerateRoot(); err != nil {
-		return fmt.Errorf("error generating CA root certificate: %v", err)
+		return fmt.Errorf("error generating CA root certificate: %w", err)
 	}
 
 	// Get the active root cert from the CA
 	rootPEM, err := provider.ActiveRoot()
 	if err != nil {
-		return fmt.Errorf("error getting root cert: %v", err)
+		return fmt.Errorf("error getting root cert: %w", err)
 	}
 	rootCA, err := parseCARoot(rootPEM, conf.Provider, conf.ClusterID)
 	if err != nil {
 		return err
 	}
+	c.warnIfSerialTruncated(rootCA)
+	rootCA.SupportsCrossSigning = ca.Capabilities(provider).CrossSigning
 
 	// Also create the intermediate CA, which is the one that actually signs leaf certs
 	interPEM, err := provider.GenerateIntermediate()
 	if err != nil {
-		return fmt.Errorf("error generating intermediate cert: %v", err)
+		return fmt.Errorf("error generating intermediate cert: %w", err)
 	}
 	intermediateCert, err := connect.ParseCert(interPEM)
 	if err != nil {
@@ -546,6 +1117,14 @@ func (c *CAManager) primaryInitialize(provider ca.Provider, conf *structs.CAConf
 			return fmt.Errorf("stored CA root %q is not the active root (%s)", rootCA.ID, activeRoot.ID)
 		}
 
+		// Roots stored by older versions don't record whether their provider
+		// can cross-sign, so fill it in now that the provider is configured.
+		if activeRoot.SupportsCrossSigning != rootCA.SupportsCrossSigning {
+			if err := c.setActiveRootCrossSigning(rootCA.SupportsCrossSigning); err != nil {
+				return fmt.Errorf("error updating stored CA root: %w", err)
+			}
+		}
+
 		rootCA.IntermediateCerts = activeRoot.IntermediateCerts
 		c.setCAProvider(provider, rootCA)
 
@@ -577,6 +1156,9 @@ func (c *CAManager) primaryInitialize(provider ca.Provider, conf *structs.CAConf
 	}
 
 	c.setCAProvider(provider, rootCA)
+	if activeRoot != nil {
+		c.publishActiveRootChanged(activeRoot.ID, rootCA.ID, structs.CARootChangeFixSigningKey)
+	}
 
 	c.logger.Info("initialized primary datacenter CA with provider", "provider", conf.Provider)
 
@@ -684,6 +1266,13 @@ func (c *CAManager) secondaryInitializeIntermediateCA(provider ca.Provider, conf
 		return err
 	}
 
+	// Note the trust domain in use before persisting so a switch to the
+	// primary's trust domain can be reported as a transition.
+	_, prevConfig, err := state.CAConfig(nil)
+	if err != nil {
+		return err
+	}
+
 	// Determine whether a root update is needed, and persist the roots/config accordingly.
 	var newRoot *structs.CARoot
 	if activeRoot == nil || activeRoot.ID != newActiveRoot.ID || newIntermediate {
@@ -694,6 +1283,13 @@ func (c *CAManager) secondaryInitializeIntermediateCA(provider ca.Provider, conf
 	}
 
 	c.setCAProvider(provider, newActiveRoot)
+	if activeRoot != nil && activeRoot.ID != newActiveRoot.ID {
+		reason := structs.CARootChangeRotation
+		if prevConfig != nil && prevConfig.ClusterID != newActiveRoot.ExternalTrustDomain {
+			reason = structs.CARootChangeSecondaryTransition
+		}
+		c.publishActiveRootChanged(activeRoot.ID, newActiveRoot.ID, reason)
+	}
 	return nil
 }
 
@@ -718,7 +1314,7 @@ func (c *CAManager) persistNewRootAndConfig(provider ca.Provider, newActiveRoot
 		return fmt.Errorf("local CA not initialized yet")
 	}
 	// Exit early if the change is a no-op.
-	if newActiveRoot == nil && config != nil && config.Provider == storedConfig.Provider && reflect.DeepEqual(config.Config, storedConfig.Config) {
+	if newActiveRoot == nil && config != nil && config.SettingsEqual(storedConfig) {
 		return nil
 	}
 
@@ -750,9 +1346,11 @@ func (c *CAManager) persistNewRootAndConfig(provider ca.Provider, newActiveRoot
 	// If there's a new active root, copy the root list and append it, updating
 	// the old root with the time it was rotated out.
 	var newRoots structs.CARoots
+	var prevRoot *structs.CARoot
 	for _, r := range oldRoots {
 		newRoot := *r
 		if newRoot.Active && newActiveRoot != nil {
+			prevRoot = r
 			newRoot.Active = false
 			newRoot.RotatedOutAt = c.timeNow()
 		}
@@ -781,23 +1379,117 @@ func (c *CAManager) persistNewRootAndConfig(provider ca.Provider, newActiveRoot
 	if respOk, ok := resp.(bool); ok && !respOk {
 		return fmt.Errorf("could not atomically update roots and config")
 	}
+	if prevRoot != nil && prevRoot.ID != newActiveRoot.ID {
+		c.recordProviderStateSnapshot(prevRoot, storedConfig)
+	}
 
 	c.logger.Info("updated root certificates from primary datacenter")
 	return nil
 }
 
-func (c *CAManager) UpdateConfiguration(args *structs.CARequest) (reterr error) {
-	// Attempt to update the state first.
-	oldState, err := c.setState(caStateReconfig, true)
+// recordProviderStateSnapshot adds the provider state that was in use with
+// root to the state history, now that root has been rotated out. The rotation
+// is already committed so a failure is only logged.
+func (c *CAManager) recordProviderStateSnapshot(root *structs.CARoot, conf *structs.CAConfiguration) {
+	if root == nil || conf == nil {
+		return
+	}
+
+	resp, err := c.delegate.ApplyCARequest(&structs.CARequest{
+		Op: structs.CAOpAddProviderStateSnapshot,
+		ProviderStateSnapshot: &structs.CAProviderStateSnapshot{
+			RootID:   root.ID,
+			Provider: conf.Provider,
+			State:    conf.State,
+		},
+	})
+	if err == nil {
+		if respErr, ok := resp.(error); ok {
+			err = respErr
+		}
+	}
 	if err != nil {
-		return err
+		c.logger.Warn("failed to record CA provider state history", "root", root.ID, "error", err)
 	}
-	defer func() {
+}
+
+// retainRootKey keeps the private key of a root that was just rotated out in
+// the built-in provider's state, next to the keys of active roots, so that
+// RollbackRoot can make the root active again. It is never stored with the
+// root, since roots are returned by the RPC endpoints and published in stream
+// events. The rotation is already committed so a failure is only logged.
+func (c *CAManager) retainRootKey(root *structs.CARoot, key string) {
+	resp, err := c.delegate.ApplyCARequest(&structs.CARequest{
+		Op: structs.CAOpSetProviderState,
+		ProviderState: &structs.CAConsulProviderState{
+			ID:         ca.RetainedRootKeyStateID(root.ID),
+			PrivateKey: key,
+			RootCert:   root.RootCert,
+		},
+	})
+	if err == nil {
+		if respErr, ok := resp.(error); ok {
+			err = respErr
+		}
+	}
+	if err != nil {
+		c.logger.Warn("failed to retain the private key of the rotated out CA root, "+
+			"it won't be possible to roll back to it", "root", root.ID, "error", err)
+	}
+}
+
+// releaseRootKey deletes the private key retained for the root with the given
+// ID, if there is one.
+func (c *CAManager) releaseRootKey(rootID string) error {
+	_, retained, err := c.delegate.State().CAProviderState(ca.RetainedRootKeyStateID(rootID))
+	if err != nil || retained == nil {
+		return err
+	}
+	resp, err := c.delegate.ApplyCARequest(&structs.CARequest{
+		Op:            structs.CAOpDeleteProviderState,
+		ProviderState: &structs.CAConsulProviderState{ID: retained.ID},
+	})
+	if err != nil {
+		return err
+	}
+	if respErr, ok := resp.(error); ok {
+		return respErr
+	}
+	return nil
+}
+
+// rootRotationTrigger is what caused a root rotation in the primary
+// datacenter, reported as the trigger label of the root rotated metric.
+type rootRotationTrigger string
+
+const (
+	rootRotationTriggerConfig    rootRotationTrigger = "config-update"
+	rootRotationTriggerManual    rootRotationTrigger = "rotate-root"
+	rootRotationTriggerAutoRenew rootRotationTrigger = "auto-renew"
+	rootRotationTriggerRollback  rootRotationTrigger = "rollback"
+)
+
+func (c *CAManager) UpdateConfiguration(args *structs.CARequest) error {
+	return c.updateConfiguration(args, rootRotationTriggerConfig)
+}
+
+// updateConfiguration is UpdateConfiguration with the trigger to report if
+// the update rotates the root.
+func (c *CAManager) updateConfiguration(args *structs.CARequest, trigger rootRotationTrigger) (reterr error) {
+	// Attempt to update the state first.
+	oldState, err := c.setState(caStateReconfig, true)
+	if err != nil {
+		return err
+	}
+	defer func() {
 		// Using named return values in deferred funcs isnt too common in our code
 		// but it is first class Go functionality. The error erturned from the
 		// main func will be available by its given name within deferred functions.
 		// See: https://blog.golang.org/defer-panic-and-recover
 		if reterr == nil {
+			// Cached leaves may have been signed with a TTL or SANs the
+			// new config no longer allows, even if the root didn't change.
+			c.leafCache.flush()
 			c.setState(caStateInitialized, false)
 		} else {
 			c.setState(oldState, false)
@@ -824,9 +1516,16 @@ func (c *CAManager) UpdateConfiguration(args *structs.CARequest) (reterr error)
 		return ErrStateReadOnly
 	}
 
+	if err := args.Config.Validate(); err != nil {
+		return err
+	}
+	if err := c.checkAllowedKeyType(args.Config); err != nil {
+		return err
+	}
+
 	// Don't allow users to change the ClusterID.
 	args.Config.ClusterID = config.ClusterID
-	if args.Config.Provider == config.Provider && reflect.DeepEqual(args.Config.Config, config.Config) {
+	if args.Config.SettingsEqual(config) {
 		return nil
 	}
 
@@ -844,20 +1543,12 @@ func (c *CAManager) UpdateConfiguration(args *structs.CARequest) (reterr error)
 	// and get the current active root CA. This acts as a good validation
 	// of the config and makes sure the provider is functioning correctly
 	// before we commit any changes to Raft.
-	newProvider, err := c.newProvider(args.Config)
+	start := c.timeNow()
+	// This endpoint can be called in a secondary DC too so set this correctly.
+	pCfg := c.providerConfig(args.Config, c.serverConf.Datacenter == c.serverConf.PrimaryDatacenter)
+	newProvider, err := c.configureNewProvider(args.Config, pCfg)
 	if err != nil {
-		return fmt.Errorf("could not initialize provider: %v", err)
-	}
-	pCfg := ca.ProviderConfig{
-		ClusterID:  args.Config.ClusterID,
-		Datacenter: c.serverConf.Datacenter,
-		// This endpoint can be called in a secondary DC too so set this correctly.
-		IsPrimary: c.serverConf.Datacenter == c.serverConf.PrimaryDatacenter,
-		RawConfig: args.Config.Config,
-		State:     args.Config.State,
-	}
-	if err := newProvider.Configure(pCfg); err != nil {
-		return fmt.Errorf("error configuring provider: %v", err)
+		return err
 	}
 
 	cleanupNewProvider := func() {
@@ -866,23 +1557,611 @@ func (c *CAManager) UpdateConfiguration(args *structs.CARequest) (reterr error)
 		}
 	}
 
+	if err := checkProviderCapabilities(args.Config, newProvider); err != nil {
+		cleanupNewProvider()
+		return err
+	}
+
 	// If this is a secondary, just check if the intermediate needs to be regenerated.
 	if c.serverConf.Datacenter != c.serverConf.PrimaryDatacenter {
+		_, root, err := state.CARootActive(nil)
+		if err != nil {
+			cleanupNewProvider()
+			return err
+		}
+		if err := c.checkIntermediateCertTTL(args.Config, root); err != nil {
+			cleanupNewProvider()
+			return err
+		}
 		if err := c.secondaryInitializeIntermediateCA(newProvider, args.Config); err != nil {
 			cleanupNewProvider()
 			return fmt.Errorf("Error updating secondary datacenter CA config: %v", err)
 		}
 		c.logger.Info("Secondary CA provider config updated")
+		c.recordProviderInit(start)
 		return nil
 	}
-	if err := c.primaryUpdateRootCA(newProvider, args, config); err != nil {
+	if err := c.primaryUpdateRootCA(newProvider, args, config, trigger); err != nil {
 		cleanupNewProvider()
 		return err
 	}
+	c.recordProviderInit(start)
+	return nil
+}
+
+// caConfigureRetryBackoff is how long configureNewProvider waits between
+// attempts to configure a provider.
+var caConfigureRetryBackoff = retryBackoff{
+	Min:        time.Second,
+	Max:        10 * time.Second,
+	Multiplier: 2,
+}
+
+// configureNewProvider creates the provider described by conf and configures
+// it with pCfg. It makes up to ConnectCAConfigureAttempts attempts, backing off
+// in between, so a transient backend failure doesn't reject a good
+// configuration. Errors classified as ca.ErrProviderMisconfigured are returned
+// right away since retrying won't fix them, and losing leadership while
+// backing off returns the leader context's error.
+func (c *CAManager) configureNewProvider(conf *structs.CAConfiguration, pCfg ca.ProviderConfig) (ca.Provider, error) {
+	attempts := c.serverConf.ConnectCAConfigureAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	ctx := c.getLeaderCtx()
+	for attempt := 1; ; attempt++ {
+		provider, err := c.newProvider(conf)
+		if err != nil {
+			return nil, fmt.Errorf("could not initialize provider: %v", err)
+		}
+		err = c.configureProvider(conf.Provider, provider, pCfg)
+		if err == nil {
+			return provider, nil
+		}
+		if needsStop, ok := provider.(ca.NeedsStop); ok {
+			needsStop.Stop()
+		}
+		if attempt >= attempts || errors.Is(err, ca.ErrProviderMisconfigured) {
+			return nil, fmt.Errorf("error configuring provider: %v", err)
+		}
+
+		wait := caConfigureRetryBackoff.wait(uint(attempt))
+		c.logger.Warn("failed to configure CA provider, retrying",
+			"provider", conf.Provider,
+			"attempt", attempt,
+			"retry_in", wait,
+			"error", err,
+		)
+		// The caller holds caStateReconfig, so stop waiting as soon as
+		// leadership is lost rather than blocking the next leader's CA setup.
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// providerConfig returns the config to configure a provider for conf with.
+func (c *CAManager) providerConfig(conf *structs.CAConfiguration, isPrimary bool) ca.ProviderConfig {
+	return ca.ProviderConfig{
+		ClusterID:                    conf.ClusterID,
+		Datacenter:                   c.serverConf.Datacenter,
+		IsPrimary:                    isPrimary,
+		RawConfig:                    conf.Config,
+		State:                        conf.State,
+		OCSPResponderURL:             conf.OCSPResponderURL,
+		IntermediateCertCommonName:   conf.IntermediateCertCommonName,
+		IntermediateCertOrganization: conf.IntermediateCertOrganization,
+		SignatureAlgorithm:           conf.SignatureAlgorithm,
+		MinLeafCertTTL:               c.serverConf.ConnectMinLeafCertTTL,
+	}
+}
+
+// configureProvider configures provider with pCfg. Providers must treat
+// pCfg.State as read-only since it is shared with the CA configuration stored
+// in Raft, so a provider that modifies it is reported with a warning and the
+// provider state mutated metric.
+func (c *CAManager) configureProvider(providerName string, provider ca.Provider, pCfg ca.ProviderConfig) error {
+	before := providerStateChecksum(pCfg.State)
+	err := provider.Configure(pCfg)
+	if providerStateChecksum(pCfg.State) != before {
+		c.logger.Warn("CA provider modified the provider state it was configured with, which must be treated as read-only",
+			"provider", providerName,
+		)
+		metrics.IncrCounterWithLabels(metricsKeyConnectCAProviderStateMutated, 1, []metrics.Label{
+			{Name: "datacenter", Value: c.serverConf.Datacenter},
+			{Name: "provider", Value: providerName},
+		})
+	}
+	return err
+}
+
+// providerStateChecksum returns a checksum of the provider state that
+// doesn't depend on map ordering.
+func providerStateChecksum(state map[string]string) string {
+	keys := make([]string, 0, len(state))
+	for k := range state {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%d:%s%d:%s", len(k), k, len(state[k]), state[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// checkAllowedKeyType returns an error if the private key type that conf
+// would generate keys with is not permitted by ConnectAllowedCAKeyTypes.
+func (c *CAManager) checkAllowedKeyType(conf *structs.CAConfiguration) error {
+	if len(c.serverConf.ConnectAllowedCAKeyTypes) == 0 {
+		return nil
+	}
+	common, err := conf.GetCommonConfig()
+	if err != nil {
+		return err
+	}
+	// Providers fall back to the default key type when none is configured.
+	keyType, keyBits := connect.DefaultPrivateKeyType, connect.DefaultPrivateKeyBits
+	if common.PrivateKeyType != "" {
+		keyType = common.PrivateKeyType
+	}
+	if common.PrivateKeyBits != 0 {
+		keyBits = common.PrivateKeyBits
+	}
+	return structs.CheckCAKeyTypeAllowed(c.serverConf.ConnectAllowedCAKeyTypes, keyType, keyBits)
+}
+
+// checkProviderCapabilities returns an error if conf asks for something that
+// provider, already configured with conf, can't do.
+func checkProviderCapabilities(conf *structs.CAConfiguration, provider ca.Provider) error {
+	caps := ca.Capabilities(provider)
+	if conf.AutoRenewRoot && caps.ExternalRoot {
+		return fmt.Errorf("automatic root renewal is not possible with the %q CA provider "+
+			"because Consul does not hold the root's private key", conf.Provider)
+	}
+	common, err := conf.GetCommonConfig()
+	if err != nil {
+		return err
+	}
+	if common.PrivateKeyType != "" && !caps.SupportsKeyType(common.PrivateKeyType) {
+		return fmt.Errorf("private key type %q is not supported by the %q CA provider",
+			common.PrivateKeyType, conf.Provider)
+	}
+	return nil
+}
+
+// intermediateCertTTLGrace is how far an intermediate may outlive its root
+// before checkIntermediateCertTTL rejects the config. Validate allows
+// RootCertTTL to equal IntermediateCertTTL, and a root generated with it
+// expires moments before an intermediate signed right after it would.
+const intermediateCertTTLGrace = time.Minute
+
+// checkIntermediateCertTTL returns an error if an intermediate signed now
+// with conf's IntermediateCertTTL would outlive root, which would leave
+// intermediates and their leaves valid past the expiry of their issuer. A nil
+// root, an unset TTL or SkipValidate skips the check.
+func (c *CAManager) checkIntermediateCertTTL(conf *structs.CAConfiguration, root *structs.CARoot) error {
+	if root == nil {
+		return nil
+	}
+	common, err := conf.GetCommonConfig()
+	if err != nil {
+		return err
+	}
+	if common.SkipValidate || common.IntermediateCertTTL <= 0 {
+		return nil
+	}
+	now := c.timeNow()
+	if expiry := now.Add(common.IntermediateCertTTL); expiry.After(root.NotAfter.Add(intermediateCertTTLGrace)) {
+		return fmt.Errorf("IntermediateCertTTL of %s exceeds the remaining lifetime of the active root CA, "+
+			"which expires at %s (in %s)", common.IntermediateCertTTL,
+			root.NotAfter.UTC().Format(time.RFC3339), root.NotAfter.Sub(now).Round(time.Second))
+	}
 	return nil
 }
 
-func (c *CAManager) primaryUpdateRootCA(newProvider ca.Provider, args *structs.CARequest, config *structs.CAConfiguration) error {
+// checkPrimaryRootKeyStrength returns an error if the active root in roots,
+// fetched from the primary datacenter, uses a key weaker than this
+// datacenter's ConnectMinPrimaryRootKeyTypes allow.
+func (c *CAManager) checkPrimaryRootKeyStrength(roots structs.IndexedCARoots) error {
+	if len(c.serverConf.ConnectMinPrimaryRootKeyTypes) == 0 {
+		return nil
+	}
+	var active *structs.CARoot
+	for _, r := range roots.Roots {
+		if r.ID == roots.ActiveRootID {
+			active = r
+			break
+		}
+	}
+	if active == nil {
+		return fmt.Errorf("primary datacenter has no active CA root")
+	}
+
+	keyType, keyBits := active.PrivateKeyType, active.PrivateKeyBits
+	if keyType == "" || keyBits == 0 {
+		// Roots persisted before the key info was recorded.
+		cert, err := connect.ParseCert(active.RootCert)
+		if err != nil {
+			return fmt.Errorf("error parsing primary datacenter's CA root: %v", err)
+		}
+		if keyType, keyBits, err = connect.KeyInfoFromCert(cert); err != nil {
+			return fmt.Errorf("error extracting primary datacenter's CA root key info: %v", err)
+		}
+	}
+	if err := structs.CheckCAKeyStrength(c.serverConf.ConnectMinPrimaryRootKeyTypes, keyType, keyBits); err != nil {
+		return fmt.Errorf("refusing to use the primary datacenter's CA root %s: %v", active.ID, err)
+	}
+	return nil
+}
+
+// DryRunConfiguration validates the CA configuration in args and initializes
+// the provider it describes to report the root that would become active,
+// without persisting anything to Raft or rotating the active root. Providers
+// backed by external systems may still create resources there, such as PKI
+// mounts in Vault, in the same way UpdateConfiguration would.
+func (c *CAManager) DryRunConfiguration(args *structs.CARequest) (*structs.CADryRunResult, error) {
+	state := c.delegate.State()
+	_, config, err := state.CAConfig(nil)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return nil, fmt.Errorf("CA has not finished initializing")
+	}
+
+	// Work on a copy so the caller's request is left untouched.
+	newConf := *args.Config
+	if len(newConf.State) > 0 && !reflect.DeepEqual(newConf.State, config.State) {
+		return nil, ErrStateReadOnly
+	}
+	if err := newConf.Validate(); err != nil {
+		return nil, err
+	}
+	if err := c.checkAllowedKeyType(&newConf); err != nil {
+		return nil, err
+	}
+	newConf.ClusterID = config.ClusterID
+	if newConf.Provider == config.Provider {
+		newConf.State = config.State
+	}
+
+	var newProvider ca.Provider
+	if newConf.Provider == structs.ConsulCAProvider {
+		delegate, err := newCADryRunDelegate(state)
+		if err != nil {
+			return nil, err
+		}
+		newProvider = ca.NewConsulProvider(delegate, c.logger.Named(newConf.Provider))
+	} else {
+		newProvider, err = c.newProvider(&newConf)
+		if err != nil {
+			return nil, fmt.Errorf("could not initialize provider: %v", err)
+		}
+	}
+	if needsStop, ok := newProvider.(ca.NeedsStop); ok {
+		defer needsStop.Stop()
+	}
+
+	isPrimary := c.serverConf.Datacenter == c.serverConf.PrimaryDatacenter
+	pCfg := c.providerConfig(&newConf, isPrimary)
+	if err := c.configureProvider(newConf.Provider, newProvider, pCfg); err != nil {
+		return nil, fmt.Errorf("error configuring provider: %v", err)
+	}
+	if err := checkProviderCapabilities(&newConf, newProvider); err != nil {
+		return nil, err
+	}
+
+	// Secondaries get their root from the primary so there is nothing more
+	// to report once the provider is configured.
+	if !isPrimary {
+		_, root, err := state.CARootActive(nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.checkIntermediateCertTTL(&newConf, root); err != nil {
+			return nil, err
+		}
+		return &structs.CADryRunResult{}, nil
+	}
+
+	if err := newProvider.GenerateRoot(); err != nil {
+		return nil, fmt.Errorf("error generating CA root certificate: %v", err)
+	}
+	newRootPEM, err := newProvider.ActiveRoot()
+	if err != nil {
+		return nil, err
+	}
+	newActiveRoot, err := parseCARoot(newRootPEM, newConf.Provider, newConf.ClusterID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.checkIntermediateCertTTL(&newConf, newActiveRoot); err != nil {
+		return nil, err
+	}
+	intermediate, err := newProvider.ActiveIntermediate()
+	if err != nil {
+		return nil, err
+	}
+	if intermediate != "" && intermediate != newRootPEM {
+		newActiveRoot.IntermediateCerts = []string{intermediate}
+	}
+
+	result := &structs.CADryRunResult{Root: newActiveRoot}
+
+	_, root, err := state.CARootActive(nil)
+	if err != nil {
+		return nil, err
+	}
+	if root != nil && root.ID == newActiveRoot.ID {
+		// The root won't change so it keeps its current intermediates.
+		result.Root = root
+		return result, nil
+	}
+	result.RootChanged = true
+
+	if root != nil {
+		oldProvider, _ := c.getCAProvider()
+		if oldProvider == nil {
+			return nil, fmt.Errorf("internal error: CA provider is nil")
+		}
+		canXSign := ca.Capabilities(oldProvider).CrossSigning
+		result.CanCrossSign = canXSign && !crossSignKeyTypeMismatch(root, newActiveRoot)
+	}
+	return result, nil
+}
+
+// RotateRoot rotates the active root by reconfiguring the current provider
+// with a newly generated private key. It returns the ID of the active root once
+// the rotation completes. If a reconfiguration is already in progress no new
+// rotation is started and the ID of the currently active root is returned.
+func (c *CAManager) RotateRoot(args *structs.CARotateRootRequest) (string, error) {
+	return c.rotateRoot(args, rootRotationTriggerManual)
+}
+
+func (c *CAManager) rotateRoot(args *structs.CARotateRootRequest, trigger rootRotationTrigger) (string, error) {
+	state := c.delegate.State()
+	_, config, err := state.CAConfig(nil)
+	if err != nil {
+		return "", err
+	}
+	if config == nil {
+		return "", fmt.Errorf("CA has not finished initializing")
+	}
+
+	// A root can only be generated from a key we hand the provider. Providers
+	// with an external root manage their keys elsewhere so a rotation has to
+	// be triggered by changing their configuration.
+	provider, _ := c.getCAProvider()
+	if provider == nil {
+		return "", fmt.Errorf("internal error: CA provider is nil")
+	}
+	if ca.Capabilities(provider).ExternalRoot {
+		if config.Provider == structs.ConsulCAProvider {
+			return "", fmt.Errorf("root rotation is not possible when ExternalRootCert is set because " +
+				"Consul does not hold the root's private key, sign a new intermediate with the " +
+				"offline root and update IntermediateCert and PrivateKey instead")
+		}
+		return "", fmt.Errorf("root rotation is not supported by the %q CA provider, "+
+			"update the CA configuration to rotate the root instead", config.Provider)
+	}
+
+	common, err := config.GetCommonConfig()
+	if err != nil {
+		return "", err
+	}
+	keyType, keyBits := common.PrivateKeyType, common.PrivateKeyBits
+	if keyType == "" {
+		keyType, keyBits = connect.DefaultPrivateKeyType, connect.DefaultPrivateKeyBits
+	}
+	_, newKey, err := connect.GeneratePrivateKeyWithType(keyType, keyBits)
+	if err != nil {
+		return "", err
+	}
+
+	err = c.rotateRootWithKey(args, config, map[string]interface{}{
+		"PrivateKey": newKey,
+		"RootCert":   "",
+	}, trigger)
+	var errCaState *caStateError
+	switch {
+	case errors.As(err, &errCaState) && errCaState.Current == caStateReconfig:
+		c.logger.Info("CA reconfiguration already in progress, skipping root rotation")
+	case err != nil:
+		return "", err
+	}
+
+	_, activeRoot, err := state.CARootActive(nil)
+	if err != nil {
+		return "", err
+	}
+	if activeRoot == nil {
+		return "", fmt.Errorf("no active CA root found after rotation")
+	}
+	return activeRoot.ID, nil
+}
+
+// rotateRootWithKey reconfigures the built-in provider with its current
+// config, except for the key material in keyConfig, which starts a rotation.
+func (c *CAManager) rotateRootWithKey(args *structs.CARotateRootRequest, config *structs.CAConfiguration,
+	keyConfig map[string]interface{}, trigger rootRotationTrigger) error {
+	newConf := config.Clone()
+	for k, v := range keyConfig {
+		newConf.Config[k] = v
+	}
+	newConf.ForceWithoutCrossSigning = args.ForceWithoutCrossSigning
+
+	req := &structs.CARequest{
+		Datacenter:   args.Datacenter,
+		Config:       newConf,
+		WriteRequest: args.WriteRequest,
+	}
+	return c.updateConfiguration(req, trigger)
+}
+
+// RollbackRoot makes the most recently rotated out root active again, such as
+// after an accidental rotation. This is only possible with the built-in
+// provider, and only while the rotated out root is still retained along with
+// its private key, which the built-in provider's state keeps from the
+// rotation until the root is pruned.
+// The rollback is a regular rotation, so the active root cross-signs the
+// previous one unless ForceWithoutCrossSigning is set. It returns the ID of
+// the root that is active again.
+func (c *CAManager) RollbackRoot(args *structs.CARotateRootRequest) (string, error) {
+	state := c.delegate.State()
+	_, config, err := state.CAConfig(nil)
+	if err != nil {
+		return "", err
+	}
+	if config == nil {
+		return "", fmt.Errorf("CA has not finished initializing")
+	}
+	provider, _ := c.getCAProvider()
+	if provider == nil {
+		return "", fmt.Errorf("internal error: CA provider is nil")
+	}
+	if ca.Capabilities(provider).ExternalRoot {
+		if config.Provider == structs.ConsulCAProvider {
+			return "", fmt.Errorf("root rollback is not possible when ExternalRootCert is set")
+		}
+		return "", fmt.Errorf("root rollback is not supported by the %q CA provider", config.Provider)
+	}
+	consulConf, err := ca.ParseConsulCAConfig(config.Config)
+	if err != nil {
+		return "", err
+	}
+
+	_, roots, err := state.CARoots(nil)
+	if err != nil {
+		return "", err
+	}
+	previous := previousCARoot(roots)
+	if previous == nil {
+		return "", fmt.Errorf("there is no previous CA root to roll back to")
+	}
+	_, retained, err := state.CAProviderState(ca.RetainedRootKeyStateID(previous.ID))
+	if err != nil {
+		return "", err
+	}
+	var previousKey string
+	if retained != nil {
+		previousKey = retained.PrivateKey
+	}
+	if err := c.checkRollbackRoot(previous, previousKey, consulConf.LeafCertTTL); err != nil {
+		return "", fmt.Errorf("cannot roll back to CA root %q: %w", previous.ID, err)
+	}
+
+	keyConfig := map[string]interface{}{
+		"PrivateKey": previousKey,
+		"RootCert":   previous.RootCert,
+		"CABundle":   "",
+	}
+	if previous.PrivateKeyType != "" {
+		keyConfig["PrivateKeyType"] = previous.PrivateKeyType
+		keyConfig["PrivateKeyBits"] = previous.PrivateKeyBits
+	}
+	err = c.rotateRootWithKey(args, config, keyConfig, rootRotationTriggerRollback)
+	if err != nil {
+		return "", err
+	}
+
+	_, activeRoot, err := state.CARootActive(nil)
+	if err != nil {
+		return "", err
+	}
+	if activeRoot == nil || activeRoot.ID != previous.ID {
+		return "", fmt.Errorf("CA root %q is not active after the rollback", previous.ID)
+	}
+	c.logger.Info("CA rolled back to the previous root", "root", previous.ID)
+	return activeRoot.ID, nil
+}
+
+// previousCARoot returns the inactive root that was rotated out most
+// recently, or nil if there is none.
+func previousCARoot(roots structs.CARoots) *structs.CARoot {
+	var previous *structs.CARoot
+	for _, r := range roots {
+		if r.Active {
+			continue
+		}
+		if previous == nil || !r.RotatedOutAt.Before(previous.RotatedOutAt) {
+			previous = r
+		}
+	}
+	return previous
+}
+
+// checkRollbackRoot checks that root can safely be made active again: its
+// retained private key must be set and match its certificate, and it must stay
+// valid for longer than the leaf certs it will sign.
+func (c *CAManager) checkRollbackRoot(root *structs.CARoot, key string, leafCertTTL time.Duration) error {
+	if key == "" {
+		return fmt.Errorf("its private key was not retained when it was rotated out")
+	}
+
+	cert, err := connect.ParseCert(root.RootCert)
+	if err != nil {
+		return fmt.Errorf("error parsing root certificate: %v", err)
+	}
+	signer, err := connect.ParseSigner(key)
+	if err != nil {
+		return fmt.Errorf("error parsing private key: %v", err)
+	}
+	keyBytes, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return err
+	}
+	certBytes, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(keyBytes, certBytes) {
+		return fmt.Errorf("the retained private key does not match the root certificate")
+	}
+
+	if !c.timeNow().Add(leafCertTTL).Before(cert.NotAfter) {
+		return fmt.Errorf("it expires at %s, before a leaf cert signed now would",
+			cert.NotAfter.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// setActiveRootCrossSigning records in the state store whether the provider
+// signing with the active root is able to cross-sign. The other roots are
+// stored unchanged.
+func (c *CAManager) setActiveRootCrossSigning(supported bool) error {
+	idx, roots, err := c.delegate.State().CARoots(nil)
+	if err != nil {
+		return err
+	}
+
+	newRoots := make(structs.CARoots, 0, len(roots))
+	for _, r := range roots {
+		r = r.Clone()
+		if r.Active {
+			r.SupportsCrossSigning = supported
+		}
+		newRoots = append(newRoots, r)
+	}
+
+	resp, err := c.delegate.ApplyCARequest(&structs.CARequest{
+		Op:    structs.CAOpSetRoots,
+		Index: idx,
+		Roots: newRoots,
+	})
+	if err != nil {
+		return err
+	}
+	if respErr, ok := resp.(error); ok {
+		return respErr
+	}
+	return nil
+}
+
+func (c *CAManager) primaryUpdateRootCA(newProvider ca.Provider, args *structs.CARequest, config *structs.CAConfiguration, trigger rootRotationTrigger) error {
 	if err := newProvider.GenerateRoot(); err != nil {
 		return fmt.Errorf("error generating CA root certificate: %v", err)
 	}
@@ -896,6 +2175,11 @@ func (c *CAManager) primaryUpdateRootCA(newProvider ca.Provider, args *structs.C
 	if err != nil {
 		return err
 	}
+	if err := c.checkIntermediateCertTTL(args.Config, newActiveRoot); err != nil {
+		return err
+	}
+	c.warnIfSerialTruncated(newActiveRoot)
+	newActiveRoot.SupportsCrossSigning = ca.Capabilities(newProvider).CrossSigning
 
 	// See if the provider needs to persist any state along with the config
 	pState, err := newProvider.State()
@@ -923,6 +2207,11 @@ func (c *CAManager) primaryUpdateRootCA(newProvider ca.Provider, args *structs.C
 		if respErr, ok := resp.(error); ok {
 			return respErr
 		}
+		if root.SupportsCrossSigning != newActiveRoot.SupportsCrossSigning {
+			if err := c.setActiveRootCrossSigning(newActiveRoot.SupportsCrossSigning); err != nil {
+				return fmt.Errorf("error updating stored CA root: %w", err)
+			}
+		}
 
 		// If the config has been committed, update the local provider instance
 		c.setCAProvider(newProvider, newActiveRoot)
@@ -937,6 +2226,8 @@ func (c *CAManager) primaryUpdateRootCA(newProvider ca.Provider, args *structs.C
 		return fmt.Errorf("internal error: CA provider is nil")
 	}
 
+	var crossSigned bool
+
 	// We only even think about cross signing if the current provider has a root cert
 	// In some cases such as having a bad CA configuration during startup the provider
 	// may not have been able to generate a cert. We then want to be able to prevent
@@ -959,9 +2250,19 @@ func (c *CAManager) primaryUpdateRootCA(newProvider ca.Provider, args *structs.C
 
 		// First up, check that the current provider actually supports
 		// cross-signing.
-		canXSign, err := oldProvider.SupportsCrossSigning()
-		if err != nil {
-			return fmt.Errorf("CA provider error: %s", err)
+		canXSign := ca.Capabilities(oldProvider).CrossSigning
+
+		// Cross-signing between Ed25519 and RSA/EC roots isn't supported by
+		// all TLS implementations in use by proxies, so treat it the same as a
+		// provider that can't cross-sign.
+		if canXSign && crossSignKeyTypeMismatch(root, newActiveRoot) {
+			if !args.Config.ForceWithoutCrossSigning {
+				return fmt.Errorf("Cannot cross-sign a %q root with the current %q root. "+
+					"You can try again with ForceWithoutCrossSigningSet but this may cause "+
+					"disruption - see documentation for more.",
+					newActiveRoot.PrivateKeyType, rootKeyType(root))
+			}
+			canXSign = false
 		}
 		if !canXSign && !args.Config.ForceWithoutCrossSigning {
 			return errors.New("The current CA Provider does not support cross-signing. " +
@@ -984,6 +2285,7 @@ func (c *CAManager) primaryUpdateRootCA(newProvider ca.Provider, args *structs.C
 			// Add the cross signed cert to the new CA's intermediates (to be attached
 			// to leaf certs).
 			newActiveRoot.IntermediateCerts = []string{xcCert}
+			crossSigned = true
 		}
 	}
 
@@ -995,6 +2297,25 @@ func (c *CAManager) primaryUpdateRootCA(newProvider ca.Provider, args *structs.C
 		newActiveRoot.IntermediateCerts = append(newActiveRoot.IntermediateCerts, intermediate)
 	}
 
+	if crossSigned {
+		if err := c.verifyCrossSignedRoot(newProvider, root, newActiveRoot, args.Config); err != nil {
+			return fmt.Errorf("error verifying cross-signed root: %v", err)
+		}
+	}
+
+	// Keep the private key of a root that Consul holds once it is rotated
+	// out, so that RollbackRoot can make it active again until the root is
+	// pruned.
+	var oldRootKey string
+	if exporter, ok := oldProvider.(ca.RootKeyExporter); ok && root != nil {
+		oldRootKey, err = exporter.RootPrivateKey()
+		if err != nil {
+			c.logger.Warn("failed to retain the private key of the rotated out CA root, "+
+				"it won't be possible to roll back to it", "error", err)
+			oldRootKey = ""
+		}
+	}
+
 	// Update the roots and CA config in the state store at the same time
 	idx, roots, err := state.CARoots(nil)
 	if err != nil {
@@ -1003,6 +2324,11 @@ func (c *CAManager) primaryUpdateRootCA(newProvider ca.Provider, args *structs.C
 
 	var newRoots structs.CARoots
 	for _, r := range roots {
+		// A root that is made active again by a rollback replaces its
+		// inactive entry.
+		if r.ID == newActiveRoot.ID {
+			continue
+		}
 		newRoot := *r
 		if newRoot.Active {
 			newRoot.Active = false
@@ -1027,9 +2353,27 @@ func (c *CAManager) primaryUpdateRootCA(newProvider ca.Provider, args *structs.C
 		return fmt.Errorf("could not atomically update roots and config")
 	}
 
+	c.recordProviderStateSnapshot(root, config)
+	if oldRootKey != "" {
+		c.retainRootKey(root, oldRootKey)
+	}
+	// The new provider holds the key of a root that a rollback made active
+	// again.
+	if err := c.releaseRootKey(newActiveRoot.ID); err != nil {
+		c.logger.Warn("failed to delete the retained private key of the active CA root", "root", newActiveRoot.ID, "error", err)
+	}
+
 	// If the config has been committed, update the local provider instance
 	// and call teardown on the old provider
 	c.setCAProvider(newProvider, newActiveRoot)
+	var oldRootID string
+	if root != nil {
+		oldRootID = root.ID
+	}
+	c.publishActiveRootChanged(oldRootID, newActiveRoot.ID, structs.CARootChangeRotation)
+	if root != nil {
+		c.incrRootRotated(root, newActiveRoot, args.Config.Provider, trigger)
+	}
 
 	if err := oldProvider.Cleanup(args.Config.Provider != config.Provider, args.Config.Config); err != nil {
 		c.logger.Warn("failed to clean up old provider", "provider", config.Provider, "error", err)
@@ -1040,6 +2384,79 @@ func (c *CAManager) primaryUpdateRootCA(newProvider ca.Provider, args *structs.C
 	return nil
 }
 
+// verifyCrossSignedRoot signs a throwaway leaf cert with the new provider and
+// checks that it chains through the new root's intermediates to the old root,
+// as it must for clients that haven't seen the new root yet. This catches a
+// broken cross-signed cert before the rotation is committed.
+func (c *CAManager) verifyCrossSignedRoot(provider ca.Provider, oldRoot, newRoot *structs.CARoot, config *structs.CAConfiguration) error {
+	signer, _, err := connect.GeneratePrivateKey()
+	if err != nil {
+		return err
+	}
+	id := &connect.SpiffeIDService{
+		Host:       connect.SpiffeIDSigningForCluster(config).Host(),
+		Namespace:  structs.IntentionDefaultNamespace,
+		Datacenter: c.serverConf.Datacenter,
+		Service:    "consul-ca-rotation-check",
+	}
+	csrPEM, err := connect.CreateCSR(id, signer, nil, nil)
+	if err != nil {
+		return err
+	}
+	csr, err := connect.ParseCSR(csrPEM)
+	if err != nil {
+		return err
+	}
+	leafPEM, err := provider.Sign(csr)
+	if err != nil {
+		return fmt.Errorf("error signing test leaf cert: %v", err)
+	}
+	leaf, err := connect.ParseCert(leafPEM)
+	if err != nil {
+		return err
+	}
+
+	oldRootCert, err := connect.ParseCert(oldRoot.RootCert)
+	if err != nil {
+		return fmt.Errorf("error parsing old root %s: %v", oldRoot.ID, err)
+	}
+	intermediates := make([]*x509.Certificate, 0, len(newRoot.IntermediateCerts))
+	for _, pem := range newRoot.IntermediateCerts {
+		cert, err := connect.ParseCert(pem)
+		if err != nil {
+			return fmt.Errorf("error parsing intermediate for new root %s: %v", newRoot.ID, err)
+		}
+		intermediates = append(intermediates, cert)
+	}
+
+	if !chainsToRoot(leaf, oldRootCert, intermediates, len(intermediates)) {
+		return fmt.Errorf("leaf cert signed by the new root does not chain to the old root %s", oldRoot.ID)
+	}
+	return nil
+}
+
+// chainsToRoot returns true if cert is signed by root, directly or through at
+// most depth of the given intermediates. Only signatures are checked, not
+// validity periods, since short leaf TTLs and the time drift buffer can leave
+// no instant at which every cert in the chain is valid.
+func chainsToRoot(cert, root *x509.Certificate, intermediates []*x509.Certificate, depth int) bool {
+	if cert.CheckSignatureFrom(root) == nil {
+		return true
+	}
+	if depth == 0 {
+		return false
+	}
+	for _, inter := range intermediates {
+		if inter.Equal(cert) || cert.CheckSignatureFrom(inter) != nil {
+			continue
+		}
+		if chainsToRoot(inter, root, intermediates, depth-1) {
+			return true
+		}
+	}
+	return false
+}
+
 // primaryRenewIntermediate regenerates the intermediate cert in the primary datacenter.
 // This is only run for CAs that require an intermediary in the primary DC, such as Vault.
 // It should only be called while the state lock is held by setting the state to non-ready.
@@ -1079,13 +2496,20 @@ func (c *CAManager) secondaryRenewIntermediate(provider ca.Provider, newActiveRo
 		return nil
 	}
 
+	_, err = c.secondarySetIntermediate(provider, newActiveRoot, intermediatePEM)
+	return err
+}
+
+// secondarySetIntermediate hands a newly signed intermediate to the provider
+// and appends it to newActiveRoot, making it the signing cert.
+func (c *CAManager) secondarySetIntermediate(provider ca.Provider, newActiveRoot *structs.CARoot, intermediatePEM string) (*x509.Certificate, error) {
 	if err := provider.SetIntermediate(intermediatePEM, newActiveRoot.RootCert); err != nil {
-		return fmt.Errorf("Failed to set the intermediate certificate with the CA provider: %v", err)
+		return nil, fmt.Errorf("Failed to set the intermediate certificate with the CA provider: %v", err)
 	}
 
 	intermediateCert, err := connect.ParseCert(intermediatePEM)
 	if err != nil {
-		return fmt.Errorf("error parsing intermediate cert: %v", err)
+		return nil, fmt.Errorf("error parsing intermediate cert: %v", err)
 	}
 
 	// Append the new intermediate to our local active root entry. This is
@@ -1094,7 +2518,105 @@ func (c *CAManager) secondaryRenewIntermediate(provider ca.Provider, newActiveRo
 	newActiveRoot.SigningKeyID = connect.EncodeSigningKeyID(intermediateCert.SubjectKeyId)
 
 	c.logger.Info("received new intermediate certificate from primary datacenter")
-	return nil
+	return intermediateCert, nil
+}
+
+// RefreshSecondaryIntermediate requests a new intermediate from the primary
+// datacenter for the current root without waiting for the renewal interval
+// or rotating the root. Intermediates on the active root that can't be
+// verified against the root are dropped. It returns the serial number of the
+// new intermediate.
+func (c *CAManager) RefreshSecondaryIntermediate() (string, error) {
+	if c.serverConf.Datacenter == c.serverConf.PrimaryDatacenter {
+		return "", fmt.Errorf("intermediate refresh is only supported in secondary datacenters")
+	}
+
+	if _, err := c.setState(caStateRenewIntermediate, true); err != nil {
+		return "", err
+	}
+	defer c.setState(caStateInitialized, false)
+
+	provider, _ := c.getCAProvider()
+	if provider == nil || !c.secondaryIsCAConfigured() {
+		return "", fmt.Errorf("secondary CA is not yet configured.")
+	}
+
+	_, root, err := c.delegate.State().CARootActive(nil)
+	if err != nil {
+		return "", err
+	}
+	if root == nil {
+		return "", fmt.Errorf("no active root")
+	}
+	activeRoot := root.Clone()
+
+	activeRoot.IntermediateCerts, err = verifiedIntermediates(activeRoot)
+	if err != nil {
+		return "", err
+	}
+	if n := len(root.IntermediateCerts) - len(activeRoot.IntermediateCerts); n > 0 {
+		c.logger.Warn("dropping invalid intermediate certificates from active root",
+			"id", activeRoot.ID,
+			"count", n,
+		)
+	}
+
+	csr, err := provider.GenerateIntermediateCSR()
+	if err != nil {
+		return "", err
+	}
+	var intermediatePEM string
+	if err := c.delegate.forwardDC("ConnectCA.SignIntermediate", c.serverConf.PrimaryDatacenter, c.delegate.generateCASignRequest(csr), &intermediatePEM); err != nil {
+		return "", fmt.Errorf("primary datacenter refused to sign the intermediate CA certificate: %v", err)
+	}
+
+	intermediateCert, err := c.secondarySetIntermediate(provider, activeRoot, intermediatePEM)
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.persistNewRootAndConfig(provider, activeRoot, nil); err != nil {
+		return "", err
+	}
+	c.setCAProvider(provider, activeRoot)
+
+	return connect.EncodeSerialNumber(intermediateCert.SerialNumber), nil
+}
+
+// verifiedIntermediates returns the intermediates of root that parse and
+// chain back to its root cert.
+func verifiedIntermediates(root *structs.CARoot) ([]string, error) {
+	rootCert, err := connect.ParseCert(root.RootCert)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing root cert: %v", err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(rootCert)
+
+	pool := x509.NewCertPool()
+	certs := make([]*x509.Certificate, len(root.IntermediateCerts))
+	for i, intermediatePEM := range root.IntermediateCerts {
+		if cert, err := connect.ParseCert(intermediatePEM); err == nil {
+			certs[i] = cert
+			pool.AddCert(cert)
+		}
+	}
+
+	var valid []string
+	for i, cert := range certs {
+		if cert == nil {
+			continue
+		}
+		_, err := cert.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: pool,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		})
+		if err == nil {
+			valid = append(valid, root.IntermediateCerts[i])
+		}
+	}
+	return valid, nil
 }
 
 // intermediateCertRenewalWatch periodically attempts to renew the intermediate cert.
@@ -1118,6 +2640,97 @@ func (c *CAManager) intermediateCertRenewalWatch(ctx context.Context) error {
 	}
 }
 
+// rootCertRenewalWatch periodically rotates the active root in the primary
+// when AutoRenewRoot is set and it is nearing expiry.
+func (c *CAManager) rootCertRenewalWatch(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(structs.RootCertRenewInterval):
+			retryLoopBackoffAbortOnSuccess(ctx, func() error {
+				return c.RenewRoot()
+			}, func(err error) {
+				c.logger.Error("error renewing root cert",
+					"routine", rootCertRenewWatchRoutineName,
+					"error", err,
+				)
+			})
+		}
+	}
+}
+
+// RenewRoot rotates the active root once RootRenewFraction of its lifetime
+// has passed, if AutoRenewRoot is set. The rotation cross-signs the new root
+// like a manual rotation does.
+func (c *CAManager) RenewRoot() error {
+	state := c.delegate.State()
+	_, config, err := state.CAConfig(nil)
+	if err != nil {
+		return err
+	}
+	if config == nil || !config.AutoRenewRoot {
+		return nil
+	}
+
+	_, root, err := state.CARootActive(nil)
+	if err != nil {
+		return err
+	}
+	if root == nil {
+		return nil
+	}
+	if lessThanRenewTimePassed(c.timeNow(), root.NotBefore, root.NotAfter, config.GetRootRenewFraction(), 0, nil) {
+		return nil
+	}
+
+	c.logger.Info("renewing CA root before it expires", "root", root.ID, "expires", root.NotAfter)
+	newRootID, err := c.rotateRoot(&structs.CARotateRootRequest{
+		Datacenter: c.serverConf.Datacenter,
+	}, rootRotationTriggerAutoRenew)
+	if err != nil {
+		return err
+	}
+	// rotateRoot returns the active root unchanged when a reconfiguration is
+	// already in progress, in which case the renewal is retried next time.
+	if newRootID == root.ID {
+		c.logger.Warn("CA root renewal skipped, reconfiguration in progress", "root", root.ID, "expires", root.NotAfter)
+		return nil
+	}
+	c.logger.Info("renewed CA root", "old_root", root.ID, "new_root", newRootID)
+	return nil
+}
+
+// intermediateNotBeforeBackdate returns how far the provider backdates the
+// NotBefore of the intermediate certificates it signs under config.
+func intermediateNotBeforeBackdate(config *structs.CAConfiguration) time.Duration {
+	if config == nil {
+		return ca.CertificateTimeDriftBuffer
+	}
+	common, err := config.GetCommonConfig()
+	if err != nil {
+		return ca.CertificateTimeDriftBuffer
+	}
+	return ca.IntermediateNotBeforeBackdate(*common)
+}
+
+// intermediateValidity returns the NotBefore and NotAfter times of
+// activeIntermediate. It asks the provider first so the cert is only parsed
+// when the provider can't answer.
+func intermediateValidity(provider ca.Provider, activeIntermediate string) (notBefore, notAfter time.Time, err error) {
+	if expirer, ok := provider.(ca.IntermediateExpirer); ok {
+		if notBefore, notAfter, err := expirer.IntermediateExpiry(); err == nil {
+			return notBefore, notAfter, nil
+		}
+	}
+
+	cert, err := connect.ParseCert(activeIntermediate)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("error parsing active intermediate cert: %v", err)
+	}
+	return cert.NotBefore, cert.NotAfter, nil
+}
+
 // RenewIntermediate checks the intermediate cert for
 // expiration. If more than half the time a cert is valid has passed,
 // it will try to renew it.
@@ -1163,13 +2776,22 @@ func (c *CAManager) RenewIntermediate(ctx context.Context, isPrimary bool) error
 		return fmt.Errorf("datacenter doesn't have an active intermediate.")
 	}
 
-	intermediateCert, err := connect.ParseCert(activeIntermediate)
+	notBefore, notAfter, err := intermediateValidity(provider, activeIntermediate)
+	if err != nil {
+		return err
+	}
+
+	_, config, err := state.CAConfig(nil)
 	if err != nil {
-		return fmt.Errorf("error parsing active intermediate cert: %v", err)
+		return err
 	}
 
-	if lessThanHalfTimePassed(c.timeNow(), intermediateCert.NotBefore.Add(ca.CertificateTimeDriftBuffer),
-		intermediateCert.NotAfter) {
+	var jitter float64
+	if config != nil {
+		jitter = config.IntermediateRenewJitter
+	}
+	if lessThanRenewTimePassed(c.timeNow(), notBefore.Add(intermediateNotBeforeBackdate(config)),
+		notAfter, config.GetIntermediateRenewFraction(), jitter, []byte(activeIntermediate)) {
 		return nil
 	}
 
@@ -1215,7 +2837,7 @@ func (c *CAManager) secondaryCARootWatch(ctx context.Context) error {
 
 	c.logger.Debug("starting Connect CA root replication from primary datacenter", "primary", c.serverConf.PrimaryDatacenter)
 
-	retryLoopBackoff(ctx, func() error {
+	retryLoopBackoffHandleSuccess(ctx, c.secondaryRetryBackoff(), func() error {
 		var roots structs.IndexedCARoots
 		if err := c.delegate.forwardDC("ConnectCA.Roots", c.serverConf.PrimaryDatacenter, &args, &roots); err != nil {
 			return fmt.Errorf("Error retrieving the primary datacenter's roots: %v", err)
@@ -1239,14 +2861,36 @@ func (c *CAManager) secondaryCARootWatch(ctx context.Context) error {
 			"routine", secondaryCARootWatchRoutineName,
 			"error", err,
 		)
-	})
+	}, false)
 
 	return nil
 }
 
+// secondaryRetryBackoff returns the backoff used between failed attempts to
+// initialize the CA in a secondary datacenter, with unset fields taking their
+// default values.
+func (c *CAManager) secondaryRetryBackoff() retryBackoff {
+	backoff := defaultRetryBackoff
+	if c.serverConf.ConnectSecondaryCARetryMinBackoff > 0 {
+		backoff.Min = c.serverConf.ConnectSecondaryCARetryMinBackoff
+	}
+	if c.serverConf.ConnectSecondaryCARetryMaxBackoff > 0 {
+		backoff.Max = c.serverConf.ConnectSecondaryCARetryMaxBackoff
+	}
+	if c.serverConf.ConnectSecondaryCARetryBackoffMultiplier > 0 {
+		backoff.Multiplier = c.serverConf.ConnectSecondaryCARetryBackoffMultiplier
+	}
+	return backoff
+}
+
 // secondaryUpdateRoots updates the cached roots from the primary and regenerates the intermediate
 // certificate if necessary.
 func (c *CAManager) secondaryUpdateRoots(roots structs.IndexedCARoots) error {
+	// Keep using the current roots rather than adopt a root that's too weak.
+	if err := c.checkPrimaryRootKeyStrength(roots); err != nil {
+		return err
+	}
+
 	// Update the state first to claim the 'lock'.
 	if _, err := c.setState(caStateReconfig, true); err != nil {
 		return err
@@ -1301,15 +2945,10 @@ func (c *CAManager) secondaryInitializeProvider(provider ca.Provider, roots stru
 		return err
 	}
 
-	pCfg := ca.ProviderConfig{
-		ClusterID:  clusterID,
-		Datacenter: c.serverConf.Datacenter,
-		IsPrimary:  false,
-		RawConfig:  conf.Config,
-		State:      conf.State,
-	}
-	if err := provider.Configure(pCfg); err != nil {
-		return fmt.Errorf("error configuring provider: %v", err)
+	pCfg := c.providerConfig(conf, false)
+	pCfg.ClusterID = clusterID
+	if err := c.configureProvider(conf.Provider, provider, pCfg); err != nil {
+		return fmt.Errorf("error configuring provider: %w", err)
 	}
 
 	return c.secondarySetCAConfigured()
@@ -1390,6 +3029,68 @@ func (l *connectSignRateLimiter) getCSRRateLimiterWithLimit(limit rate.Limit) *r
 }
 
 func (c *CAManager) SignCertificate(csr *x509.CertificateRequest, spiffeID connect.CertURI) (*structs.IssuedCert, error) {
+	return c.SignCertificateWithTTL(csr, spiffeID, 0)
+}
+
+// SignCertificateWithTTL signs a leaf certificate like SignCertificate. A
+// non-zero ttl requests a shorter lifetime for a service certificate and is
+// clamped to [MinLeafCertTTL, LeafCertTTL] using this datacenter's config.
+func (c *CAManager) SignCertificateWithTTL(csr *x509.CertificateRequest, spiffeID connect.CertURI, ttl time.Duration) (*structs.IssuedCert, error) {
+	s, err := c.newLeafSigner()
+	if err != nil {
+		return nil, err
+	}
+	defer s.close()
+	return s.sign(csr, spiffeID, ttl)
+}
+
+// SignCertificatesWithTTL signs a batch of leaf certificates like
+// SignCertificateWithTTL, looking up the provider and CA configuration once
+// for the whole batch. The returned slices hold one entry per CSR, in order:
+// a CSR that can't be signed gets a nil cert and its error without affecting
+// the others. The error is only set when the CA can't sign at all, in which
+// case no CSR was signed.
+func (c *CAManager) SignCertificatesWithTTL(csrs []*x509.CertificateRequest, spiffeIDs []connect.CertURI, ttl time.Duration) ([]*structs.IssuedCert, []error, error) {
+	if len(csrs) != len(spiffeIDs) {
+		return nil, nil, fmt.Errorf("got %d CSRs but %d SPIFFE IDs", len(csrs), len(spiffeIDs))
+	}
+	s, err := c.newLeafSigner()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer s.close()
+
+	certs := make([]*structs.IssuedCert, len(csrs))
+	errs := make([]error, len(csrs))
+	for i, csr := range csrs {
+		certs[i], errs[i] = s.sign(csr, spiffeIDs[i], ttl)
+	}
+	return certs, errs, nil
+}
+
+// leafSigner signs leaf certificates against a single snapshot of the CA
+// provider and configuration, so that the CSRs of a batch share the lookups,
+// the concurrency limiter slot and the provider's active certificates.
+type leafSigner struct {
+	c         *CAManager
+	provider  ca.Provider
+	caRoot    *structs.CARoot
+	config    *structs.CAConfiguration
+	commonCfg *structs.CommonCAProviderConfig
+	signingID *connect.SpiffeIDSigning
+	labels    []metrics.Label
+
+	// root and inter are the provider's active certificates, fetched before
+	// the first CSR is signed. certsErr is the error from fetching them.
+	certsLoaded bool
+	root, inter string
+	certsErr    error
+
+	// release frees the concurrency limiter slot once one is held.
+	release func()
+}
+
+func (c *CAManager) newLeafSigner() (*leafSigner, error) {
 	provider, caRoot := c.getCAProvider()
 	if provider == nil {
 		return nil, fmt.Errorf("CA is uninitialized and unable to sign certificates yet: provider is nil")
@@ -1397,24 +3098,123 @@ func (c *CAManager) SignCertificate(csr *x509.CertificateRequest, spiffeID conne
 		return nil, fmt.Errorf("CA is uninitialized and unable to sign certificates yet: no root certificate")
 	}
 
-	// Verify that the CSR entity is in the cluster's trust domain
 	state := c.delegate.State()
 	_, config, err := state.CAConfig(nil)
 	if err != nil {
 		return nil, err
 	}
-	signingID := connect.SpiffeIDSigningForCluster(config)
+	commonCfg, err := config.GetCommonConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &leafSigner{
+		c:         c,
+		provider:  provider,
+		caRoot:    caRoot,
+		config:    config,
+		commonCfg: commonCfg,
+		signingID: connect.SpiffeIDSigningForCluster(config),
+		labels: []metrics.Label{
+			{Name: "datacenter", Value: c.serverConf.Datacenter},
+			{Name: "provider", Value: config.Provider},
+		},
+	}, nil
+}
+
+// close releases the concurrency limiter slot if the signer holds one.
+func (s *leafSigner) close() {
+	if s.release != nil {
+		s.release()
+		s.release = nil
+	}
+}
+
+// acquire waits for the CSR rate limiter before each signing. With the
+// concurrency limiter instead, one slot is held until close so a batch counts
+// as a single concurrent signing.
+func (s *leafSigner) acquire() error {
+	if s.commonCfg.CSRMaxPerSecond > 0 {
+		lim := s.c.caLeafLimiter.getCSRRateLimiterWithLimit(rate.Limit(s.commonCfg.CSRMaxPerSecond))
+		// Wait up to the small threshold we allow for a token.
+		ctx, cancel := context.WithTimeout(context.Background(), csrLimitWait)
+		defer cancel()
+		if lim.Wait(ctx) != nil {
+			return ErrRateLimited
+		}
+	} else if s.commonCfg.CSRMaxConcurrent > 0 && s.release == nil {
+		limiter := &s.c.caLeafLimiter.csrConcurrencyLimiter
+		limiter.SetSize(int64(s.commonCfg.CSRMaxConcurrent))
+		ctx, cancel := context.WithTimeout(context.Background(), csrLimitWait)
+		defer cancel()
+		if err := limiter.Acquire(ctx); err != nil {
+			return ErrRateLimited
+		}
+		s.release = limiter.Release
+	}
+	return nil
+}
+
+// loadActiveCerts fetches the provider's active root and intermediate and
+// checks that neither expired. The result is reused for later CSRs.
+func (s *leafSigner) loadActiveCerts() error {
+	if s.certsLoaded {
+		return s.certsErr
+	}
+	s.certsLoaded = true
+	s.certsErr = s.fetchActiveCerts()
+	return s.certsErr
+}
+
+func (s *leafSigner) fetchActiveCerts() error {
+	root, err := s.provider.ActiveRoot()
+	if err != nil {
+		return err
+	}
+	// Check if the root expired before using it to sign.
+	err = s.c.checkExpired(root)
+	if err != nil {
+		return fmt.Errorf("root expired: %w", err)
+	}
+
+	inter, err := s.provider.ActiveIntermediate()
+	if err != nil {
+		return err
+	}
+	// Check if the intermediate expired before using it to sign.
+	err = s.c.checkExpired(inter)
+	if err != nil {
+		return fmt.Errorf("intermediate expired: %w", err)
+	}
+
+	s.root, s.inter = root, inter
+	return nil
+}
+
+func (s *leafSigner) sign(csr *x509.CertificateRequest, spiffeID connect.CertURI, ttl time.Duration) (*structs.IssuedCert, error) {
+	// Verify that the CSR entity is in the cluster's trust domain
 	serviceID, isService := spiffeID.(*connect.SpiffeIDService)
 	agentID, isAgent := spiffeID.(*connect.SpiffeIDAgent)
 	if !isService && !isAgent {
 		return nil, fmt.Errorf("SPIFFE ID in CSR must be a service or agent ID")
 	}
+	if ttl != 0 && !isService {
+		return nil, fmt.Errorf("a leaf cert TTL can only be requested for a service")
+	}
 
 	var entMeta structs.EnterpriseMeta
 	if isService {
-		if !signingID.CanSign(spiffeID) {
+		if !s.signingID.CanSign(spiffeID) {
 			return nil, fmt.Errorf("SPIFFE ID in CSR from a different trust domain: %s, "+
-				"we are %s", serviceID.Host, signingID.Host())
+				"we are %s", serviceID.Host, s.signingID.Host())
+		}
+		// Every provider copies the CSR's SANs into the leaf, so check here
+		// that the CSR names only the service the caller was authorized for.
+		if err := connect.ValidateCSR(csr, serviceID); err != nil {
+			return nil, err
+		}
+		if err := connect.ValidateCSRDNSNames(csr, s.config.LeafDNSSANAllowlist); err != nil {
+			return nil, err
 		}
 		entMeta.Merge(serviceID.GetEnterpriseMeta())
 	} else {
@@ -1422,7 +3222,7 @@ func (c *CAManager) SignCertificate(csr *x509.CertificateRequest, spiffeID conne
 		// here we are just automatically fixing the trust domain. For auto-encrypt and
 		// auto-config they make certificate requests before learning about the roots
 		// so they will have a dummy trust domain in the CSR.
-		trustDomain := signingID.Host()
+		trustDomain := s.signingID.Host()
 		if agentID.Host != trustDomain {
 			originalURI := agentID.URI()
 
@@ -1443,71 +3243,68 @@ func (c *CAManager) SignCertificate(csr *x509.CertificateRequest, spiffeID conne
 		entMeta.Merge(agentID.GetEnterpriseMeta())
 	}
 
-	commonCfg, err := config.GetCommonConfig()
-	if err != nil {
-		return nil, err
-	}
-	if commonCfg.CSRMaxPerSecond > 0 {
-		lim := c.caLeafLimiter.getCSRRateLimiterWithLimit(rate.Limit(commonCfg.CSRMaxPerSecond))
-		// Wait up to the small threshold we allow for a token.
-		ctx, cancel := context.WithTimeout(context.Background(), csrLimitWait)
-		defer cancel()
-		if lim.Wait(ctx) != nil {
-			return nil, ErrRateLimited
+	var ttlSigner ca.SignerWithTTL
+	if ttl != 0 {
+		var ok bool
+		if ttlSigner, ok = s.provider.(ca.SignerWithTTL); !ok {
+			return nil, fmt.Errorf("the %q CA provider does not support requesting a leaf cert TTL", s.config.Provider)
 		}
-	} else if commonCfg.CSRMaxConcurrent > 0 {
-		c.caLeafLimiter.csrConcurrencyLimiter.SetSize(int64(commonCfg.CSRMaxConcurrent))
-		ctx, cancel := context.WithTimeout(context.Background(), csrLimitWait)
-		defer cancel()
-		if err := c.caLeafLimiter.csrConcurrencyLimiter.Acquire(ctx); err != nil {
-			return nil, ErrRateLimited
+		ttl = structs.ClampLeafCertTTLWithMin(ttl, s.c.serverConf.ConnectMinLeafCertTTL, s.commonCfg.LeafCertTTL)
+	}
+
+	// A duplicate of a recently signed CSR gets the same leaf without
+	// waiting for the limiter or calling the provider again.
+	cacheTTL := s.c.serverConf.ConnectLeafSignCacheTTL
+	var cacheKey string
+	var cacheGen uint64
+	if cacheTTL > 0 {
+		cacheKey = leafSignCacheKey(csr, ttl, s.caRoot.ID)
+		cacheGen = s.c.leafCache.generation()
+		if cert := s.c.leafCache.get(cacheKey, s.c.timeNow()); cert != nil {
+			return cert, nil
 		}
-		defer c.caLeafLimiter.csrConcurrencyLimiter.Release()
 	}
 
-	connect.HackSANExtensionForCSR(csr)
-
-	root, err := provider.ActiveRoot()
-	if err != nil {
+	if err := s.acquire(); err != nil {
 		return nil, err
 	}
-	// Check if the root expired before using it to sign.
-	err = c.checkExpired(root)
-	if err != nil {
-		return nil, fmt.Errorf("root expired: %w", err)
-	}
 
-	inter, err := provider.ActiveIntermediate()
-	if err != nil {
+	connect.HackSANExtensionForCSR(csr)
+
+	if err := s.loadActiveCerts(); err != nil {
 		return nil, err
 	}
-	// Check if the intermediate expired before using it to sign.
-	err = c.checkExpired(inter)
-	if err != nil {
-		return nil, fmt.Errorf("intermediate expired: %w", err)
-	}
 
 	// All seems to be in order, actually sign it.
 
-	pem, err := provider.Sign(csr)
+	start := time.Now()
+	var pem string
+	var err error
+	if ttlSigner != nil {
+		pem, err = ttlSigner.SignWithTTL(csr, ttl)
+	} else {
+		pem, err = s.provider.Sign(csr)
+	}
+	metrics.MeasureSinceWithLabels(metricsKeyConnectCALeafSignTime, start, s.labels)
 	if err == ca.ErrRateLimited {
 		return nil, ErrRateLimited
 	}
 	if err != nil {
 		return nil, err
 	}
+	metrics.IncrCounterWithLabels(metricsKeyConnectCALeafSigned, 1, s.labels)
 
 	// Append any intermediates needed by this root.
-	for _, p := range caRoot.IntermediateCerts {
+	for _, p := range s.caRoot.IntermediateCerts {
 		pem = pem + ca.EnsureTrailingNewline(p)
 	}
 
 	// Append our local CA's intermediate if there is one.
-	if inter != root {
-		pem = pem + ca.EnsureTrailingNewline(inter)
+	if s.inter != s.root {
+		pem = pem + ca.EnsureTrailingNewline(s.inter)
 	}
 
-	modIdx, err := c.delegate.ApplyCALeafRequest()
+	modIdx, err := s.c.delegate.ApplyCALeafRequest()
 	if err != nil {
 		return nil, err
 	}
@@ -1537,6 +3334,9 @@ func (c *CAManager) SignCertificate(csr *x509.CertificateRequest, spiffeID conne
 		reply.AgentURI = cert.URIs[0].String()
 	}
 
+	if cacheKey != "" {
+		s.c.leafCache.add(cacheKey, cacheGen, &reply, s.c.timeNow(), cacheTTL)
+	}
 	return &reply, nil
 }
 
@@ -11,9 +11,12 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/serf/serf"
 	"github.com/stretchr/testify/assert"
@@ -25,7 +28,9 @@ import (
 	"github.com/hashicorp/consul/agent/metadata"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/agent/token"
+	"github.com/hashicorp/consul/lib/routine"
 	"github.com/hashicorp/consul/sdk/testutil"
+	"github.com/hashicorp/consul/sdk/testutil/retry"
 )
 
 // TODO(kyhavlov): replace with t.Deadline()
@@ -157,6 +162,7 @@ type mockCAProvider struct {
 	callbackCh      chan string
 	rootPEM         string
 	intermediatePem string
+	healthErr       error
 }
 
 func (m *mockCAProvider) Configure(cfg ca.ProviderConfig) error { return nil }
@@ -183,8 +189,8 @@ func (m *mockCAProvider) GenerateIntermediate() (string, error)
 func (m *mockCAProvider) Sign(*x509.CertificateRequest) (string, error)             { return "", nil }
 func (m *mockCAProvider) SignIntermediate(*x509.CertificateRequest) (string, error) { return "", nil }
 func (m *mockCAProvider) CrossSignCA(*x509.Certificate) (string, error)             { return "", nil }
-func (m *mockCAProvider) SupportsCrossSigning() (bool, error)                       { return false, nil }
 func (m *mockCAProvider) Cleanup(_ bool, _ map[string]interface{}) error            { return nil }
+func (m *mockCAProvider) HealthCheck() error                                        { return m.healthErr }
 
 func waitForCh(t *testing.T, ch chan string, expected string) {
 	t.Helper()
@@ -282,6 +288,217 @@ func TestCAManager_Initialize(t *testing.T) {
 	require.Equal(t, caStateInitialized, manager.state)
 }
 
+// misconfiguredCAProvider is a mockCAProvider whose Configure fails with
+// ca.ErrProviderMisconfigured until fixed is set.
+type misconfiguredCAProvider struct {
+	mockCAProvider
+	configureCalls uint32
+	fixed          uint32
+}
+
+func (m *misconfiguredCAProvider) Configure(cfg ca.ProviderConfig) error {
+	atomic.AddUint32(&m.configureCalls, 1)
+	if atomic.LoadUint32(&m.fixed) == 0 {
+		return ca.WrapProviderError(ca.ErrProviderMisconfigured, fmt.Errorf("bad config"))
+	}
+	return nil
+}
+
+func TestCAManager_BackgroundInitialization_Misconfigured(t *testing.T) {
+	conf := DefaultConfig()
+	conf.ConnectEnabled = true
+	conf.PrimaryDatacenter = "dc1"
+	conf.Datacenter = "dc2"
+	delegate := NewMockCAServerDelegate(t, conf)
+	logger := testutil.Logger(t)
+	manager := NewCAManager(delegate, routine.NewManager(logger), logger, conf)
+	provider := &misconfiguredCAProvider{
+		mockCAProvider: mockCAProvider{
+			callbackCh: delegate.callbackCh,
+			rootPEM:    delegate.primaryRoot.RootCert,
+		},
+	}
+	manager.providerShim = provider
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		for {
+			select {
+			case <-delegate.callbackCh:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- manager.backgroundCAInitialization(ctx)
+	}()
+
+	retry.Run(t, func(r *retry.R) {
+		require.EqualValues(r, 1, atomic.LoadUint32(&provider.configureCalls))
+	})
+
+	// A misconfigured provider shouldn't be retried on the usual backoff.
+	time.Sleep(2 * defaultRetryBackoff.Min)
+	require.EqualValues(t, 1, atomic.LoadUint32(&provider.configureCalls))
+	status, _ := manager.Health()
+	require.Equal(t, structs.CAHealthUninitialized, status)
+
+	// Changing the CA configuration wakes the loop up again.
+	atomic.StoreUint32(&provider.fixed, 1)
+	idx, cfg, err := delegate.store.CAConfig(nil)
+	require.NoError(t, err)
+	newCfg := *cfg
+	require.NoError(t, delegate.store.CASetConfig(idx+1, &newCfg))
+
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(CATestTimeout):
+		t.Fatal("background initialization never finished")
+	}
+	require.EqualValues(t, 2, atomic.LoadUint32(&provider.configureCalls))
+	require.Equal(t, caStateInitialized, manager.state)
+}
+
+// flakyCAProvider is a mockCAProvider whose Configure fails while failures is
+// greater than zero. The errors are transient unless misconfigured is set.
+type flakyCAProvider struct {
+	mockCAProvider
+	configureCalls uint32
+	failures       int32
+	misconfigured  uint32
+}
+
+func (m *flakyCAProvider) Configure(cfg ca.ProviderConfig) error {
+	atomic.AddUint32(&m.configureCalls, 1)
+	if atomic.AddInt32(&m.failures, -1) < 0 {
+		return nil
+	}
+	if atomic.LoadUint32(&m.misconfigured) != 0 {
+		return ca.WrapProviderError(ca.ErrProviderMisconfigured, fmt.Errorf("bad config"))
+	}
+	return fmt.Errorf("connection reset by peer")
+}
+
+func TestCAManager_UpdateConfiguration_RetriesConfigure(t *testing.T) {
+	// No parallel execution because we change globals
+	origBackoff := caConfigureRetryBackoff
+	defer func() { caConfigureRetryBackoff = origBackoff }()
+	caConfigureRetryBackoff = retryBackoff{Min: time.Millisecond, Max: time.Millisecond, Multiplier: 1}
+
+	newManager := func(t *testing.T, attempts int) (*CAManager, *flakyCAProvider) {
+		conf := DefaultConfig()
+		conf.ConnectEnabled = true
+		conf.PrimaryDatacenter = "dc1"
+		conf.Datacenter = "dc2"
+		conf.ConnectCAConfigureAttempts = attempts
+		delegate := NewMockCAServerDelegate(t, conf)
+		manager := NewCAManager(delegate, nil, testutil.Logger(t), conf)
+		provider := &flakyCAProvider{
+			mockCAProvider: mockCAProvider{
+				callbackCh: delegate.callbackCh,
+				rootPEM:    delegate.primaryRoot.RootCert,
+			},
+		}
+		manager.providerShim = provider
+		initTestManager(t, manager, delegate)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+		go func() {
+			for {
+				select {
+				case <-delegate.callbackCh:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		atomic.StoreUint32(&provider.configureCalls, 0)
+		return manager, provider
+	}
+	update := func(manager *CAManager) error {
+		newConfig := testCAConfig()
+		newConfig.Config["LeafCertTTL"] = "48h"
+		// The mock primary root expires within a second, long before the
+		// intermediate TTL, so skip the TTL checks.
+		newConfig.Config["SkipValidate"] = true
+		return manager.UpdateConfiguration(&structs.CARequest{Config: newConfig})
+	}
+
+	t.Run("succeeds on the second attempt", func(t *testing.T) {
+		manager, provider := newManager(t, 2)
+		atomic.StoreInt32(&provider.failures, 1)
+
+		require.NoError(t, update(manager))
+		require.EqualValues(t, 2, atomic.LoadUint32(&provider.configureCalls))
+		require.Equal(t, caStateInitialized, manager.state)
+	})
+
+	t.Run("single attempt by default", func(t *testing.T) {
+		manager, provider := newManager(t, 0)
+		atomic.StoreInt32(&provider.failures, 1)
+
+		err := update(manager)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "connection reset by peer")
+		require.EqualValues(t, 1, atomic.LoadUint32(&provider.configureCalls))
+	})
+
+	t.Run("gives up after the configured attempts", func(t *testing.T) {
+		manager, provider := newManager(t, 3)
+		atomic.StoreInt32(&provider.failures, 5)
+
+		require.Error(t, update(manager))
+		require.EqualValues(t, 3, atomic.LoadUint32(&provider.configureCalls))
+	})
+
+	t.Run("misconfiguration isn't retried", func(t *testing.T) {
+		manager, provider := newManager(t, 3)
+		atomic.StoreUint32(&provider.misconfigured, 1)
+		atomic.StoreInt32(&provider.failures, 1)
+
+		err := update(manager)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "bad config")
+		require.EqualValues(t, 1, atomic.LoadUint32(&provider.configureCalls))
+	})
+
+	t.Run("losing leadership stops the backoff", func(t *testing.T) {
+		manager, provider := newManager(t, 3)
+		atomic.StoreInt32(&provider.failures, 5)
+
+		caConfigureRetryBackoff = retryBackoff{Min: time.Hour, Max: time.Hour, Multiplier: 1}
+		defer func() {
+			caConfigureRetryBackoff = retryBackoff{Min: time.Millisecond, Max: time.Millisecond, Multiplier: 1}
+		}()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		manager.leaderCtx = ctx
+		errCh := make(chan error, 1)
+		go func() { errCh <- update(manager) }()
+
+		retry.Run(t, func(r *retry.R) {
+			require.EqualValues(r, 1, atomic.LoadUint32(&provider.configureCalls))
+		})
+		cancel()
+
+		select {
+		case err := <-errCh:
+			require.True(t, errors.Is(err, context.Canceled), "expected context.Canceled, got %v", err)
+		case <-time.After(CATestTimeout):
+			t.Fatal("update didn't return after leadership was lost")
+		}
+		require.EqualValues(t, 1, atomic.LoadUint32(&provider.configureCalls))
+		require.Equal(t, caStateInitialized, manager.state)
+	})
+}
+
 func TestCAManager_UpdateConfigWhileRenewIntermediate(t *testing.T) {
 
 	// No parallel execution because we change globals
@@ -344,6 +561,153 @@ func TestCAManager_UpdateConfigWhileRenewIntermediate(t *testing.T) {
 	require.EqualValues(t, caStateInitialized, manager.state)
 }
 
+// expirerCAProvider is a mockCAProvider that reports the validity period of
+// its intermediate through IntermediateExpirer.
+type expirerCAProvider struct {
+	mockCAProvider
+	notBefore, notAfter time.Time
+	expiryErr           error
+	expiryCalls         int32
+}
+
+func (m *expirerCAProvider) IntermediateExpiry() (time.Time, time.Time, error) {
+	atomic.AddInt32(&m.expiryCalls, 1)
+	return m.notBefore, m.notAfter, m.expiryErr
+}
+
+func TestCAManager_RenewIntermediate_UsesIntermediateExpirer(t *testing.T) {
+	// No parallel execution because we change globals
+	origInterval := structs.IntermediateCertRenewInterval
+	origDriftBuffer := ca.CertificateTimeDriftBuffer
+	defer func() {
+		structs.IntermediateCertRenewInterval = origInterval
+		ca.CertificateTimeDriftBuffer = origDriftBuffer
+	}()
+	structs.IntermediateCertRenewInterval = time.Millisecond
+	ca.CertificateTimeDriftBuffer = 0
+
+	conf := DefaultConfig()
+	conf.ConnectEnabled = true
+	conf.PrimaryDatacenter = "dc1"
+	conf.Datacenter = "dc2"
+	delegate := NewMockCAServerDelegate(t, conf)
+	manager := NewCAManager(delegate, nil, testutil.Logger(t), conf)
+
+	// The intermediate itself only lives for a second so it is already due
+	// for renewal, but the provider reports it has most of its life left.
+	now := time.Now()
+	provider := &expirerCAProvider{
+		mockCAProvider: mockCAProvider{
+			callbackCh: delegate.callbackCh,
+			rootPEM:    delegate.primaryRoot.RootCert,
+		},
+		notBefore: now.Add(-10 * time.Minute),
+		notAfter:  now.Add(time.Hour),
+	}
+	manager.providerShim = provider
+	initTestManager(t, manager, delegate)
+	manager.timeNow = func() time.Time { return now }
+
+	// The times from the provider are used, so no renewal happens.
+	require.NoError(t, manager.RenewIntermediate(context.TODO(), false))
+	require.EqualValues(t, 1, atomic.LoadInt32(&provider.expiryCalls))
+	waitForEmptyCh(t, delegate.callbackCh)
+
+	// When the provider can't answer the cert is parsed instead, which shows
+	// it has expired by now.
+	provider.expiryErr = errors.New("no intermediate")
+	manager.timeNow = func() time.Time { return now.Add(time.Second) }
+	errCh := make(chan error)
+	go func() {
+		errCh <- manager.RenewIntermediate(context.TODO(), false)
+	}()
+
+	waitForCh(t, delegate.callbackCh, "provider/GenerateIntermediateCSR")
+	waitForCh(t, delegate.callbackCh, "forwardDC/ConnectCA.SignIntermediate")
+	waitForCh(t, delegate.callbackCh, "provider/SetIntermediate")
+	waitForCh(t, delegate.callbackCh, "raftApply/ConnectCA")
+
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(CATestTimeout):
+		t.Fatal("never got result from errCh")
+	}
+	require.EqualValues(t, 2, atomic.LoadInt32(&provider.expiryCalls))
+}
+
+// capabilityCAProvider is a mockCAProvider that reports caps through
+// CapabilityReporter.
+type capabilityCAProvider struct {
+	mockCAProvider
+	caps ca.ProviderCapabilities
+}
+
+func (m *capabilityCAProvider) Capabilities() ca.ProviderCapabilities { return m.caps }
+
+func TestCheckProviderCapabilities(t *testing.T) {
+	internalRoot := &capabilityCAProvider{caps: ca.ProviderCapabilities{KeyTypes: []string{"ec", "rsa"}}}
+	externalRoot := &capabilityCAProvider{caps: ca.ProviderCapabilities{ExternalRoot: true}}
+
+	conf := func(autoRenew bool, keyType string) *structs.CAConfiguration {
+		c := &structs.CAConfiguration{
+			Provider:      "mock",
+			AutoRenewRoot: autoRenew,
+			Config:        map[string]interface{}{},
+		}
+		if keyType != "" {
+			c.Config["PrivateKeyType"] = keyType
+		}
+		return c
+	}
+
+	cases := map[string]struct {
+		conf     *structs.CAConfiguration
+		provider ca.Provider
+		err      string
+	}{
+		"auto renew with internal root": {
+			conf:     conf(true, ""),
+			provider: internalRoot,
+		},
+		"auto renew with external root": {
+			conf:     conf(true, ""),
+			provider: externalRoot,
+			err:      "automatic root renewal is not possible",
+		},
+		"auto renew without CapabilityReporter": {
+			conf:     conf(true, ""),
+			provider: &mockCAProvider{},
+		},
+		"supported key type": {
+			conf:     conf(false, "ec"),
+			provider: internalRoot,
+		},
+		"unsupported key type": {
+			conf:     conf(false, "ed25519"),
+			provider: internalRoot,
+			err:      `private key type "ed25519" is not supported`,
+		},
+		"key type of a provider that doesn't list them": {
+			conf:     conf(false, "ed25519"),
+			provider: externalRoot,
+		},
+	}
+
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := checkProviderCapabilities(tc.conf, tc.provider)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.err)
+		})
+	}
+}
+
 func TestCAManager_SignLeafWithExpiredCert(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -464,3 +828,59 @@ func TestCADelegateWithState_GenerateCASignRequest(t *testing.T) {
 	req := d.generateCASignRequest("A")
 	require.Equal(t, "east", req.RequestDatacenter())
 }
+
+// stateMutatingCAProvider is a provider that breaks the contract of treating
+// the provider state it is configured with as read-only.
+type stateMutatingCAProvider struct {
+	mockCAProvider
+}
+
+func (m *stateMutatingCAProvider) Configure(cfg ca.ProviderConfig) error {
+	cfg.State["foo"] = "mutated"
+	return nil
+}
+
+func TestCAManager_ConfigureProvider_StateMutated(t *testing.T) {
+	// Can not use t.Parallel(), because this modifies the global metrics sink.
+	sink := metrics.NewInmemSink(time.Minute, time.Minute)
+	cfg := metrics.DefaultConfig("consul")
+	cfg.EnableHostname = false
+	cfg.EnableRuntimeMetrics = false
+	metrics.NewGlobal(cfg, sink)
+	t.Cleanup(func() {
+		metrics.NewGlobal(cfg, &metrics.BlackholeSink{})
+	})
+
+	var logs bytes.Buffer
+	logger := hclog.New(&hclog.LoggerOptions{Output: &logs})
+
+	conf := DefaultConfig()
+	conf.Datacenter = "dc1"
+	manager := NewCAManager(nil, nil, logger, conf)
+
+	const counter = "consul.connect.ca.provider.state_mutated;datacenter=dc1;provider=mock"
+	mutatedCount := func() int {
+		var count int
+		for _, interval := range sink.Data() {
+			if c, ok := interval.Counters[counter]; ok {
+				count += c.Count
+			}
+		}
+		return count
+	}
+
+	runStep(t, "well-behaved provider", func(t *testing.T) {
+		pCfg := ca.ProviderConfig{State: map[string]string{"foo": "bar"}}
+		require.NoError(t, manager.configureProvider("mock", &mockCAProvider{}, pCfg))
+		require.Equal(t, "bar", pCfg.State["foo"])
+		require.Empty(t, logs.String())
+		require.Equal(t, 0, mutatedCount())
+	})
+
+	runStep(t, "provider mutates state", func(t *testing.T) {
+		pCfg := ca.ProviderConfig{State: map[string]string{"foo": "bar"}}
+		require.NoError(t, manager.configureProvider("mock", &stateMutatingCAProvider{}, pCfg))
+		require.Contains(t, logs.String(), "CA provider modified the provider state it was configured with")
+		require.Equal(t, 1, mutatedCount())
+	})
+}
@@ -1,14 +1,21 @@
 package consul
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,6 +25,8 @@ import (
 
 	"github.com/hashicorp/consul/agent/connect"
 	"github.com/hashicorp/consul/agent/connect/ca"
+	"github.com/hashicorp/consul/agent/consul/state"
+	"github.com/hashicorp/consul/agent/consul/stream"
 	"github.com/hashicorp/consul/agent/structs"
 	"github.com/hashicorp/consul/agent/token"
 	"github.com/hashicorp/consul/sdk/testutil/retry"
@@ -175,6 +184,108 @@ func TestLeader_Builtin_PrimaryCA_ChangeKeyConfig(t *testing.T) {
 
 }
 
+func TestLeader_Builtin_PrimaryCA_Ed25519(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	dir1, srv := testServerWithConfig(t, func(c *Config) {
+		c.Datacenter = "dc1"
+		c.PrimaryDatacenter = "dc1"
+		c.Build = "1.6.0"
+		c.CAConfig.Config["PrivateKeyType"] = "ed25519"
+		c.CAConfig.Config["PrivateKeyBits"] = 256
+	})
+	defer os.RemoveAll(dir1)
+	defer srv.Shutdown()
+	codec := rpcClient(t, srv)
+	defer codec.Close()
+
+	testrpc.WaitForLeader(t, srv.RPC, "dc1")
+	testrpc.WaitForActiveCARoot(t, srv.RPC, "dc1", nil)
+
+	signAndValidate := func(t *testing.T, provider ca.Provider, caRoot *structs.CARoot) {
+		spiffeService := &connect.SpiffeIDService{
+			Host:       "node1",
+			Namespace:  "default",
+			Datacenter: "dc1",
+			Service:    "foo",
+		}
+		raw, _ := connect.TestCSR(t, spiffeService)
+
+		leafCsr, err := connect.ParseCSR(raw)
+		require.NoError(t, err)
+
+		leafPEM, err := provider.Sign(leafCsr)
+		require.NoError(t, err)
+
+		require.NoError(t, connect.ValidateLeaf(caRoot.RootCert, leafPEM, []string{}))
+	}
+
+	var (
+		provider ca.Provider
+		caRoot   *structs.CARoot
+	)
+	retry.Run(t, func(r *retry.R) {
+		provider, caRoot = getCAProviderWithLock(srv)
+		require.NotNil(r, caRoot)
+		require.Equal(r, "ed25519", caRoot.PrivateKeyType)
+		require.Equal(r, 256, caRoot.PrivateKeyBits)
+	})
+
+	runStep(t, "sign leaf cert with ed25519 root", func(t *testing.T) {
+		signAndValidate(t, provider, caRoot)
+	})
+
+	newConfig := &structs.CAConfiguration{
+		Provider: "consul",
+		Config: map[string]interface{}{
+			"PrivateKey":     "",
+			"RootCert":       "",
+			"PrivateKeyType": "ec",
+			"PrivateKeyBits": 256,
+		},
+	}
+
+	runStep(t, "rotating to a different key type requires force", func(t *testing.T) {
+		args := &structs.CARequest{
+			Datacenter: "dc1",
+			Config:     newConfig,
+		}
+		var reply interface{}
+		err := msgpackrpc.CallWithCodec(codec, "ConnectCA.ConfigurationSet", args, &reply)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Cannot cross-sign")
+	})
+
+	runStep(t, "rotate with ForceWithoutCrossSigning", func(t *testing.T) {
+		newConfig.ForceWithoutCrossSigning = true
+		args := &structs.CARequest{
+			Datacenter: "dc1",
+			Config:     newConfig,
+		}
+		var reply interface{}
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.ConfigurationSet", args, &reply))
+	})
+
+	var (
+		newProvider ca.Provider
+		newCaRoot   *structs.CARoot
+	)
+	retry.Run(t, func(r *retry.R) {
+		newProvider, newCaRoot = getCAProviderWithLock(srv)
+		require.NotNil(r, newCaRoot)
+		require.Equal(r, "ec", newCaRoot.PrivateKeyType)
+		require.Empty(r, newCaRoot.IntermediateCerts)
+	})
+
+	runStep(t, "sign leaf cert with new ec root", func(t *testing.T) {
+		signAndValidate(t, newProvider, newCaRoot)
+	})
+}
+
 func TestLeader_SecondaryCA_Initialize(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -314,6 +425,70 @@ func TestLeader_SecondaryCA_Initialize(t *testing.T) {
 	}
 }
 
+func TestLeader_SecondaryCA_ManualInitialize(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.Datacenter = "primary"
+		c.PrimaryDatacenter = "primary"
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	testrpc.WaitForLeader(t, s1.RPC, "primary")
+
+	dir2, s2 := testServerWithConfig(t, func(c *Config) {
+		c.Datacenter = "secondary"
+		c.PrimaryDatacenter = "primary"
+		c.ConnectCAManualSecondaryInit = true
+	})
+	defer os.RemoveAll(dir2)
+	defer s2.Shutdown()
+
+	joinWAN(t, s2, s1)
+	testrpc.WaitForLeader(t, s2.RPC, "secondary")
+
+	// The secondary waits for the RPC instead of pulling the primary's roots.
+	retry.Run(t, func(r *retry.R) {
+		require.True(r, s2.leaderRoutineManager.IsRunning(secondaryCAManualInitRoutineName))
+	})
+	provider, root := getCAProviderWithLock(s2)
+	require.Nil(t, provider)
+	require.Nil(t, root)
+	_, activeRoot, err := s2.fsm.State().CARootActive(nil)
+	require.NoError(t, err)
+	require.Nil(t, activeRoot)
+
+	codec := rpcClient(t, s2)
+	defer codec.Close()
+	args := &structs.DCSpecificRequest{Datacenter: "secondary"}
+	retry.Run(t, func(r *retry.R) {
+		require.NoError(r, msgpackrpc.CallWithCodec(codec, "ConnectCA.InitializeSecondary", args, &struct{}{}))
+	})
+
+	_, primaryRoot := getCAProviderWithLock(s1)
+	waitForActiveCARoot(t, s2, primaryRoot)
+	secondaryProvider, _ := getCAProviderWithLock(s2)
+	intermediatePEM, err := secondaryProvider.ActiveIntermediate()
+	require.NoError(t, err)
+
+	// Calling it again is a no-op now that the CA is initialized.
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.InitializeSecondary", args, &struct{}{}))
+
+	spiffeID := connect.TestSpiffeIDServiceWithHostDC(t, "web", connect.TestClusterID+".consul", "secondary")
+	csr, _ := connect.TestCSR(t, spiffeID)
+	signArgs := &structs.CASignRequest{
+		Datacenter: "secondary",
+		CSR:        csr,
+	}
+	var cert structs.IssuedCert
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.Sign", signArgs, &cert))
+	require.NoError(t, connect.ValidateLeaf(primaryRoot.RootCert, cert.CertPEM, []string{intermediatePEM}))
+}
+
 func waitForActiveCARoot(t *testing.T, srv *Server, expect *structs.CARoot) {
 	retry.Run(t, func(r *retry.R) {
 		_, root := getCAProviderWithLock(srv)
@@ -1033,211 +1208,776 @@ func TestLeader_SecondaryCA_TransitionFromPrimary(t *testing.T) {
 	})
 }
 
-func TestLeader_SecondaryCA_UpgradeBeforePrimary(t *testing.T) {
+func TestLeader_SecondaryCA_SignsWithCachedIntermediate(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
 	}
 
 	t.Parallel()
 
-	// Initialize dc1 as the primary DC
 	dir1, s1 := testServerWithConfig(t, func(c *Config) {
 		c.PrimaryDatacenter = "dc1"
-		c.Build = "1.3.0"
-		c.MaxQueryTime = 500 * time.Millisecond
+		c.Build = "1.6.0"
 	})
 	defer os.RemoveAll(dir1)
 	defer s1.Shutdown()
 
 	testrpc.WaitForLeader(t, s1.RPC, "dc1")
 
-	// dc2 as a secondary DC
 	dir2, s2 := testServerWithConfig(t, func(c *Config) {
 		c.Datacenter = "dc2"
 		c.PrimaryDatacenter = "dc1"
 		c.Build = "1.6.0"
-		c.MaxQueryTime = 500 * time.Millisecond
 	})
 	defer os.RemoveAll(dir2)
 	defer s2.Shutdown()
 
-	// Create the WAN link
 	joinWAN(t, s2, s1)
-	testrpc.WaitForLeader(t, s2.RPC, "dc2")
+	testrpc.WaitForActiveCARoot(t, s2.RPC, "dc2", nil)
 
-	// ensure all the CA initialization stuff would have already been done
-	// this is necessary to ensure that not only has a leader been elected
-	// but that it has also finished its establishLeadership call
-	retry.Run(t, func(r *retry.R) {
-		require.True(r, s1.isReadyForConsistentReads())
-		require.True(r, s2.isReadyForConsistentReads())
+	csr, _ := connect.TestCSR(t, &connect.SpiffeIDService{
+		Host:       connect.TestClusterID + ".consul",
+		Namespace:  "default",
+		Datacenter: "dc2",
+		Service:    "web",
 	})
+	sign := func(t require.TestingT, srv *Server) structs.IssuedCert {
+		args := &structs.CASignRequest{
+			Datacenter: "dc2",
+			CSR:        csr,
+		}
+		var leaf structs.IssuedCert
+		require.NoError(t, srv.RPC("ConnectCA.Sign", args, &leaf))
+		return leaf
+	}
 
-	// Verify the primary has a root (we faked its version too low but since its the primary it ignores any version checks)
+	var intermediatePEM string
 	retry.Run(t, func(r *retry.R) {
-		state1 := s1.fsm.State()
-		_, roots1, err := state1.CARoots(nil)
+		provider, root := getCAProviderWithLock(s2)
+		require.NotNil(r, root)
+		pem, err := provider.ActiveIntermediate()
 		require.NoError(r, err)
-		require.Len(r, roots1, 1)
+		require.NotEmpty(r, pem)
+		intermediatePEM = pem
 	})
+	sign(t, s2)
 
-	// Verify the secondary does not have a root - defers initialization until the primary has been upgraded.
-	state2 := s2.fsm.State()
-	_, roots2, err := state2.CARoots(nil)
+	_, primaryRoot, err := s1.fsm.State().CARootActive(nil)
 	require.NoError(t, err)
-	require.Empty(t, roots2)
-
-	// Update the version on the fly so s2 kicks off the secondary DC transition.
-	tags := s1.config.SerfWANConfig.Tags
-	tags["build"] = "1.6.0"
-	s1.serfWAN.SetTags(tags)
 
-	// Wait for the secondary transition to happen and then verify the secondary DC
-	// has both roots present.
-	secondaryProvider, _ := getCAProviderWithLock(s2)
-	retry.Run(t, func(r *retry.R) {
-		state1 := s1.fsm.State()
-		_, roots1, err := state1.CARoots(nil)
-		require.NoError(r, err)
-		require.Len(r, roots1, 1)
+	// Take the primary away and restart the secondary so it can't fetch the
+	// roots or a new intermediate at startup.
+	s1.Shutdown()
+	s2.Shutdown()
 
-		state2 := s2.fsm.State()
-		_, roots2, err := state2.CARoots(nil)
-		require.NoError(r, err)
-		require.Len(r, roots2, 1)
+	dir3, s3 := testServerWithConfig(t, func(c *Config) {
+		c.DataDir = s2.config.DataDir
+		c.Datacenter = "dc2"
+		c.PrimaryDatacenter = "dc1"
+		c.NodeName = s2.config.NodeName
+		c.NodeID = s2.config.NodeID
+		c.Build = "1.6.0"
+	})
+	defer os.RemoveAll(dir3)
+	defer s3.Shutdown()
 
-		// ensure the roots are the same
-		require.Equal(r, roots1[0].ID, roots2[0].ID)
-		require.Equal(r, roots1[0].RootCert, roots2[0].RootCert)
+	testrpc.WaitForLeader(t, s3.RPC, "dc2")
 
-		inter, err := secondaryProvider.ActiveIntermediate()
-		require.NoError(r, err)
-		require.NotEmpty(r, inter, "should have valid intermediate")
+	var leaf structs.IssuedCert
+	retry.Run(t, func(r *retry.R) {
+		leaf = sign(r, s3)
 	})
 
-	_, caRoot := getCAProviderWithLock(s1)
-	intermediatePEM, err := secondaryProvider.ActiveIntermediate()
+	provider, root := getCAProviderWithLock(s3)
+	require.Equal(t, primaryRoot.ID, root.ID)
+	activeIntermediate, err := provider.ActiveIntermediate()
 	require.NoError(t, err)
+	require.Equal(t, intermediatePEM, activeIntermediate)
 
-	// Have dc2 sign a leaf cert and make sure the chain is correct.
-	spiffeService := &connect.SpiffeIDService{
-		Host:       "node1",
-		Namespace:  "default",
-		Datacenter: "dc1",
-		Service:    "foo",
+	require.NoError(t, connect.ValidateLeaf(primaryRoot.RootCert, leaf.CertPEM, []string{intermediatePEM}))
+}
+
+func TestLeader_SecondaryCA_TransitionFromPrimary_AdditionalTrustDomains(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
 	}
-	raw, _ := connect.TestCSR(t, spiffeService)
 
-	leafCsr, err := connect.ParseCSR(raw)
-	require.NoError(t, err)
+	t.Parallel()
 
-	leafPEM, err := secondaryProvider.Sign(leafCsr)
+	// Initialize dc1 as the primary DC
+	id1, err := uuid.GenerateUUID()
 	require.NoError(t, err)
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+		c.CAConfig.ClusterID = id1
+		c.Build = "1.6.0"
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
 
-	cert, err := connect.ParseCert(leafPEM)
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	// dc2 as a primary DC initially
+	id2, err := uuid.GenerateUUID()
 	require.NoError(t, err)
+	dir2, s2 := testServerWithConfig(t, func(c *Config) {
+		c.Datacenter = "dc2"
+		c.PrimaryDatacenter = "dc2"
+		c.CAConfig.ClusterID = id2
+		c.Build = "1.6.0"
+	})
+	defer os.RemoveAll(dir2)
+	defer s2.Shutdown()
 
-	// Check that the leaf signed by the new cert can be verified using the
-	// returned cert chain (signed intermediate + remote root).
-	intermediatePool := x509.NewCertPool()
-	intermediatePool.AppendCertsFromPEM([]byte(intermediatePEM))
-	rootPool := x509.NewCertPool()
-	rootPool.AppendCertsFromPEM([]byte(caRoot.RootCert))
+	testrpc.WaitForLeader(t, s2.RPC, "dc2")
+	testrpc.WaitForActiveCARoot(t, s2.RPC, "dc2", nil)
 
-	_, err = cert.Verify(x509.VerifyOptions{
-		Intermediates: intermediatePool,
-		Roots:         rootPool,
+	args := structs.DCSpecificRequest{Datacenter: "dc2"}
+	var dc2PrimaryRoots structs.IndexedCARoots
+	require.NoError(t, s2.RPC("ConnectCA.Roots", &args, &dc2PrimaryRoots))
+	require.Empty(t, dc2PrimaryRoots.AdditionalTrustDomains)
+
+	signLeaf := func(r require.TestingT, s *Server, trustDomain string) string {
+		spiffeID := connect.TestSpiffeIDServiceWithHostDC(t, "web", trustDomain, "dc2")
+		csr, _ := connect.TestCSR(t, spiffeID)
+		req := structs.CASignRequest{Datacenter: "dc2", CSR: csr}
+		var reply structs.IssuedCert
+		require.NoError(r, s.RPC("ConnectCA.Sign", &req, &reply))
+		return reply.CertPEM
+	}
+
+	// Issue a leaf under dc2's original trust domain.
+	oldLeaf := signLeaf(t, s2, dc2PrimaryRoots.TrustDomain)
+
+	// Shutdown s2 and restart it with the dc1 as the primary
+	s2.Shutdown()
+	dir3, s3 := testServerWithConfig(t, func(c *Config) {
+		c.DataDir = s2.config.DataDir
+		c.Datacenter = "dc2"
+		c.PrimaryDatacenter = "dc1"
+		c.NodeName = s2.config.NodeName
+		c.NodeID = s2.config.NodeID
 	})
-	require.NoError(t, err)
-}
+	defer os.RemoveAll(dir3)
+	defer s3.Shutdown()
 
-func getTestRoots(s *Server, datacenter string) (*structs.IndexedCARoots, *structs.CARoot, error) {
-	rootReq := &structs.DCSpecificRequest{
-		Datacenter: datacenter,
-	}
-	var rootList structs.IndexedCARoots
-	if err := s.RPC("ConnectCA.Roots", rootReq, &rootList); err != nil {
-		return nil, nil, err
-	}
+	joinWAN(t, s3, s1)
+	testrpc.WaitForLeader(t, s3.RPC, "dc2")
 
-	var active *structs.CARoot
-	for _, root := range rootList.Roots {
-		if root.Active {
-			active = root
-			break
-		}
-	}
+	retry.Run(t, func(r *retry.R) {
+		var dc2SecondaryRoots structs.IndexedCARoots
+		require.NoError(r, s3.RPC("ConnectCA.Roots", &args, &dc2SecondaryRoots))
+		require.Len(r, dc2SecondaryRoots.Roots, 2)
+		require.NotEqual(r, dc2PrimaryRoots.TrustDomain, dc2SecondaryRoots.TrustDomain)
 
-	return &rootList, active, nil
+		// The old domain is still accepted while its root is trusted.
+		require.Equal(r, []string{dc2PrimaryRoots.TrustDomain}, dc2SecondaryRoots.AdditionalTrustDomains)
+
+		var oldRoot, newRoot *structs.CARoot
+		for _, root := range dc2SecondaryRoots.Roots {
+			if root.ID == dc2PrimaryRoots.ActiveRootID {
+				oldRoot = root
+			} else {
+				newRoot = root
+			}
+		}
+		require.NotNil(r, oldRoot)
+		require.NotNil(r, newRoot)
+
+		newLeaf := signLeaf(r, s3, dc2SecondaryRoots.TrustDomain)
+
+		accepted := append([]string{dc2SecondaryRoots.TrustDomain}, dc2SecondaryRoots.AdditionalTrustDomains...)
+		require.NoError(r, connect.ValidateLeaf(oldRoot.RootCert, oldLeaf, oldRoot.IntermediateCerts,
+			connect.WithTrustDomains(accepted...)))
+		require.NoError(r, connect.ValidateLeaf(newRoot.RootCert, newLeaf, newRoot.IntermediateCerts,
+			connect.WithTrustDomains(accepted...)))
+
+		// Without the additional domain the old leaf is rejected.
+		err := connect.ValidateLeaf(oldRoot.RootCert, oldLeaf, oldRoot.IntermediateCerts,
+			connect.WithTrustDomains(dc2SecondaryRoots.TrustDomain))
+		require.Error(r, err)
+		require.Contains(r, err.Error(), "not in any accepted trust domain")
+	})
 }
 
-func TestLeader_CARootPruning(t *testing.T) {
+func TestLeader_SecondaryCA_TransitionFromPrimary_GRPCProvider(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
 	}
 
-	// Can not use t.Parallel(), because this modifies a global.
-	origPruneInterval := caRootPruneInterval
-	caRootPruneInterval = 200 * time.Millisecond
-	t.Cleanup(func() {
-		// Reset the value of the global prune interval so that it doesn't affect other tests
-		caRootPruneInterval = origPruneInterval
-	})
-
-	require := require.New(t)
-	dir1, s1 := testServer(t)
-	defer os.RemoveAll(dir1)
-	defer s1.Shutdown()
-	codec := rpcClient(t, s1)
-	defer codec.Close()
+	t.Parallel()
+
+	// Initialize dc1 as the primary DC
+	id1, err := uuid.GenerateUUID()
+	require.NoError(t, err)
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+		c.CAConfig.ClusterID = id1
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	// dc2 as a primary DC initially, using an external CA provider.
+	caServer := ca.NewTestGRPCProviderServer(t)
+	grpcCAConfig := func(c *Config) {
+		c.CAConfig.Provider = structs.GRPCCAProvider
+		c.CAConfig.Config["Address"] = caServer.Addr
+		c.CAConfig.Config["Insecure"] = true
+	}
+	id2, err := uuid.GenerateUUID()
+	require.NoError(t, err)
+	dir2, s2 := testServerWithConfig(t, func(c *Config) {
+		c.Datacenter = "dc2"
+		c.PrimaryDatacenter = "dc2"
+		c.CAConfig.ClusterID = id2
+		grpcCAConfig(c)
+	})
+	defer os.RemoveAll(dir2)
+	defer s2.Shutdown()
+
+	testrpc.WaitForLeader(t, s2.RPC, "dc2")
+	testrpc.WaitForActiveCARoot(t, s2.RPC, "dc2", nil)
+
+	args := structs.DCSpecificRequest{Datacenter: "dc2"}
+	var dc2PrimaryRoots structs.IndexedCARoots
+	require.NoError(t, s2.RPC("ConnectCA.Roots", &args, &dc2PrimaryRoots))
+	require.Len(t, dc2PrimaryRoots.Roots, 1)
+
+	// Shutdown s2 and restart it with the dc1 as the primary
+	s2.Shutdown()
+	dir3, s3 := testServerWithConfig(t, func(c *Config) {
+		c.DataDir = s2.config.DataDir
+		c.Datacenter = "dc2"
+		c.PrimaryDatacenter = "dc1"
+		c.NodeName = s2.config.NodeName
+		c.NodeID = s2.config.NodeID
+		grpcCAConfig(c)
+	})
+	defer os.RemoveAll(dir3)
+	defer s3.Shutdown()
+
+	joinWAN(t, s3, s1)
+	testrpc.WaitForLeader(t, s3.RPC, "dc2")
+
+	retry.Run(t, func(r *retry.R) {
+		_, dc1Root, err := getTestRoots(s1, "dc1")
+		require.NoError(r, err)
+		require.NotNil(r, dc1Root)
+
+		dc2SecondaryRoots, dc2Root, err := getTestRoots(s3, "dc2")
+		require.NoError(r, err)
+		require.NotNil(r, dc2Root)
+
+		// dc2 switched to dc1's trust domain and root, with an intermediate
+		// from the external provider signed by dc1.
+		require.Equal(r, dc1Root.ID, dc2Root.ID)
+		require.NotEqual(r, dc2PrimaryRoots.TrustDomain, dc2SecondaryRoots.TrustDomain)
+		require.Len(r, dc2Root.IntermediateCerts, 1)
+
+		spiffeID := connect.TestSpiffeIDServiceWithHostDC(t, "web", dc2SecondaryRoots.TrustDomain, "dc2")
+		csr, _ := connect.TestCSR(t, spiffeID)
+		req := structs.CASignRequest{Datacenter: "dc2", CSR: csr}
+		var reply structs.IssuedCert
+		require.NoError(r, s3.RPC("ConnectCA.Sign", &req, &reply))
+
+		require.NoError(r, connect.ValidateLeaf(dc1Root.RootCert, reply.CertPEM, dc2Root.IntermediateCerts,
+			connect.WithTrustDomains(dc2SecondaryRoots.TrustDomain)))
+	})
+}
+
+func TestLeader_SecondaryCA_UpgradeBeforePrimary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	// Initialize dc1 as the primary DC
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+		c.Build = "1.3.0"
+		c.MaxQueryTime = 500 * time.Millisecond
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	// dc2 as a secondary DC
+	dir2, s2 := testServerWithConfig(t, func(c *Config) {
+		c.Datacenter = "dc2"
+		c.PrimaryDatacenter = "dc1"
+		c.Build = "1.6.0"
+		c.MaxQueryTime = 500 * time.Millisecond
+	})
+	defer os.RemoveAll(dir2)
+	defer s2.Shutdown()
+
+	// Create the WAN link
+	joinWAN(t, s2, s1)
+	testrpc.WaitForLeader(t, s2.RPC, "dc2")
+
+	// ensure all the CA initialization stuff would have already been done
+	// this is necessary to ensure that not only has a leader been elected
+	// but that it has also finished its establishLeadership call
+	retry.Run(t, func(r *retry.R) {
+		require.True(r, s1.isReadyForConsistentReads())
+		require.True(r, s2.isReadyForConsistentReads())
+	})
+
+	// Verify the primary has a root (we faked its version too low but since its the primary it ignores any version checks)
+	retry.Run(t, func(r *retry.R) {
+		state1 := s1.fsm.State()
+		_, roots1, err := state1.CARoots(nil)
+		require.NoError(r, err)
+		require.Len(r, roots1, 1)
+	})
+
+	// Verify the secondary does not have a root - defers initialization until the primary has been upgraded.
+	state2 := s2.fsm.State()
+	_, roots2, err := state2.CARoots(nil)
+	require.NoError(t, err)
+	require.Empty(t, roots2)
+
+	// Update the version on the fly so s2 kicks off the secondary DC transition.
+	tags := s1.config.SerfWANConfig.Tags
+	tags["build"] = "1.6.0"
+	s1.serfWAN.SetTags(tags)
+
+	// Wait for the secondary transition to happen and then verify the secondary DC
+	// has both roots present.
+	secondaryProvider, _ := getCAProviderWithLock(s2)
+	retry.Run(t, func(r *retry.R) {
+		state1 := s1.fsm.State()
+		_, roots1, err := state1.CARoots(nil)
+		require.NoError(r, err)
+		require.Len(r, roots1, 1)
+
+		state2 := s2.fsm.State()
+		_, roots2, err := state2.CARoots(nil)
+		require.NoError(r, err)
+		require.Len(r, roots2, 1)
+
+		// ensure the roots are the same
+		require.Equal(r, roots1[0].ID, roots2[0].ID)
+		require.Equal(r, roots1[0].RootCert, roots2[0].RootCert)
+
+		inter, err := secondaryProvider.ActiveIntermediate()
+		require.NoError(r, err)
+		require.NotEmpty(r, inter, "should have valid intermediate")
+	})
+
+	_, caRoot := getCAProviderWithLock(s1)
+	intermediatePEM, err := secondaryProvider.ActiveIntermediate()
+	require.NoError(t, err)
+
+	// Have dc2 sign a leaf cert and make sure the chain is correct.
+	spiffeService := &connect.SpiffeIDService{
+		Host:       "node1",
+		Namespace:  "default",
+		Datacenter: "dc1",
+		Service:    "foo",
+	}
+	raw, _ := connect.TestCSR(t, spiffeService)
+
+	leafCsr, err := connect.ParseCSR(raw)
+	require.NoError(t, err)
+
+	leafPEM, err := secondaryProvider.Sign(leafCsr)
+	require.NoError(t, err)
+
+	cert, err := connect.ParseCert(leafPEM)
+	require.NoError(t, err)
+
+	// Check that the leaf signed by the new cert can be verified using the
+	// returned cert chain (signed intermediate + remote root).
+	intermediatePool := x509.NewCertPool()
+	intermediatePool.AppendCertsFromPEM([]byte(intermediatePEM))
+	rootPool := x509.NewCertPool()
+	rootPool.AppendCertsFromPEM([]byte(caRoot.RootCert))
+
+	_, err = cert.Verify(x509.VerifyOptions{
+		Intermediates: intermediatePool,
+		Roots:         rootPool,
+	})
+	require.NoError(t, err)
+}
+
+func TestLeader_SecondaryCA_RejectsWeakPrimaryRoot(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.PrimaryDatacenter = "dc1"
+		c.Build = "1.6.0"
+		c.CAConfig.Config["PrivateKeyType"] = "rsa"
+		c.CAConfig.Config["PrivateKeyBits"] = 2048
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	dir2, s2 := testServerWithConfig(t, func(c *Config) {
+		c.Datacenter = "dc2"
+		c.PrimaryDatacenter = "dc1"
+		c.Build = "1.6.0"
+		c.ConnectMinPrimaryRootKeyTypes = []structs.CAKeyType{
+			{Type: "rsa", Bits: 4096},
+			{Type: "ec", Bits: 256},
+		}
+		c.ConnectSecondaryCARetryMinBackoff = 100 * time.Millisecond
+		c.ConnectSecondaryCARetryMaxBackoff = 500 * time.Millisecond
+	})
+	defer os.RemoveAll(dir2)
+	defer s2.Shutdown()
+
+	joinWAN(t, s2, s1)
+	testrpc.WaitForLeader(t, s2.RPC, "dc2")
+
+	// The secondary keeps retrying but never adopts the primary's root.
+	retry.Run(t, func(r *retry.R) {
+		status, err := s2.caManager.Health()
+		require.Equal(r, structs.CAHealthUninitialized, status)
+		require.Error(r, err)
+		require.Contains(r, err.Error(), "refusing to use the primary datacenter's CA root")
+		require.Contains(r, err.Error(), "CA key rsa:2048 is weaker than the required minimum of rsa:4096")
+	})
+
+	_, roots, err := s2.fsm.State().CARoots(nil)
+	require.NoError(t, err)
+	require.Empty(t, roots)
+}
+func getTestRoots(s *Server, datacenter string) (*structs.IndexedCARoots, *structs.CARoot, error) {
+	rootReq := &structs.DCSpecificRequest{
+		Datacenter: datacenter,
+	}
+	var rootList structs.IndexedCARoots
+	if err := s.RPC("ConnectCA.Roots", rootReq, &rootList); err != nil {
+		return nil, nil, err
+	}
+
+	var active *structs.CARoot
+	for _, root := range rootList.Roots {
+		if root.Active {
+			active = root
+			break
+		}
+	}
+
+	return &rootList, active, nil
+}
+
+func TestLeader_CARootPruning(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	dir1, s1 := testServer(t)
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	testrpc.WaitForTestAgent(t, s1.RPC, "dc1")
+
+	// Get the current root
+	rootReq := &structs.DCSpecificRequest{
+		Datacenter: "dc1",
+	}
+	var rootList structs.IndexedCARoots
+	require.Nil(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.Roots", rootReq, &rootList))
+	require.Len(t, rootList.Roots, 1)
+	oldRoot := rootList.Roots[0]
+
+	// Update the provider config to use a new private key, which should
+	// cause a rotation.
+	_, newKey, err := connect.GeneratePrivateKey()
+	require.NoError(t, err)
+	newConfig := &structs.CAConfiguration{
+		Provider: "consul",
+		Config: map[string]interface{}{
+			"LeafCertTTL":  "500ms",
+			"PrivateKey":   newKey,
+			"RootCert":     "",
+			"SkipValidate": true,
+		},
+		RootPruneInterval: structs.MinRootPruneInterval,
+	}
+	{
+		args := &structs.CARequest{
+			Datacenter: "dc1",
+			Config:     newConfig,
+		}
+		var reply interface{}
+
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.ConfigurationSet", args, &reply))
+	}
+
+	// Should have 2 roots now.
+	_, roots, err := s1.fsm.State().CARoots(nil)
+	require.NoError(t, err)
+	require.Len(t, roots, 2)
+
+	// Now the old root should be pruned.
+	retry.RunWith(&retry.Timer{Timeout: 3 * structs.MinRootPruneInterval, Wait: 500 * time.Millisecond}, t, func(r *retry.R) {
+		_, roots, err := s1.fsm.State().CARoots(nil)
+		require.NoError(r, err)
+		require.Len(r, roots, 1)
+		require.True(r, roots[0].Active)
+		require.NotEqual(r, roots[0].ID, oldRoot.ID)
+	})
+}
+
+func TestLeader_CARootPruning_Hook(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	var (
+		s1       *Server
+		mu       sync.Mutex
+		hookIDs  []string
+		rejectCh = make(chan struct{})
+	)
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.CARootPruneHook = func(root *structs.CARoot) error {
+			// The root must still be stored when the hook runs.
+			_, roots, err := s1.fsm.State().CARoots(nil)
+			if err != nil {
+				return err
+			}
+			if !containsRoot(roots, root.ID) {
+				return fmt.Errorf("root %s was already removed", root.ID)
+			}
+
+			mu.Lock()
+			hookIDs = append(hookIDs, root.ID)
+			mu.Unlock()
+
+			// Keep the root the first time to check that errors cancel pruning.
+			select {
+			case <-rejectCh:
+				return nil
+			default:
+				close(rejectCh)
+				return fmt.Errorf("archive unavailable")
+			}
+		}
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	defer codec.Close()
 
 	testrpc.WaitForTestAgent(t, s1.RPC, "dc1")
 
-	// Get the current root
-	rootReq := &structs.DCSpecificRequest{
-		Datacenter: "dc1",
+	_, oldRoot, err := s1.fsm.State().CARootActive(nil)
+	require.NoError(t, err)
+	require.NotNil(t, oldRoot)
+
+	// Rotate the root with a new private key.
+	_, newKey, err := connect.GeneratePrivateKey()
+	require.NoError(t, err)
+	args := &structs.CARequest{
+		Datacenter: "dc1",
+		Config: &structs.CAConfiguration{
+			Provider: "consul",
+			Config: map[string]interface{}{
+				"LeafCertTTL":  "500ms",
+				"PrivateKey":   newKey,
+				"RootCert":     "",
+				"SkipValidate": true,
+			},
+			RootPruneInterval: structs.MinRootPruneInterval,
+		},
+	}
+	var reply interface{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.ConfigurationSet", args, &reply))
+
+	// The hook rejects the first attempt, so the old root is only pruned on a
+	// later pass and the hook sees it at least twice.
+	retry.RunWith(&retry.Timer{Timeout: 5 * structs.MinRootPruneInterval, Wait: 500 * time.Millisecond}, t, func(r *retry.R) {
+		_, roots, err := s1.fsm.State().CARoots(nil)
+		require.NoError(r, err)
+		require.Len(r, roots, 1)
+		require.NotEqual(r, oldRoot.ID, roots[0].ID)
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.GreaterOrEqual(t, len(hookIDs), 2)
+	for _, id := range hookIDs {
+		require.Equal(t, oldRoot.ID, id)
+	}
+}
+
+func TestLeader_CARootAutoRenew(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	// no parallel execution because we change globals
+	origInterval := structs.RootCertRenewInterval
+	defer func() {
+		structs.RootCertRenewInterval = origInterval
+	}()
+	structs.RootCertRenewInterval = 100 * time.Millisecond
+
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.CAConfig.Config["LeafCertTTL"] = "500ms"
+		c.CAConfig.Config["IntermediateCertTTL"] = "20s"
+		c.CAConfig.Config["RootCertTTL"] = "20s"
+		c.CAConfig.Config["SkipValidate"] = true
+		c.CAConfig.AutoRenewRoot = true
+		c.CAConfig.RootRenewFraction = 0.25
+		c.CAConfig.RootPruneInterval = structs.MinRootPruneInterval
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+
+	testrpc.WaitForLeader(t, s1.RPC, "dc1")
+
+	_, oldRoot, err := s1.fsm.State().CARootActive(nil)
+	require.NoError(t, err)
+	require.NotNil(t, oldRoot)
+
+	// A new root should be created well before the old one expires.
+	retry.RunWith(&retry.Timer{Timeout: 15 * time.Second, Wait: 250 * time.Millisecond}, t, func(r *retry.R) {
+		_, active, err := s1.fsm.State().CARootActive(nil)
+		require.NoError(r, err)
+		require.NotNil(r, active)
+		if active.ID == oldRoot.ID {
+			r.Fatal("root not renewed yet")
+		}
+	})
+	require.True(t, time.Now().Before(oldRoot.NotAfter), "root was renewed after it expired")
+
+	// The old root should eventually be pruned.
+	retry.RunWith(&retry.Timer{Timeout: 4 * structs.MinRootPruneInterval, Wait: 500 * time.Millisecond}, t, func(r *retry.R) {
+		_, roots, err := s1.fsm.State().CARoots(nil)
+		require.NoError(r, err)
+		for _, root := range roots {
+			require.NotEqual(r, oldRoot.ID, root.ID)
+		}
+	})
+}
+
+func TestLeader_CARootPruning_MaxRetainedRoots(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
 	}
-	var rootList structs.IndexedCARoots
-	require.Nil(msgpackrpc.CallWithCodec(codec, "ConnectCA.Roots", rootReq, &rootList))
-	require.Len(rootList.Roots, 1)
-	oldRoot := rootList.Roots[0]
 
-	// Update the provider config to use a new private key, which should
-	// cause a rotation.
-	_, newKey, err := connect.GeneratePrivateKey()
-	require.NoError(err)
-	newConfig := &structs.CAConfiguration{
-		Provider: "consul",
-		Config: map[string]interface{}{
-			"LeafCertTTL":  "500ms",
-			"PrivateKey":   newKey,
-			"RootCert":     "",
-			"SkipValidate": true,
-		},
-	}
-	{
+	t.Parallel()
+
+	dir1, s1 := testServer(t)
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	testrpc.WaitForTestAgent(t, s1.RPC, "dc1")
+
+	_, roots, err := s1.fsm.State().CARoots(nil)
+	require.NoError(t, err)
+	require.Len(t, roots, 1)
+	rootIDs := []string{roots[0].ID}
+
+	// Rotate four times with a leaf TTL long enough that none of the old
+	// roots would be pruned for being unused.
+	for i := 0; i < 4; i++ {
+		_, newKey, err := connect.GeneratePrivateKey()
+		require.NoError(t, err)
 		args := &structs.CARequest{
 			Datacenter: "dc1",
-			Config:     newConfig,
+			Config: &structs.CAConfiguration{
+				Provider: "consul",
+				Config: map[string]interface{}{
+					"LeafCertTTL":  "72h",
+					"PrivateKey":   newKey,
+					"RootCert":     "",
+					"SkipValidate": true,
+				},
+				RootPruneInterval: structs.MinRootPruneInterval,
+				MaxRetainedRoots:  3,
+			},
 		}
 		var reply interface{}
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.ConfigurationSet", args, &reply))
 
-		require.NoError(msgpackrpc.CallWithCodec(codec, "ConnectCA.ConfigurationSet", args, &reply))
+		_, active, err := s1.fsm.State().CARootActive(nil)
+		require.NoError(t, err)
+		rootIDs = append(rootIDs, active.ID)
 	}
 
-	// Should have 2 roots now.
-	_, roots, err := s1.fsm.State().CARoots(nil)
-	require.NoError(err)
-	require.Len(roots, 2)
+	// Only the active root and the two most recently rotated out should be
+	// kept.
+	retry.RunWith(&retry.Timer{Timeout: 3 * structs.MinRootPruneInterval, Wait: 500 * time.Millisecond}, t, func(r *retry.R) {
+		_, roots, err := s1.fsm.State().CARoots(nil)
+		require.NoError(r, err)
+		require.Len(r, roots, 3)
 
-	time.Sleep(2 * time.Second)
+		var ids []string
+		for _, root := range roots {
+			ids = append(ids, root.ID)
+			require.Equal(r, root.ID == rootIDs[4], root.Active)
+		}
+		require.ElementsMatch(r, rootIDs[2:], ids)
+
+		// Private keys are only retained for the rotated out roots that
+		// weren't pruned.
+		for i, id := range rootIDs {
+			_, retained, err := s1.fsm.State().CAProviderState(ca.RetainedRootKeyStateID(id))
+			require.NoError(r, err)
+			require.Equal(r, i == 2 || i == 3, retained != nil, "root %d", i)
+		}
+	})
+}
 
-	// Now the old root should be pruned.
-	_, roots, err = s1.fsm.State().CARoots(nil)
-	require.NoError(err)
-	require.Len(roots, 1)
-	require.True(roots[0].Active)
-	require.NotEqual(roots[0].ID, oldRoot.ID)
+func TestLeader_CARootPruneInterval_Validate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	dir1, s1 := testServer(t)
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	testrpc.WaitForTestAgent(t, s1.RPC, "dc1")
+
+	args := &structs.CARequest{
+		Datacenter: "dc1",
+		Config: &structs.CAConfiguration{
+			Provider:          "consul",
+			Config:            s1.config.CAConfig.Config,
+			RootPruneInterval: time.Second,
+		},
+	}
+	var reply interface{}
+	err := msgpackrpc.CallWithCodec(codec, "ConnectCA.ConfigurationSet", args, &reply)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "root prune interval must be greater or equal than")
+
+	// Changing only the interval should be persisted.
+	args.Config.RootPruneInterval = 10 * time.Minute
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.ConfigurationSet", args, &reply))
+
+	_, conf, err := s1.fsm.State().CAConfig(nil)
+	require.NoError(t, err)
+	require.Equal(t, 10*time.Minute, conf.RootPruneInterval)
 }
 
 func TestLeader_PersistIntermediateCAs(t *testing.T) {
@@ -1323,6 +2063,93 @@ func TestLeader_PersistIntermediateCAs(t *testing.T) {
 	})
 }
 
+func TestLeader_CAActiveRootChangedEvent(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for testing.Short")
+	}
+
+	t.Parallel()
+
+	dir1, s1 := testServerWithConfig(t, func(c *Config) {
+		c.RPCConfig.EnableStreaming = true
+	})
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	testrpc.WaitForActiveCARoot(t, s1.RPC, "dc1", nil)
+
+	dir2, s2 := testServerWithConfig(t, func(c *Config) {
+		c.Datacenter = "dc2"
+		c.PrimaryDatacenter = "dc1"
+		c.RPCConfig.EnableStreaming = true
+	})
+	defer os.RemoveAll(dir2)
+	defer s2.Shutdown()
+
+	joinWAN(t, s2, s1)
+	testrpc.WaitForLeader(t, s2.RPC, "dc2")
+	testrpc.WaitForActiveCARoot(t, s2.RPC, "dc2", nil)
+
+	subscribe := func(s *Server) *stream.Subscription {
+		sub, err := s.fsm.State().EventPublisher().Subscribe(&stream.SubscribeRequest{
+			Topic: state.TopicCAActiveRoot,
+		})
+		require.NoError(t, err)
+		return sub
+	}
+	sub1 := subscribe(s1)
+	defer sub1.Unsubscribe()
+	sub2 := subscribe(s2)
+	defer sub2.Unsubscribe()
+
+	_, oldRoot := getCAProviderWithLock(s1)
+	require.NotNil(t, oldRoot)
+
+	// Rotate the root in the primary with a new private key.
+	_, newKey, err := connect.GeneratePrivateKey()
+	require.NoError(t, err)
+	args := &structs.CARequest{
+		Datacenter: "dc1",
+		Config: &structs.CAConfiguration{
+			Provider: "consul",
+			Config: map[string]interface{}{
+				"PrivateKey": newKey,
+				"RootCert":   "",
+			},
+		},
+	}
+	var reply interface{}
+	require.NoError(t, s1.RPC("ConnectCA.ConfigurationSet", args, &reply))
+
+	_, newRoot := getCAProviderWithLock(s1)
+	require.NotEqual(t, oldRoot.ID, newRoot.ID)
+
+	expected := state.EventPayloadCAActiveRootChanged{
+		OldRootID: oldRoot.ID,
+		NewRootID: newRoot.ID,
+		Reason:    structs.CARootChangeRotation,
+	}
+	require.Equal(t, expected, nextActiveRootChanged(t, sub1))
+
+	// The secondary picks up the rotation from the primary's roots.
+	require.Equal(t, expected, nextActiveRootChanged(t, sub2))
+}
+
+// nextActiveRootChanged returns the payload of the next CA active root change
+// event from sub, skipping framing events.
+func nextActiveRootChanged(t *testing.T, sub *stream.Subscription) state.EventPayloadCAActiveRootChanged {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for {
+		event, err := sub.Next(ctx)
+		require.NoError(t, err)
+		if payload, ok := event.Payload.(state.EventPayloadCAActiveRootChanged); ok {
+			return payload
+		}
+	}
+}
+
 func TestLeader_ParseCARoot(t *testing.T) {
 	type test struct {
 		name             string
@@ -1392,8 +2219,108 @@ func TestLeader_ParseCARoot(t *testing.T) {
 			require.Equal(strings.ToLower(tt.wantSigningKeyID), root.SigningKeyID)
 			require.Equal(tt.wantKeyType, root.PrivateKeyType)
 			require.Equal(tt.wantKeyBits, root.PrivateKeyBits)
+			require.Nil(root.SigningKeyIDChain)
+			require.False(root.SerialTruncated)
+		})
+	}
+}
+
+func TestLeader_ParseCARoot_SerialTruncated(t *testing.T) {
+	// An externally generated cert with a 128 bit serial number,
+	// 1F:2E:3D:4C:5B:6A:79:88:01:23:45:67:89:AB:CD:EF according to
+	// `openssl x509 -noout -serial`.
+	pem := readTestData(t, "cert-with-large-serial.pem")
+
+	root, err := parseCARoot(pem, "consul", "cluster")
+	require.NoError(t, err)
+	require.True(t, root.SerialTruncated)
+	// Only the low 64 bits, 0x0123456789ABCDEF, are kept.
+	require.Equal(t, uint64(0x0123456789ABCDEF), root.SerialNumber)
+
+	// DescribeRoot still reports the full serial.
+	desc, err := describeCARoot(pem)
+	require.NoError(t, err)
+	require.Equal(t, "1f:2e:3d:4c:5b:6a:79:88:01:23:45:67:89:ab:cd:ef", desc.SerialNumber)
+}
+
+func TestLeader_ParseCARoot_SigningKeyIDChain(t *testing.T) {
+	pem := readTestData(t, "cert-with-ec-256-key.pem") + "\n" + readTestData(t, "cert-with-ec-384-key.pem")
+
+	root, err := parseCARoot(pem, "consul", "cluster")
+	require.NoError(t, err)
+
+	// SigningKeyID is still derived from the first cert only.
+	first := "97:4d:17:81:64:f8:b4:af:05:e8:6c:79:c5:40:3b:0e:3e:8b:c0:ae:38:51:54:8a:2f:05:db:e3:e8:e4:24:ec"
+	second := "0b:a0:88:9b:dc:95:31:51:2e:3d:d4:f9:42:d0:6a:a0:62:46:82:d2:7c:22:e7:29:a9:aa:e8:a5:8c:cf:c7:42"
+	require.Equal(t, first, root.SigningKeyID)
+	require.Equal(t, []string{first, second}, root.SigningKeyIDChain)
+}
+
+func TestLeader_DescribeCARoot(t *testing.T) {
+	// Expected values are from the same `openssl x509 -noout -text` reports
+	// as TestLeader_ParseCARoot. The certs are self-signed so the authority
+	// key ID is the subject key ID.
+	tests := []struct {
+		name             string
+		pem              string
+		wantSerial       string
+		wantSigningKeyID string
+		wantKeyType      string
+		wantKeyBits      int
+		wantNotBefore    time.Time
+	}{
+		{
+			name:             "default cert",
+			pem:              readTestData(t, "cert-with-ec-256-key.pem"),
+			wantSerial:       "73:C4:93:D7:DA:A5:20:35",
+			wantSigningKeyID: "97:4D:17:81:64:F8:B4:AF:05:E8:6C:79:C5:40:3B:0E:3E:8B:C0:AE:38:51:54:8A:2F:05:DB:E3:E8:E4:24:EC",
+			wantKeyType:      "ec",
+			wantKeyBits:      256,
+			wantNotBefore:    time.Date(2019, 10, 17, 11, 46, 29, 0, time.UTC),
+		},
+		{
+			name:             "ec 384 cert",
+			pem:              readTestData(t, "cert-with-ec-384-key.pem"),
+			wantSerial:       "28:BB:9A:78:BC:EA:FD:1D",
+			wantSigningKeyID: "0B:A0:88:9B:DC:95:31:51:2E:3D:D4:F9:42:D0:6A:A0:62:46:82:D2:7C:22:E7:29:A9:AA:E8:A5:8C:CF:C7:42",
+			wantKeyType:      "ec",
+			wantKeyBits:      384,
+			wantNotBefore:    time.Date(2019, 10, 17, 11, 55, 18, 0, time.UTC),
+		},
+		{
+			name:             "rsa 4096 cert",
+			pem:              readTestData(t, "cert-with-rsa-4096-key.pem"),
+			wantSerial:       "47:FA:D8:4C:4D:8A:66:D3",
+			wantSigningKeyID: "92:FA:CC:97:57:1E:31:84:A2:33:DD:9B:6A:A8:7C:FC:BE:E2:94:CA:AC:B3:33:17:39:3B:B8:67:9B:DC:C1:08",
+			wantKeyType:      "rsa",
+			wantKeyBits:      4096,
+			wantNotBefore:    time.Date(2019, 10, 17, 11, 53, 15, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc, err := describeCARoot(tt.pem)
+			require.NoError(t, err)
+
+			root, err := parseCARoot(tt.pem, "consul", "cluster")
+			require.NoError(t, err)
+			require.Equal(t, root.ID, desc.ID)
+
+			require.Equal(t, strings.ToLower(tt.wantSerial), desc.SerialNumber)
+			require.Equal(t, "CN=Test CA 1", desc.Subject)
+			require.Equal(t, "CN=Test CA 1", desc.Issuer)
+			require.Equal(t, tt.wantNotBefore, desc.NotBefore.UTC())
+			require.Equal(t, tt.wantNotBefore.AddDate(10, 0, 0), desc.NotAfter.UTC())
+			require.Equal(t, tt.wantKeyType, desc.KeyType)
+			require.Equal(t, tt.wantKeyBits, desc.KeyBits)
+			require.Equal(t, strings.ToLower(tt.wantSigningKeyID), desc.SubjectKeyID)
+			require.Equal(t, strings.ToLower(tt.wantSigningKeyID), desc.AuthorityKeyID)
+			require.Equal(t, "11111111-2222-3333-4444-555555555555.consul", desc.TrustDomain)
 		})
 	}
+
+	_, err := describeCARoot("")
+	require.Error(t, err)
 }
 
 func readTestData(t *testing.T, name string) string {
@@ -1416,6 +2343,148 @@ func TestLeader_lessThanHalfTimePassed(t *testing.T) {
 	require.True(t, lessThanHalfTimePassed(now, now.Add(-10*time.Second), now.Add(20*time.Second)))
 }
 
+func TestLeader_fractionTimePassed(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		fraction float64
+		want     bool
+	}{
+		{"half, expired", now.Add(-10 * time.Second), now.Add(-5 * time.Second), 0.5, true},
+		{"half, ends now", now.Add(-10 * time.Second), now, 0.5, true},
+		{"half, past", now.Add(-10 * time.Second), now.Add(5 * time.Second), 0.5, true},
+		{"half, exactly", now.Add(-10 * time.Second), now.Add(10 * time.Second), 0.5, true},
+		{"half, not yet", now.Add(-10 * time.Second), now.Add(20 * time.Second), 0.5, false},
+
+		{"third, expired", now.Add(-10 * time.Second), now.Add(-5 * time.Second), 0.33, true},
+		{"third, past", now.Add(-10 * time.Second), now.Add(10 * time.Second), 0.33, true},
+		{"third, exactly", now.Add(-33 * time.Second), now.Add(67 * time.Second), 0.33, true},
+		{"third, not yet", now.Add(-10 * time.Second), now.Add(30 * time.Second), 0.33, false},
+
+		{"three quarters, expired", now.Add(-10 * time.Second), now.Add(-5 * time.Second), 0.75, true},
+		{"three quarters, exactly", now.Add(-30 * time.Second), now.Add(10 * time.Second), 0.75, true},
+		{"three quarters, not yet", now.Add(-10 * time.Second), now.Add(10 * time.Second), 0.75, false},
+		{"three quarters, just started", now, now.Add(10 * time.Second), 0.75, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, fractionTimePassed(now, tc.start, tc.end, tc.fraction))
+		})
+	}
+}
+
+func TestLeader_lessThanRenewTimePassed(t *testing.T) {
+	now := time.Now()
+	notBefore := now.Add(-50 * time.Second)
+
+	// Without jitter this is the same as lessThanHalfTimePassed.
+	require.False(t, lessThanRenewTimePassed(now, notBefore, now.Add(40*time.Second), 0.5, 0, nil))
+	require.False(t, lessThanRenewTimePassed(now, notBefore, now.Add(50*time.Second), 0.5, 0, nil))
+	require.True(t, lessThanRenewTimePassed(now, notBefore, now.Add(51*time.Second), 0.5, 0, nil))
+
+	// With 20% jitter on a 100s lifetime the renewal point falls somewhere
+	// between 50s and 70s after notBefore.
+	notAfter := notBefore.Add(100 * time.Second)
+	for i := 0; i < 100; i++ {
+		seed := []byte(strconv.Itoa(i))
+		require.True(t, lessThanRenewTimePassed(notBefore.Add(49*time.Second), notBefore, notAfter, 0.5, 0.2, seed))
+		require.False(t, lessThanRenewTimePassed(notBefore.Add(70*time.Second), notBefore, notAfter, 0.5, 0.2, seed))
+	}
+
+	// The same cert always gets the same renewal point, so checking it
+	// repeatedly doesn't roll the dice again.
+	seed := []byte("serial")
+	offset := renewJitterOffset(seed, 20*time.Second)
+	for i := 0; i < 100; i++ {
+		require.Equal(t, offset, renewJitterOffset(seed, 20*time.Second))
+	}
+	renewAt := notBefore.Add(50*time.Second + offset)
+	require.True(t, lessThanRenewTimePassed(renewAt.Add(-time.Millisecond), notBefore, notAfter, 0.5, 0.2, seed))
+	require.False(t, lessThanRenewTimePassed(renewAt.Add(time.Millisecond), notBefore, notAfter, 0.5, 0.2, seed))
+
+	// Different certs are spread across the window.
+	var renewed, waited bool
+	for i := 0; i < 1000 && !(renewed && waited); i++ {
+		if lessThanRenewTimePassed(notBefore.Add(60*time.Second), notBefore, notAfter, 0.5, 0.2, []byte(strconv.Itoa(i))) {
+			waited = true
+		} else {
+			renewed = true
+		}
+	}
+	require.True(t, renewed)
+	require.True(t, waited)
+}
+
+func TestLeader_pruneExpiredIntermediates(t *testing.T) {
+	now := time.Now()
+	root := connect.TestCA(t, nil)
+
+	oldest := testIntermediateCert(t, root, now.Add(-10*time.Hour))
+	old := testIntermediateCert(t, root, now.Add(-2*time.Hour))
+	current := testIntermediateCert(t, root, now.Add(-30*time.Minute))
+
+	currentCert, err := connect.ParseCert(current)
+	require.NoError(t, err)
+	root.IntermediateCerts = []string{oldest, old, current}
+	root.SigningKeyID = connect.EncodeSigningKeyID(currentCert.SubjectKeyId)
+
+	// Nothing has been replaced for long enough.
+	keep, err := pruneExpiredIntermediates(root, 24*time.Hour, ca.CertificateTimeDriftBuffer, now)
+	require.NoError(t, err)
+	require.Equal(t, []string{oldest, old, current}, keep)
+
+	// The oldest intermediate was replaced 2h ago, the next one 30m ago.
+	keep, err = pruneExpiredIntermediates(root, time.Hour, ca.CertificateTimeDriftBuffer, now)
+	require.NoError(t, err)
+	require.Equal(t, []string{old, current}, keep)
+
+	// The signing intermediate is never pruned.
+	keep, err = pruneExpiredIntermediates(root, time.Minute, ca.CertificateTimeDriftBuffer, now)
+	require.NoError(t, err)
+	require.Equal(t, []string{current}, keep)
+
+	// Cross-signed certs for the root itself are retained.
+	root.IntermediateCerts = []string{root.RootCert, oldest, current}
+	keep, err = pruneExpiredIntermediates(root, time.Minute, ca.CertificateTimeDriftBuffer, now)
+	require.NoError(t, err)
+	require.Equal(t, []string{root.RootCert, current}, keep)
+}
+
+// testIntermediateCert returns an intermediate CA cert signed by root that
+// is valid from notBefore, taking the drift buffer into account.
+func testIntermediateCert(t *testing.T, root *structs.CARoot, notBefore time.Time) string {
+	t.Helper()
+
+	rootCert, err := connect.ParseCert(root.RootCert)
+	require.NoError(t, err)
+	rootSigner, err := connect.ParseSigner(root.SigningKey)
+	require.NoError(t, err)
+	signer, _, err := connect.GeneratePrivateKey()
+	require.NoError(t, err)
+	keyID, err := connect.KeyId(signer.Public())
+	require.NoError(t, err)
+
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "intermediate"},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		NotBefore:             notBefore.Add(-ca.CertificateTimeDriftBuffer),
+		NotAfter:              notBefore.Add(24 * time.Hour),
+		SubjectKeyId:          keyID,
+		AuthorityKeyId:        rootCert.SubjectKeyId,
+	}
+	bs, err := x509.CreateCertificate(rand.Reader, &template, rootCert, signer.Public(), rootSigner)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: bs}))
+	return buf.String()
+}
+
 func TestLeader_retryLoopBackoffHandleSuccess(t *testing.T) {
 	if testing.Short() {
 		t.Skip("too slow for testing.Short")
@@ -1445,7 +2514,7 @@ func TestLeader_retryLoopBackoffHandleSuccess(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
 			defer cancel()
 
-			retryLoopBackoffHandleSuccess(ctx, tc.loopFn, func(_ error) {}, tc.abort)
+			retryLoopBackoffHandleSuccess(ctx, defaultRetryBackoff, tc.loopFn, func(_ error) {}, tc.abort)
 			select {
 			case <-ctx.Done():
 				if !tc.timedOut {
@@ -1460,6 +2529,77 @@ func TestLeader_retryLoopBackoffHandleSuccess(t *testing.T) {
 	}
 }
 
+func TestRetryBackoff_wait(t *testing.T) {
+	backoff := retryBackoff{Min: time.Second, Max: 10 * time.Second, Multiplier: 3}
+	require.Equal(t, time.Second, backoff.wait(1))
+	require.Equal(t, 3*time.Second, backoff.wait(2))
+	require.Equal(t, 9*time.Second, backoff.wait(3))
+	require.Equal(t, 10*time.Second, backoff.wait(4))
+	require.Equal(t, 10*time.Second, backoff.wait(1000))
+
+	// The default keeps the historical schedule of doubling from 2s to 256s.
+	require.Equal(t, 2*time.Second, defaultRetryBackoff.wait(1))
+	require.Equal(t, 4*time.Second, defaultRetryBackoff.wait(2))
+	require.Equal(t, 256*time.Second, defaultRetryBackoff.wait(8))
+	require.Equal(t, 256*time.Second, defaultRetryBackoff.wait(9))
+}
+
+func TestLeader_retryLoopBackoffHandleSuccess_CustomBackoff(t *testing.T) {
+	backoff := retryBackoff{Min: 20 * time.Millisecond, Max: 80 * time.Millisecond, Multiplier: 2}
+
+	t.Run("failures back off as configured", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// The rate limiter lets the first retryBucketSize attempts through
+		// immediately, so only the backoff spaces them out.
+		var calls []time.Time
+		retryLoopBackoffHandleSuccess(ctx, backoff, func() error {
+			calls = append(calls, time.Now())
+			if len(calls) == retryBucketSize {
+				cancel()
+			}
+			return fmt.Errorf("test error")
+		}, func(_ error) {}, true)
+
+		require.Len(t, calls, retryBucketSize)
+		for i, expect := range []time.Duration{20, 40, 80, 80} {
+			waited := calls[i+1].Sub(calls[i])
+			require.True(t, waited >= expect*time.Millisecond, "attempt %d waited %s", i+1, waited)
+		}
+	})
+
+	t.Run("abort on success", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		var calls int
+		retryLoopBackoffHandleSuccess(ctx, backoff, func() error {
+			calls++
+			if calls < 3 {
+				return fmt.Errorf("test error")
+			}
+			return nil
+		}, func(_ error) {}, true)
+
+		require.NoError(t, ctx.Err())
+		require.Equal(t, 3, calls)
+	})
+}
+
+func TestCAManager_secondaryRetryBackoff(t *testing.T) {
+	manager := &CAManager{serverConf: &Config{}}
+	require.Equal(t, defaultRetryBackoff, manager.secondaryRetryBackoff())
+
+	manager.serverConf.ConnectSecondaryCARetryMinBackoff = 5 * time.Second
+	manager.serverConf.ConnectSecondaryCARetryBackoffMultiplier = 1.5
+	require.Equal(t, retryBackoff{
+		Min:        5 * time.Second,
+		Max:        defaultRetryBackoff.Max,
+		Multiplier: 1.5,
+	}, manager.secondaryRetryBackoff())
+}
+
 func TestLeader_Vault_BadCAConfigShouldntPreventLeaderEstablishment(t *testing.T) {
 	ca.SkipIfVaultNotPresent(t)
 
@@ -1617,6 +2757,62 @@ func TestLeader_Consul_ForceWithoutCrossSigning(t *testing.T) {
 	}
 }
 
+// unrelatedCrossSignProvider wraps a provider so that CrossSignCA returns a
+// cert that doesn't chain to its root.
+type unrelatedCrossSignProvider struct {
+	ca.Provider
+	crossSigned string
+}
+
+func (p *unrelatedCrossSignProvider) CrossSignCA(*x509.Certificate) (string, error) {
+	return p.crossSigned, nil
+}
+
+func TestLeader_Consul_RotationAbortsOnUnverifiableCrossSign(t *testing.T) {
+	dir1, s1 := testServer(t)
+	defer os.RemoveAll(dir1)
+	defer s1.Shutdown()
+	codec := rpcClient(t, s1)
+	defer codec.Close()
+
+	waitForLeaderEstablishment(t, s1)
+
+	_, oldRoot, err := getTestRoots(s1, "dc1")
+	require.NoError(t, err)
+	require.NotNil(t, oldRoot)
+
+	provider, root := getCAProviderWithLock(s1)
+	s1.caManager.setCAProvider(&unrelatedCrossSignProvider{
+		Provider:    provider,
+		crossSigned: connect.TestCA(t, nil).RootCert,
+	}, root)
+
+	// Update the provider config to use a new private key, which should
+	// cause a rotation.
+	_, newKey, err := connect.GeneratePrivateKey()
+	require.NoError(t, err)
+	args := &structs.CARequest{
+		Datacenter: "dc1",
+		Config: &structs.CAConfiguration{
+			Provider: "consul",
+			Config: map[string]interface{}{
+				"PrivateKey": newKey,
+				"RootCert":   "",
+			},
+		},
+	}
+	var reply interface{}
+	err = msgpackrpc.CallWithCodec(codec, "ConnectCA.ConfigurationSet", args, &reply)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not chain to the old root")
+
+	// The rotation was aborted so the old root is still the only root.
+	roots, activeRoot, err := getTestRoots(s1, "dc1")
+	require.NoError(t, err)
+	require.Len(t, roots.Roots, 1)
+	require.Equal(t, oldRoot.ID, activeRoot.ID)
+}
+
 func TestLeader_Vault_ForceWithoutCrossSigning(t *testing.T) {
 	ca.SkipIfVaultNotPresent(t)
 
@@ -81,6 +81,15 @@ func signingCAExpiryMonitor(s *Server) CertExpirationMonitor {
 }
 
 func getActiveIntermediateExpiry(s *Server) (time.Duration, error) {
+	// Ask the provider first since it can usually answer without parsing the
+	// cert. Fall back to the stored intermediate for providers that can't.
+	provider, _ := s.caManager.getCAProvider()
+	if expirer, ok := provider.(ca.IntermediateExpirer); ok {
+		if _, expiry, err := expirer.IntermediateExpiry(); err == nil {
+			return time.Until(expiry), nil
+		}
+	}
+
 	state := s.fsm.State()
 	_, root, err := state.CARootActive(nil)
 	switch {
@@ -0,0 +1,113 @@
+package consul
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+const (
+	// leafSignCacheMaxLifetimeUsed is the fraction of a cached leaf cert's
+	// lifetime after which it is no longer handed out, so that a duplicate
+	// request never gets a cert that is close to expiring.
+	leafSignCacheMaxLifetimeUsed = 0.1
+
+	// maxLeafSignCacheEntries bounds the memory used by the cache. New leaves
+	// aren't cached while it is full of unexpired entries.
+	maxLeafSignCacheEntries = 10000
+)
+
+// leafSignCache remembers recently signed leaf certs by the CSR they were
+// signed for, so that identical CSRs submitted in a short window, such as by
+// sidecars restarting together, are only signed once.
+type leafSignCache struct {
+	lock    sync.Mutex
+	entries map[string]leafSignCacheEntry
+	// gen is incremented by flush, so a leaf signed before a flush isn't
+	// added after it.
+	gen uint64
+}
+
+type leafSignCacheEntry struct {
+	cert    *structs.IssuedCert
+	expires time.Time
+}
+
+// leafSignCacheKey identifies a signing request by the CSR, the requested
+// TTL and the root that is signing it, so that a rotation never serves a
+// leaf from the previous root.
+func leafSignCacheKey(csr *x509.CertificateRequest, ttl time.Duration, rootID string) string {
+	h := sha256.New()
+	h.Write(csr.Raw)
+	h.Write([]byte(strconv.FormatInt(int64(ttl), 10)))
+	h.Write([]byte(rootID))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// get returns a copy of the leaf cached for key if it is still fresh at now.
+func (c *leafSignCache) get(key string, now time.Time) *structs.IssuedCert {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	if !now.Before(entry.expires) ||
+		connect.FractionTimePassed(now, entry.cert.ValidAfter, entry.cert.ValidBefore, leafSignCacheMaxLifetimeUsed) {
+		delete(c.entries, key)
+		return nil
+	}
+	cert := *entry.cert
+	return &cert
+}
+
+// generation returns the current generation of the cache, to be passed to add
+// for a leaf signed after it was read.
+func (c *leafSignCache) generation() uint64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.gen
+}
+
+// add caches cert under key for ttl from now, unless the cache was flushed
+// since gen was read. Expired entries are pruned first to keep the cache
+// small.
+func (c *leafSignCache) add(key string, gen uint64, cert *structs.IssuedCert, now time.Time, ttl time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if gen != c.gen {
+		return
+	}
+
+	for k, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	if len(c.entries) >= maxLeafSignCacheEntries {
+		return
+	}
+	if c.entries == nil {
+		c.entries = make(map[string]leafSignCacheEntry)
+	}
+	stored := *cert
+	c.entries[key] = leafSignCacheEntry{cert: &stored, expires: now.Add(ttl)}
+}
+
+// flush drops every cached leaf. It is called when a change that the cache key
+// doesn't cover, such as a revocation or a CA config change that keeps the
+// root, could make a cached leaf wrong.
+func (c *leafSignCache) flush() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.entries = nil
+	c.gen++
+}
@@ -0,0 +1,52 @@
+package consul
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestLeafSignCache(t *testing.T) {
+	now := time.Now()
+	csr := &x509.CertificateRequest{Raw: []byte("csr")}
+	key := leafSignCacheKey(csr, 0, "root-1")
+	cert := &structs.IssuedCert{
+		SerialNumber: "01",
+		ValidAfter:   now.Add(-time.Minute),
+		ValidBefore:  now.Add(time.Hour),
+	}
+
+	var cache leafSignCache
+	require.Nil(t, cache.get(key, now))
+
+	cache.add(key, cache.generation(), cert, now, time.Minute)
+	require.Equal(t, cert, cache.get(key, now.Add(30*time.Second)))
+
+	// The key covers the TTL and root so they never share a cached leaf.
+	require.Nil(t, cache.get(leafSignCacheKey(csr, time.Hour, "root-1"), now))
+	require.Nil(t, cache.get(leafSignCacheKey(csr, 0, "root-2"), now))
+
+	// Entries expire after the cache TTL.
+	require.Nil(t, cache.get(key, now.Add(time.Minute)))
+
+	// A leaf is not handed out once too much of its lifetime has passed,
+	// even within the cache TTL.
+	cache.add(key, cache.generation(), cert, now, time.Hour)
+	require.NotNil(t, cache.get(key, now.Add(time.Minute)))
+	require.Nil(t, cache.get(key, now.Add(10*time.Minute)))
+
+	// Flushing drops every entry, and a leaf signed before the flush isn't
+	// added after it.
+	gen := cache.generation()
+	cache.add(key, gen, cert, now, time.Hour)
+	cache.flush()
+	require.Nil(t, cache.get(key, now))
+	cache.add(key, gen, cert, now, time.Hour)
+	require.Nil(t, cache.get(key, now))
+	cache.add(key, cache.generation(), cert, now, time.Hour)
+	require.NotNil(t, cache.get(key, now))
+}
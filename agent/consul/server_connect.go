@@ -2,6 +2,9 @@ package consul
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/go-memdb"
 
@@ -54,7 +57,9 @@ func (s *Server) getCARoots(ws memdb.WatchSet, state *state.Store) (*structs.Ind
 			ID:                  r.ID,
 			Name:                r.Name,
 			SerialNumber:        r.SerialNumber,
+			SerialTruncated:     r.SerialTruncated,
 			SigningKeyID:        r.SigningKeyID,
+			SigningKeyIDChain:   structs.CloneStringSlice(r.SigningKeyIDChain),
 			ExternalTrustDomain: r.ExternalTrustDomain,
 			NotBefore:           r.NotBefore,
 			NotAfter:            r.NotAfter,
@@ -64,12 +69,135 @@ func (s *Server) getCARoots(ws memdb.WatchSet, state *state.Store) (*structs.Ind
 			Active:              r.Active,
 			PrivateKeyType:      r.PrivateKeyType,
 			PrivateKeyBits:      r.PrivateKeyBits,
+
+			SupportsCrossSigning: r.SupportsCrossSigning,
 		}
 
 		if r.Active {
 			indexedRoots.ActiveRootID = r.ID
+			indexedRoots.SupportsCrossSigning = r.SupportsCrossSigning
 		}
 	}
 
+	if config != nil {
+		indexedRoots.AdditionalTrustDomains = additionalTrustDomains(config, indexedRoots.Roots)
+	}
+
 	return indexedRoots, nil
 }
+
+// additionalTrustDomains returns the distinct trust domains of roots that were
+// trusted under a different cluster ID than the current one. These roots are
+// left behind when a cluster migrates trust domain and are kept until pruned,
+// which bounds how long their domains stay accepted.
+func additionalTrustDomains(config *structs.CAConfiguration, roots structs.CARoots) []string {
+	var domains []string
+	seen := make(map[string]bool)
+	for _, r := range roots {
+		if r.Active || r.ExternalTrustDomain == "" || r.ExternalTrustDomain == config.ClusterID {
+			continue
+		}
+		domain := connect.SpiffeIDSigningForCluster(&structs.CAConfiguration{ClusterID: r.ExternalTrustDomain}).Host()
+		if seen[domain] {
+			continue
+		}
+		seen[domain] = true
+		domains = append(domains, domain)
+	}
+	return domains
+}
+
+// waitForCARootPropagation blocks until every other datacenter known to the
+// router reports rootID as its active CA root, meaning its intermediate has
+// been re-signed by that root. It returns an error naming the datacenters
+// that haven't caught up once timeout elapses.
+func (s *Server) waitForCARootPropagation(rootID string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+
+	var pending []string
+	for _, dc := range s.router.GetDatacenters() {
+		if dc != s.config.Datacenter {
+			pending = append(pending, dc)
+		}
+	}
+
+	// Track each datacenter's roots index so the queries block until its
+	// roots change.
+	indexes := make(map[string]uint64, len(pending))
+	for len(pending) > 0 {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			sort.Strings(pending)
+			return fmt.Errorf("CA configuration was applied but timed out waiting for the new root to reach datacenters: %s",
+				strings.Join(pending, ", "))
+		}
+
+		dc := pending[0]
+		args := structs.DCSpecificRequest{
+			Datacenter: dc,
+			QueryOptions: structs.QueryOptions{
+				MinQueryIndex: indexes[dc],
+				MaxQueryTime:  remaining,
+			},
+		}
+		var roots structs.IndexedCARoots
+		if err := s.forwardDC("ConnectCA.Roots", dc, &args, &roots); err != nil {
+			// The datacenter may be briefly unreachable, so keep trying
+			// until the deadline.
+			s.logger.Debug("failed to check CA roots of datacenter", "datacenter", dc, "error", err)
+			select {
+			case <-time.After(time.Second):
+			case <-s.shutdownCh:
+				return fmt.Errorf("server is shutting down")
+			}
+			continue
+		}
+
+		if roots.ActiveRootID == rootID {
+			pending = pending[1:]
+			continue
+		}
+		indexes[dc] = nextIndexVal(indexes[dc], roots.Index)
+	}
+	return nil
+}
+
+// caFederationStatus compares the CA roots of every other datacenter known to
+// the router with the primary's roots. A datacenter that can't be reached is
+// reported with its error rather than failing the whole check.
+func (s *Server) caFederationStatus(primary *structs.IndexedCARoots) *structs.CAFederationStatus {
+	status := &structs.CAFederationStatus{
+		ActiveRootID: primary.ActiveRootID,
+		Consistent:   true,
+	}
+	for _, dc := range s.router.GetDatacenters() {
+		if dc == s.config.Datacenter {
+			continue
+		}
+
+		dcStatus := structs.CADatacenterRootStatus{Datacenter: dc}
+		args := structs.DCSpecificRequest{Datacenter: dc}
+		var roots structs.IndexedCARoots
+		if err := s.forwardDC("ConnectCA.Roots", dc, &args, &roots); err != nil {
+			dcStatus.Error = err.Error()
+			status.Consistent = false
+			status.Datacenters = append(status.Datacenters, dcStatus)
+			continue
+		}
+
+		dcStatus.ActiveRootID = roots.ActiveRootID
+		dcStatus.StaleActive = roots.ActiveRootID != primary.ActiveRootID
+		dcStatus.MissingRoot = true
+		for _, root := range roots.Roots {
+			if root.ID == primary.ActiveRootID {
+				dcStatus.MissingRoot = false
+				break
+			}
+		}
+		if dcStatus.StaleActive || dcStatus.MissingRoot {
+			status.Consistent = false
+		}
+		status.Datacenters = append(status.Datacenters, dcStatus)
+	}
+	return status
+}
@@ -2,6 +2,8 @@ package state
 
 import (
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/hashicorp/go-memdb"
 	"github.com/pkg/errors"
@@ -15,6 +17,8 @@ const (
 	tableConnectCAConfig        = "connect-ca-config"
 	tableConnectCARoots         = "connect-ca-roots"
 	tableConnectCALeafCerts     = "connect-ca-leaf-certs"
+	tableConnectCARevoked       = "connect-ca-revoked"
+	tableConnectCAStateHistory  = "connect-ca-state-history"
 )
 
 // caBuiltinProviderTableSchema returns a new table schema used for storing
@@ -74,6 +78,43 @@ func caRootTableSchema() *memdb.TableSchema {
 	}
 }
 
+// caRevokedTableSchema returns a new table schema used for storing
+// revoked leaf certificates for Connect.
+func caRevokedTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: tableConnectCARevoked,
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field:     "SerialNumber",
+					Lowercase: true,
+				},
+			},
+		},
+	}
+}
+
+// caStateHistoryTableSchema returns a new table schema used for storing the
+// provider state of roots that have been rotated out.
+func caStateHistoryTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: tableConnectCAStateHistory,
+		Indexes: map[string]*memdb.IndexSchema{
+			"id": {
+				Name:         "id",
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "RootID",
+				},
+			},
+		},
+	}
+}
+
 // CAConfig is used to pull the CA config from the snapshot.
 func (s *Snapshot) CAConfig() (*structs.CAConfiguration, error) {
 	c, err := s.tx.First(tableConnectCAConfig, "id")
@@ -499,3 +540,231 @@ func (s *Store) CAIncrementProviderSerialNumber(idx uint64) (uint64, error) {
 	err = tx.Commit()
 	return next, err
 }
+
+// CARevokedCerts is used to pull all the revoked leaf certs for the snapshot.
+func (s *Snapshot) CARevokedCerts() ([]*structs.CARevokedCert, error) {
+	ixns, err := s.tx.Get(tableConnectCARevoked, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*structs.CARevokedCert
+	for wrapped := ixns.Next(); wrapped != nil; wrapped = ixns.Next() {
+		ret = append(ret, wrapped.(*structs.CARevokedCert))
+	}
+
+	return ret, nil
+}
+
+// CARevokedCert is used when restoring from a snapshot.
+func (s *Restore) CARevokedCert(cert *structs.CARevokedCert) error {
+	if err := s.tx.Insert(tableConnectCARevoked, cert); err != nil {
+		return fmt.Errorf("failed restoring revoked CA cert: %s", err)
+	}
+	if err := indexUpdateMaxTxn(s.tx, cert.ModifyIndex, tableConnectCARevoked); err != nil {
+		return fmt.Errorf("failed updating index: %s", err)
+	}
+
+	return nil
+}
+
+// CARevokedCerts returns the list of all revoked leaf certs.
+func (s *Store) CARevokedCerts(ws memdb.WatchSet) (uint64, []*structs.CARevokedCert, error) {
+	tx := s.db.Txn(false)
+	defer tx.Abort()
+
+	// Get the index
+	idx := maxIndexTxn(tx, tableConnectCARevoked)
+
+	// Get all
+	iter, err := tx.Get(tableConnectCARevoked, "id")
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed revoked CA cert lookup: %s", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	var results []*structs.CARevokedCert
+	for v := iter.Next(); v != nil; v = iter.Next() {
+		results = append(results, v.(*structs.CARevokedCert))
+	}
+	return idx, results, nil
+}
+
+// CARevokedCert returns the revoked leaf cert with the given serial number,
+// or nil if it hasn't been revoked.
+func (s *Store) CARevokedCert(ws memdb.WatchSet, serialNumber string) (uint64, *structs.CARevokedCert, error) {
+	tx := s.db.Txn(false)
+	defer tx.Abort()
+
+	// Get the index
+	idx := maxIndexTxn(tx, tableConnectCARevoked)
+
+	watchCh, cert, err := tx.FirstWatch(tableConnectCARevoked, "id", serialNumber)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed revoked CA cert lookup: %s", err)
+	}
+	ws.Add(watchCh)
+
+	if cert == nil {
+		return idx, nil, nil
+	}
+	return idx, cert.(*structs.CARevokedCert), nil
+}
+
+// CARevokeCert is used to add a leaf cert to the revocation list. Revoking a
+// cert that is already revoked is a no-op.
+func (s *Store) CARevokeCert(idx uint64, cert *structs.CARevokedCert) error {
+	tx := s.db.WriteTxn(idx)
+	defer tx.Abort()
+
+	existing, err := tx.First(tableConnectCARevoked, "id", cert.SerialNumber)
+	if err != nil {
+		return fmt.Errorf("failed revoked CA cert lookup: %s", err)
+	}
+	if existing != nil {
+		return nil
+	}
+
+	cert.CreateIndex = idx
+	cert.ModifyIndex = idx
+	if err := tx.Insert(tableConnectCARevoked, cert); err != nil {
+		return fmt.Errorf("failed revoking CA cert: %s", err)
+	}
+	if err := tx.Insert(tableIndex, &IndexEntry{tableConnectCARevoked, idx}); err != nil {
+		return fmt.Errorf("failed updating index: %s", err)
+	}
+
+	return tx.Commit()
+}
+
+// CAProviderStateHistory is used to pull the provider state history for the
+// snapshot.
+func (s *Snapshot) CAProviderStateHistory() ([]*structs.CAProviderStateSnapshot, error) {
+	iter, err := s.tx.Get(tableConnectCAStateHistory, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*structs.CAProviderStateSnapshot
+	for wrapped := iter.Next(); wrapped != nil; wrapped = iter.Next() {
+		ret = append(ret, wrapped.(*structs.CAProviderStateSnapshot))
+	}
+
+	return ret, nil
+}
+
+// CAProviderStateSnapshot is used when restoring from a snapshot.
+func (s *Restore) CAProviderStateSnapshot(snap *structs.CAProviderStateSnapshot) error {
+	if err := s.tx.Insert(tableConnectCAStateHistory, snap); err != nil {
+		return fmt.Errorf("failed restoring CA provider state history: %s", err)
+	}
+	if err := indexUpdateMaxTxn(s.tx, snap.ModifyIndex, tableConnectCAStateHistory); err != nil {
+		return fmt.Errorf("failed updating index: %s", err)
+	}
+
+	return nil
+}
+
+// CAProviderStateHistory returns the recorded provider state of rotated out
+// roots, ordered from oldest to newest.
+func (s *Store) CAProviderStateHistory(ws memdb.WatchSet) (uint64, []*structs.CAProviderStateSnapshot, error) {
+	tx := s.db.Txn(false)
+	defer tx.Abort()
+
+	idx := maxIndexTxn(tx, tableConnectCAStateHistory)
+
+	iter, err := tx.Get(tableConnectCAStateHistory, "id")
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed CA provider state history lookup: %s", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	var results []*structs.CAProviderStateSnapshot
+	for v := iter.Next(); v != nil; v = iter.Next() {
+		results = append(results, v.(*structs.CAProviderStateSnapshot))
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].ModifyIndex < results[j].ModifyIndex
+	})
+	return idx, results, nil
+}
+
+// CAAddProviderStateSnapshot records the provider state of a root that has
+// been rotated out. Once there are more than structs.CAProviderStateHistoryLimit
+// entries the oldest are removed.
+func (s *Store) CAAddProviderStateSnapshot(idx uint64, snap *structs.CAProviderStateSnapshot) error {
+	tx := s.db.WriteTxn(idx)
+	defer tx.Abort()
+
+	existing, err := tx.First(tableConnectCAStateHistory, "id", snap.RootID)
+	if err != nil {
+		return fmt.Errorf("failed CA provider state history lookup: %s", err)
+	}
+	if existing != nil {
+		snap.CreateIndex = existing.(*structs.CAProviderStateSnapshot).CreateIndex
+	} else {
+		snap.CreateIndex = idx
+	}
+	snap.ModifyIndex = idx
+	if err := tx.Insert(tableConnectCAStateHistory, snap); err != nil {
+		return fmt.Errorf("failed inserting CA provider state history: %s", err)
+	}
+
+	iter, err := tx.Get(tableConnectCAStateHistory, "id")
+	if err != nil {
+		return fmt.Errorf("failed CA provider state history lookup: %s", err)
+	}
+	var history []*structs.CAProviderStateSnapshot
+	for v := iter.Next(); v != nil; v = iter.Next() {
+		history = append(history, v.(*structs.CAProviderStateSnapshot))
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].ModifyIndex < history[j].ModifyIndex
+	})
+	for len(history) > structs.CAProviderStateHistoryLimit {
+		if err := tx.Delete(tableConnectCAStateHistory, history[0]); err != nil {
+			return fmt.Errorf("failed pruning CA provider state history: %s", err)
+		}
+		history = history[1:]
+	}
+
+	if err := tx.Insert(tableIndex, &IndexEntry{tableConnectCAStateHistory, idx}); err != nil {
+		return fmt.Errorf("failed updating index: %s", err)
+	}
+
+	return tx.Commit()
+}
+
+// CAPruneRevokedCerts removes the revoked leaf certs that expired before the
+// given time, since they no longer need to be listed in a CRL.
+func (s *Store) CAPruneRevokedCerts(idx uint64, before time.Time) error {
+	tx := s.db.WriteTxn(idx)
+	defer tx.Abort()
+
+	iter, err := tx.Get(tableConnectCARevoked, "id")
+	if err != nil {
+		return fmt.Errorf("failed revoked CA cert lookup: %s", err)
+	}
+
+	var expired []*structs.CARevokedCert
+	for v := iter.Next(); v != nil; v = iter.Next() {
+		cert := v.(*structs.CARevokedCert)
+		if cert.ExpiresAt().Before(before) {
+			expired = append(expired, cert)
+		}
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+
+	for _, cert := range expired {
+		if err := tx.Delete(tableConnectCARevoked, cert); err != nil {
+			return fmt.Errorf("failed pruning revoked CA cert: %s", err)
+		}
+	}
+	if err := tx.Insert(tableIndex, &IndexEntry{tableConnectCARevoked, idx}); err != nil {
+		return fmt.Errorf("failed updating index: %s", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,147 @@
+package state
+
+import (
+	"github.com/hashicorp/consul/acl"
+	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/agent/consul/stream"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+// EventPayloadCARoots is used as the Payload for a stream.Event to indicate
+// changes to the set of trusted CA roots. Value always contains the full set.
+//
+// The CARoot values are the ones stored in memdb and must not be modified.
+type EventPayloadCARoots struct {
+	Value *structs.IndexedCARoots
+}
+
+// HasReadPermission always returns true because CA roots are public, in the
+// same way as the ConnectCA.Roots endpoint.
+func (e EventPayloadCARoots) HasReadPermission(acl.Authorizer) bool {
+	return true
+}
+
+// MatchesKey always returns true because there is only one set of roots.
+func (e EventPayloadCARoots) MatchesKey(_, _, _ string) bool {
+	return true
+}
+
+// CARootsEventsFromChanges returns an event containing the current set of CA
+// roots if the changes touched the CA roots table.
+func CARootsEventsFromChanges(tx ReadTxn, changes Changes) ([]stream.Event, error) {
+	for _, change := range changes.Changes {
+		if change.Table != tableConnectCARoots {
+			continue
+		}
+
+		roots, err := indexedCARootsTxn(tx)
+		if err != nil {
+			return nil, err
+		}
+		return []stream.Event{{
+			Topic:   topicCARoots,
+			Index:   changes.Index,
+			Payload: EventPayloadCARoots{Value: roots},
+		}}, nil
+	}
+	return nil, nil
+}
+
+// caRootsSnapshot returns a stream.SnapshotFunc that provides a snapshot
+// containing the current set of CA roots.
+func caRootsSnapshot(db ReadDB) stream.SnapshotFunc {
+	return func(_ stream.SubscribeRequest, buf stream.SnapshotAppender) (uint64, error) {
+		tx := db.ReadTxn()
+		defer tx.Abort()
+
+		roots, err := indexedCARootsTxn(tx)
+		if err != nil {
+			return 0, err
+		}
+		if roots.Index == 0 {
+			// The CA has not been initialized yet.
+			return 0, nil
+		}
+
+		buf.Append([]stream.Event{{
+			Topic:   topicCARoots,
+			Index:   roots.Index,
+			Payload: EventPayloadCARoots{Value: roots},
+		}})
+		return roots.Index, nil
+	}
+}
+
+// caActiveRootTopic is the topic for EventPayloadCAActiveRootChanged. It is
+// internal to the server and not exposed through the subscribe endpoint.
+type caActiveRootTopic struct{}
+
+func (caActiveRootTopic) String() string {
+	return "CAActiveRoot"
+}
+
+// TopicCAActiveRoot is the topic the CA manager publishes to whenever it
+// swaps the active CA root.
+var TopicCAActiveRoot stream.Topic = caActiveRootTopic{}
+
+// EventPayloadCAActiveRootChanged is used as the Payload for a stream.Event
+// published by the leader when it starts signing with a different active
+// root. Unlike the CARoots topic these events are not derived from state
+// changes, so Reason records what triggered the swap.
+type EventPayloadCAActiveRootChanged struct {
+	OldRootID string
+	NewRootID string
+	Reason    structs.CARootChangeReason
+}
+
+// HasReadPermission returns true if the token may read operator data, which
+// is the permission required to inspect the CA configuration.
+func (e EventPayloadCAActiveRootChanged) HasReadPermission(authz acl.Authorizer) bool {
+	return authz.OperatorRead(nil) == acl.Allow
+}
+
+// MatchesKey always returns true because there is only one active root.
+func (e EventPayloadCAActiveRootChanged) MatchesKey(_, _, _ string) bool {
+	return true
+}
+
+// caActiveRootSnapshot returns a stream.SnapshotFunc for TopicCAActiveRoot.
+// Root changes are transient so the snapshot is always empty; it only
+// positions new subscribers at the current roots index.
+func caActiveRootSnapshot(db ReadDB) stream.SnapshotFunc {
+	return func(_ stream.SubscribeRequest, _ stream.SnapshotAppender) (uint64, error) {
+		tx := db.ReadTxn()
+		defer tx.Abort()
+
+		idx, _, err := caRootsTxn(tx, nil)
+		return idx, err
+	}
+}
+
+func indexedCARootsTxn(tx ReadTxn) (*structs.IndexedCARoots, error) {
+	confIdx, config, err := caConfigTxn(tx, nil)
+	if err != nil {
+		return nil, err
+	}
+	rootsIdx, roots, err := caRootsTxn(tx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &structs.IndexedCARoots{Roots: roots}
+	result.Index = rootsIdx
+	if confIdx > result.Index {
+		result.Index = confIdx
+	}
+	if config != nil {
+		if signingID := connect.SpiffeIDSigningForCluster(config); signingID != nil {
+			result.TrustDomain = signingID.Host()
+		}
+	}
+	for _, r := range roots {
+		if r.Active {
+			result.ActiveRootID = r.ID
+		}
+	}
+	return result, nil
+}
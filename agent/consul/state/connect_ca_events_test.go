@@ -0,0 +1,76 @@
+package state
+
+import (
+	"testing"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/consul/agent/connect"
+	"github.com/hashicorp/consul/agent/consul/stream"
+	"github.com/hashicorp/consul/agent/structs"
+)
+
+func TestCARootsSnapshot(t *testing.T) {
+	store := NewStateStore(nil)
+	fn := caRootsSnapshot((*readDB)(store.db.db))
+
+	// Nothing is sent before the CA is initialized.
+	buf := &snapshotAppender{}
+	idx, err := fn(stream.SubscribeRequest{}, buf)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), idx)
+	require.Empty(t, buf.events)
+
+	require.NoError(t, store.CASetConfig(1, &structs.CAConfiguration{
+		ClusterID: connect.TestClusterID,
+		Provider:  "consul",
+	}))
+	root := connect.TestCA(t, nil)
+	ok, err := store.CARootSetCAS(2, 0, []*structs.CARoot{root})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	buf = &snapshotAppender{}
+	idx, err = fn(stream.SubscribeRequest{}, buf)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), idx)
+	require.Len(t, buf.events, 1)
+	require.Len(t, buf.events[0], 1)
+
+	event := buf.events[0][0]
+	require.Equal(t, topicCARoots, event.Topic)
+	require.Equal(t, uint64(2), event.Index)
+	payload := event.Payload.(EventPayloadCARoots)
+	require.Equal(t, root.ID, payload.Value.ActiveRootID)
+	require.Equal(t, connect.TestClusterID+".consul", payload.Value.TrustDomain)
+	require.Equal(t, structs.CARoots{root}, structs.CARoots(payload.Value.Roots))
+}
+
+func TestCARootsEventsFromChanges(t *testing.T) {
+	store := NewStateStore(nil)
+	root := connect.TestCA(t, nil)
+	ok, err := store.CARootSetCAS(1, 0, []*structs.CARoot{root})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	tx := store.db.ReadTxn()
+	defer tx.Abort()
+
+	// Unrelated changes produce no events.
+	events, err := CARootsEventsFromChanges(tx, Changes{
+		Index:   3,
+		Changes: memdb.Changes{{Table: tableConnectCAConfig}},
+	})
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	events, err = CARootsEventsFromChanges(tx, Changes{
+		Index:   3,
+		Changes: memdb.Changes{{Table: tableConnectCARoots}, {Table: tableConnectCARoots}},
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, uint64(3), events[0].Index)
+	require.Equal(t, root.ID, events[0].Payload.(EventPayloadCARoots).Value.ActiveRootID)
+}
@@ -1,8 +1,10 @@
 package state
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/consul/sdk/testutil"
 
@@ -500,3 +502,196 @@ func TestStore_CABuiltinProvider_Snapshot_Restore(t *testing.T) {
 		assert.Equal(state, res)
 	}
 }
+
+func TestStore_CARevokeCert(t *testing.T) {
+	s := testStateStore(t)
+
+	ws := memdb.NewWatchSet()
+	idx, certs, err := s.CARevokedCerts(ws)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), idx)
+	require.Empty(t, certs)
+
+	cert := &structs.CARevokedCert{SerialNumber: "0a:0b"}
+	require.NoError(t, s.CARevokeCert(5, cert))
+	require.True(t, watchFired(ws))
+
+	idx, certs, err = s.CARevokedCerts(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), idx)
+	require.Equal(t, []*structs.CARevokedCert{cert}, certs)
+	require.Equal(t, uint64(5), certs[0].CreateIndex)
+
+	// Revoking the same serial again is a no-op, regardless of case.
+	require.NoError(t, s.CARevokeCert(6, &structs.CARevokedCert{SerialNumber: "0A:0B"}))
+	idx, certs, err = s.CARevokedCerts(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), idx)
+	require.Len(t, certs, 1)
+}
+
+func TestStore_CARevokedCert(t *testing.T) {
+	s := testStateStore(t)
+
+	ws := memdb.NewWatchSet()
+	idx, cert, err := s.CARevokedCert(ws, "0a:0b")
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), idx)
+	require.Nil(t, cert)
+
+	// Revoking an unrelated cert moves the index but doesn't match.
+	require.NoError(t, s.CARevokeCert(5, &structs.CARevokedCert{SerialNumber: "01"}))
+	idx, cert, err = s.CARevokedCert(nil, "0a:0b")
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), idx)
+	require.Nil(t, cert)
+
+	revoked := &structs.CARevokedCert{SerialNumber: "0a:0b"}
+	require.NoError(t, s.CARevokeCert(6, revoked))
+	require.True(t, watchFired(ws))
+
+	idx, cert, err = s.CARevokedCert(nil, "0A:0B")
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), idx)
+	require.Equal(t, revoked, cert)
+}
+
+func TestStore_CAPruneRevokedCerts(t *testing.T) {
+	s := testStateStore(t)
+
+	now := time.Now()
+	expired := &structs.CARevokedCert{SerialNumber: "01", RevokedAt: now.Add(-2 * time.Hour), NotAfter: now.Add(-time.Hour)}
+	valid := &structs.CARevokedCert{SerialNumber: "02", RevokedAt: now.Add(-2 * time.Hour), NotAfter: now.Add(time.Hour)}
+	// Entries without NotAfter are kept for the longest leaf lifetime.
+	legacy := &structs.CARevokedCert{SerialNumber: "03", RevokedAt: now.Add(-time.Hour)}
+	for i, cert := range []*structs.CARevokedCert{expired, valid, legacy} {
+		require.NoError(t, s.CARevokeCert(uint64(5+i), cert))
+	}
+
+	// Nothing is removed if no entry expired before the given time.
+	require.NoError(t, s.CAPruneRevokedCerts(10, now.Add(-2*time.Hour)))
+	idx, certs, err := s.CARevokedCerts(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), idx)
+	require.Len(t, certs, 3)
+
+	ws := memdb.NewWatchSet()
+	_, _, err = s.CARevokedCerts(ws)
+	require.NoError(t, err)
+	require.NoError(t, s.CAPruneRevokedCerts(11, now))
+	require.True(t, watchFired(ws))
+
+	idx, certs, err = s.CARevokedCerts(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), idx)
+	require.Equal(t, []*structs.CARevokedCert{valid, legacy}, certs)
+}
+
+func TestStore_CARevokedCerts_Snapshot_Restore(t *testing.T) {
+	s := testStateStore(t)
+
+	before := []*structs.CARevokedCert{
+		{SerialNumber: "01"},
+		{SerialNumber: "02"},
+	}
+	for i, cert := range before {
+		require.NoError(t, s.CARevokeCert(uint64(98+i), cert))
+	}
+
+	// Take a snapshot.
+	snap := s.Snapshot()
+	defer snap.Close()
+
+	// Modify the state store.
+	require.NoError(t, s.CARevokeCert(100, &structs.CARevokedCert{SerialNumber: "03"}))
+
+	snapped, err := snap.CARevokedCerts()
+	require.NoError(t, err)
+	require.Equal(t, before, snapped)
+
+	// Restore onto a new state store.
+	s2 := testStateStore(t)
+	restore := s2.Restore()
+	for _, entry := range snapped {
+		require.NoError(t, restore.CARevokedCert(entry))
+	}
+	restore.Commit()
+
+	idx, res, err := s2.CARevokedCerts(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(99), idx)
+	require.Equal(t, before, res)
+}
+
+func TestStore_CAAddProviderStateSnapshot(t *testing.T) {
+	s := testStateStore(t)
+
+	for i := 0; i < structs.CAProviderStateHistoryLimit+2; i++ {
+		snap := &structs.CAProviderStateSnapshot{
+			RootID:   fmt.Sprintf("root-%d", i),
+			Provider: "consul",
+			State:    map[string]string{"n": fmt.Sprint(i)},
+		}
+		require.NoError(t, s.CAAddProviderStateSnapshot(uint64(10+i), snap))
+	}
+
+	// The two oldest entries were pruned and the rest are ordered oldest first.
+	idx, history, err := s.CAProviderStateHistory(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10+structs.CAProviderStateHistoryLimit+1), idx)
+	require.Len(t, history, structs.CAProviderStateHistoryLimit)
+	for i, snap := range history {
+		require.Equal(t, fmt.Sprintf("root-%d", i+2), snap.RootID)
+		require.Equal(t, uint64(12+i), snap.ModifyIndex)
+	}
+
+	// Recording the same root again replaces its entry and makes it the newest.
+	require.NoError(t, s.CAAddProviderStateSnapshot(50, &structs.CAProviderStateSnapshot{
+		RootID:   "root-2",
+		Provider: "vault",
+	}))
+	_, history, err = s.CAProviderStateHistory(nil)
+	require.NoError(t, err)
+	require.Len(t, history, structs.CAProviderStateHistoryLimit)
+	last := history[len(history)-1]
+	require.Equal(t, "root-2", last.RootID)
+	require.Equal(t, "vault", last.Provider)
+	require.Equal(t, uint64(12), last.CreateIndex)
+	require.Equal(t, uint64(50), last.ModifyIndex)
+}
+
+func TestStore_CAProviderStateHistory_Snapshot_Restore(t *testing.T) {
+	s := testStateStore(t)
+
+	before := []*structs.CAProviderStateSnapshot{
+		{RootID: "a", Provider: "consul", State: map[string]string{"foo": "bar"}},
+		{RootID: "b", Provider: "consul"},
+	}
+	for i, snap := range before {
+		require.NoError(t, s.CAAddProviderStateSnapshot(uint64(98+i), snap))
+	}
+
+	// Take a snapshot.
+	snap := s.Snapshot()
+	defer snap.Close()
+
+	// Modify the state store.
+	require.NoError(t, s.CAAddProviderStateSnapshot(100, &structs.CAProviderStateSnapshot{RootID: "c"}))
+
+	snapped, err := snap.CAProviderStateHistory()
+	require.NoError(t, err)
+	require.Equal(t, before, snapped)
+
+	// Restore onto a new state store.
+	s2 := testStateStore(t)
+	restore := s2.Restore()
+	for _, entry := range snapped {
+		require.NoError(t, restore.CAProviderStateSnapshot(entry))
+	}
+	restore.Commit()
+
+	idx, res, err := s2.CAProviderStateHistory(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(99), idx)
+	require.Equal(t, before, res)
+}
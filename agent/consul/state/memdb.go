@@ -181,6 +181,7 @@ func (db *readDB) ReadTxn() AbortTxn {
 var (
 	topicServiceHealth        = pbsubscribe.Topic_ServiceHealth
 	topicServiceHealthConnect = pbsubscribe.Topic_ServiceHealthConnect
+	topicCARoots              = pbsubscribe.Topic_CARoots
 )
 
 func processDBChanges(tx ReadTxn, changes Changes) ([]stream.Event, error) {
@@ -188,6 +189,7 @@ func processDBChanges(tx ReadTxn, changes Changes) ([]stream.Event, error) {
 	fns := []func(tx ReadTxn, changes Changes) ([]stream.Event, error){
 		aclChangeUnsubscribeEvent,
 		ServiceHealthEventsFromChanges,
+		CARootsEventsFromChanges,
 		// TODO: add other table handlers here.
 	}
 	for _, fn := range fns {
@@ -204,5 +206,7 @@ func newSnapshotHandlers(db ReadDB) stream.SnapshotHandlers {
 	return stream.SnapshotHandlers{
 		topicServiceHealth:        serviceHealthSnapshot(db, topicServiceHealth),
 		topicServiceHealthConnect: serviceHealthSnapshot(db, topicServiceHealthConnect),
+		topicCARoots:              caRootsSnapshot(db),
+		TopicCAActiveRoot:         caActiveRootSnapshot(db),
 	}
 }
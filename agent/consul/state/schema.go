@@ -17,7 +17,9 @@ func newDBSchema() *memdb.DBSchema {
 		bindingRulesTableSchema,
 		caBuiltinProviderTableSchema,
 		caConfigTableSchema,
+		caRevokedTableSchema,
 		caRootTableSchema,
+		caStateHistoryTableSchema,
 		checksTableSchema,
 		configTableSchema,
 		coordinatesTableSchema,
@@ -10,10 +10,12 @@ import (
 	"testing"
 	"time"
 
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
 	gogrpc "google.golang.org/grpc"
 
+	"github.com/hashicorp/consul/agent/connect"
 	grpc "github.com/hashicorp/consul/agent/grpc"
 	"github.com/hashicorp/consul/agent/grpc/resolver"
 	"github.com/hashicorp/consul/agent/router"
@@ -349,6 +351,91 @@ func TestSubscribeBackend_IntegrationWithServer_DeliversAllMessages(t *testing.T
 		"at least some of the subscribers should have received non-snapshot updates")
 }
 
+func TestSubscribeBackend_IntegrationWithServer_CARoots(t *testing.T) {
+	if testing.Short() {
+		t.Skip("too slow for -short run")
+	}
+
+	_, server := testServerWithConfig(t, func(c *Config) {
+		c.Datacenter = "dc1"
+		c.Bootstrap = true
+		c.RPCConfig.EnableStreaming = true
+	})
+	defer server.Shutdown()
+	codec := rpcClient(t, server)
+	defer codec.Close()
+
+	client, builder := newClientWithGRPCResolver(t)
+
+	testrpc.WaitForLeader(t, server.RPC, "dc1")
+	joinLAN(t, client, server)
+	testrpc.WaitForTestAgent(t, client.RPC, "dc1")
+
+	_, origRoot, err := server.fsm.State().CARootActive(nil)
+	require.NoError(t, err)
+
+	pool := grpc.NewClientConnPool(grpc.ClientConnPoolConfig{
+		Servers:               builder,
+		TLSWrapper:            grpc.TLSWrapper(client.tlsConfigurator.OutgoingRPCWrapper()),
+		UseTLSForDC:           client.tlsConfigurator.UseTLS,
+		DialingFromServer:     true,
+		DialingFromDatacenter: "dc1",
+	})
+	conn, err := pool.ClientConn("dc1")
+	require.NoError(t, err)
+
+	streamClient := pbsubscribe.NewStateChangeSubscriptionClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	streamHandle, err := streamClient.Subscribe(ctx, &pbsubscribe.SubscribeRequest{Topic: pbsubscribe.Topic_CARoots})
+	require.NoError(t, err)
+
+	eventCh := make(chan *pbsubscribe.Event, 0)
+	go receiveSubscribeEvents(t, eventCh, streamHandle)
+
+	nextEvent := func(t *testing.T) *pbsubscribe.Event {
+		select {
+		case event := <-eventCh:
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for event")
+			return nil
+		}
+	}
+
+	// The snapshot contains the current roots.
+	event := nextEvent(t)
+	roots := event.GetCARoots()
+	require.NotNil(t, roots)
+	require.Equal(t, origRoot.ID, roots.ActiveRootID)
+	require.Len(t, roots.Roots, 1)
+	require.Empty(t, roots.Roots[0].SigningKey)
+	require.True(t, nextEvent(t).GetEndOfSnapshot())
+
+	// Rotate the root.
+	_, newKey, err := connect.GeneratePrivateKey()
+	require.NoError(t, err)
+	args := &structs.CARequest{
+		Datacenter: "dc1",
+		Config: &structs.CAConfiguration{
+			Provider: "consul",
+			Config: map[string]interface{}{
+				"PrivateKey": newKey,
+				"RootCert":   "",
+			},
+		},
+	}
+	var reply interface{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ConnectCA.ConfigurationSet", args, &reply))
+
+	// The new root set is pushed with both roots.
+	event = nextEvent(t)
+	roots = event.GetCARoots()
+	require.NotNil(t, roots)
+	require.NotEqual(t, origRoot.ID, roots.ActiveRootID)
+	require.Len(t, roots.Roots, 2)
+}
+
 func newClientWithGRPCResolver(t *testing.T, ops ...func(*Config)) (*Client, *resolver.ServerResolverBuilder) {
 	_, config := testClientConfig(t)
 	for _, op := range ops {
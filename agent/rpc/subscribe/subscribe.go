@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/consul/agent/consul/state"
 	"github.com/hashicorp/consul/agent/consul/stream"
 	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto/pbconnect"
 	"github.com/hashicorp/consul/proto/pbservice"
 	"github.com/hashicorp/consul/proto/pbsubscribe"
 )
@@ -155,6 +156,10 @@ func setPayload(e *pbsubscribe.Event, payload stream.Payload) {
 				CheckServiceNode: pbservice.NewCheckServiceNodeFromStructs(p.Value),
 			},
 		}
+	case state.EventPayloadCARoots:
+		e.Payload = &pbsubscribe.Event_CARoots{
+			CARoots: pbconnect.NewCARootsFromStructs(p.Value),
+		}
 	default:
 		panic(fmt.Sprintf("unexpected payload: %T: %#v", p, p))
 	}
@@ -258,6 +258,7 @@ func getPrometheusDefs(cfg lib.TelemetryConfig, isServer bool) ([]prometheus.Gau
 		CatalogCounters,
 		cache.Counters,
 		consul.ACLCounters,
+		consul.CAManagerCounters,
 		consul.CatalogCounters,
 		consul.ClientCounters,
 		consul.RPCCounters,
@@ -303,6 +304,7 @@ func getPrometheusDefs(cfg lib.TelemetryConfig, isServer bool) ([]prometheus.Gau
 		HTTPSummaries,
 		consul.ACLSummaries,
 		consul.ACLEndpointSummaries,
+		consul.CAManagerSummaries,
 		consul.CatalogSummaries,
 		consul.FederationStateSummaries,
 		consul.IntentionSummaries,
@@ -2,7 +2,10 @@ package structs
 
 import (
 	"fmt"
+	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/mitchellh/mapstructure"
@@ -47,6 +50,21 @@ type IndexedCARoots struct {
 	// seamless rotation between trust domains thanks to cross-signing.
 	TrustDomain string
 
+	// AdditionalTrustDomains lists previous trust domains that are still
+	// accepted alongside TrustDomain. It is populated after the cluster
+	// migrates between trust domains (e.g. a former primary becoming a
+	// secondary) so that leaves issued under the old domain keep validating.
+	// A domain is listed for as long as a root carrying it as
+	// ExternalTrustDomain is still trusted, so the window ends once that root
+	// is pruned.
+	AdditionalTrustDomains []string `json:",omitempty"`
+
+	// SupportsCrossSigning is true if the active CA provider is able to
+	// cross-sign a new root during rotation. When false, rotating the root
+	// requires ForceWithoutCrossSigning to be set in the CA configuration.
+	// It mirrors the value recorded on the active root.
+	SupportsCrossSigning bool
+
 	// Roots is a list of root CA certs to trust.
 	Roots []*CARoot
 
@@ -67,11 +85,22 @@ type CARoot struct {
 	// SerialNumber is the x509 serial number of the certificate.
 	SerialNumber uint64
 
+	// SerialTruncated is true when the certificate's serial number doesn't
+	// fit in 64 bits, in which case SerialNumber only holds its low 64 bits
+	// and won't match the serial reported by other tools.
+	SerialTruncated bool `json:",omitempty"`
+
 	// SigningKeyID is the ID of the public key that corresponds to the private
 	// key used to sign leaf certificates. Is is the HexString format of the
 	// raw AuthorityKeyID bytes.
 	SigningKeyID string
 
+	// SigningKeyIDChain is the HexString format of the SubjectKeyId of each
+	// cert in RootCert, in order. It is only set when RootCert contains more
+	// than one cert and is for debugging chain issues; SigningKeyID remains
+	// the value used everywhere else.
+	SigningKeyIDChain []string `json:",omitempty"`
+
 	// ExternalTrustDomain is the trust domain this root was generated under. It
 	// is usually empty implying "the current cluster trust-domain". It is set
 	// only in the case that a cluster changes trust domain and then all old roots
@@ -111,7 +140,7 @@ type CARoot struct {
 	RotatedOutAt time.Time `json:"-"`
 
 	// PrivateKeyType is the type of the private key used to sign certificates. It
-	// may be "rsa" or "ec". This is provided as a convenience to avoid parsing
+	// may be "rsa", "ec" or "ed25519". This is provided as a convenience to avoid parsing
 	// the public key to from the certificate to infer the type.
 	PrivateKeyType string
 
@@ -120,6 +149,11 @@ type CARoot struct {
 	// certificate to infer the type.
 	PrivateKeyBits int
 
+	// SupportsCrossSigning records whether the provider that signs with this
+	// root is able to cross-sign a new root. It is set by the leader when the
+	// root is stored so that every server reports the same value.
+	SupportsCrossSigning bool
+
 	RaftIndex
 }
 
@@ -130,6 +164,7 @@ func (c *CARoot) Clone() *CARoot {
 
 	newCopy := *c
 	newCopy.IntermediateCerts = CloneStringSlice(c.IntermediateCerts)
+	newCopy.SigningKeyIDChain = CloneStringSlice(c.SigningKeyIDChain)
 	return &newCopy
 }
 
@@ -144,6 +179,16 @@ type CASignRequest struct {
 	// CSR is the PEM-encoded CSR.
 	CSR string
 
+	// TTL optionally requests a shorter lifetime for a service leaf cert. It
+	// is clamped to [MinLeafCertTTL, LeafCertTTL] by the datacenter signing the
+	// cert, using that datacenter's configured minimum if it has one. Zero
+	// uses the configured LeafCertTTL.
+	TTL time.Duration
+
+	// SourceDatacenter is the secondary datacenter requesting an intermediate
+	// from ConnectCA.SignIntermediate, so the primary can report it.
+	SourceDatacenter string `json:",omitempty"`
+
 	// WriteRequest is a common struct containing ACL tokens and other
 	// write-related common elements for requests.
 	WriteRequest
@@ -154,6 +199,476 @@ func (q *CASignRequest) RequestDatacenter() string {
 	return q.Datacenter
 }
 
+// CASignBatchRequest is the request for signing several service or agent
+// certificates in one RPC.
+type CASignBatchRequest struct {
+	// Datacenter is the target for this request.
+	Datacenter string
+
+	// CSRs are the PEM-encoded CSRs to sign.
+	CSRs []string
+
+	// TTL optionally requests a shorter lifetime for each service leaf cert
+	// in the batch, see CASignRequest.TTL.
+	TTL time.Duration
+
+	// WriteRequest is a common struct containing ACL tokens and other
+	// write-related common elements for requests.
+	WriteRequest
+}
+
+// RequestDatacenter returns the datacenter for a given request.
+func (q *CASignBatchRequest) RequestDatacenter() string {
+	return q.Datacenter
+}
+
+// CASignBatchResponse is the response to a CASignBatchRequest. Results has
+// one entry per CSR in the request, in the same order.
+type CASignBatchResponse struct {
+	Results []CASignBatchResult
+}
+
+// CASignBatchResult is the outcome of signing one CSR of a batch. Exactly
+// one of Cert and Error is set.
+type CASignBatchResult struct {
+	Cert  *IssuedCert `json:",omitempty"`
+	Error string      `json:",omitempty"`
+}
+
+// CARevokeRequest is the request for revoking a leaf certificate.
+type CARevokeRequest struct {
+	// Datacenter is the target for this request.
+	Datacenter string
+
+	// SerialNumber is the colon-hex encoded serial number of the leaf to
+	// revoke, as returned in IssuedCert.SerialNumber.
+	SerialNumber string
+
+	// NotAfter is the expiry of the leaf to revoke, as returned in
+	// IssuedCert.ValidBefore. The leaf is dropped from the revocation list
+	// once it has expired. When zero, it is assumed to be valid for
+	// MaxLeafCertTTL from now.
+	NotAfter time.Time
+
+	// WriteRequest is a common struct containing ACL tokens and other
+	// write-related common elements for requests.
+	WriteRequest
+}
+
+// RequestDatacenter returns the datacenter for a given request.
+func (q *CARevokeRequest) RequestDatacenter() string {
+	return q.Datacenter
+}
+
+// CARevokedCert is a leaf certificate that has been revoked before its
+// expiry.
+type CARevokedCert struct {
+	// SerialNumber is the colon-hex encoded serial number of the revoked cert.
+	SerialNumber string
+
+	// RevokedAt is the time the cert was revoked.
+	RevokedAt time.Time
+
+	// NotAfter is the expiry of the revoked cert. Entries stored before it
+	// was recorded have it unset.
+	NotAfter time.Time
+
+	RaftIndex
+}
+
+// ExpiresAt returns the time after which the revoked cert can no longer be
+// valid and so no longer needs to be listed. Entries without NotAfter are
+// kept for MaxLeafCertTTL after they were revoked.
+func (r *CARevokedCert) ExpiresAt() time.Time {
+	if r.NotAfter.IsZero() {
+		return r.RevokedAt.Add(MaxLeafCertTTL)
+	}
+	return r.NotAfter
+}
+
+// CARefreshIntermediateRequest is the request for
+// ConnectCA.RefreshIntermediate.
+type CARefreshIntermediateRequest struct {
+	// Datacenter is the secondary datacenter whose intermediate is refreshed.
+	Datacenter string
+
+	// WriteRequest is a common struct containing ACL tokens and other
+	// write-related common elements for requests.
+	WriteRequest
+}
+
+// RequestDatacenter returns the datacenter for a given request.
+func (q *CARefreshIntermediateRequest) RequestDatacenter() string {
+	return q.Datacenter
+}
+
+// CARefreshIntermediateResponse is the response for
+// ConnectCA.RefreshIntermediate.
+type CARefreshIntermediateResponse struct {
+	// SerialNumber is the colon-hex encoded serial number of the new
+	// intermediate.
+	SerialNumber string
+}
+
+// CAProviderStateHistoryLimit is how many CAProviderStateSnapshot entries
+// are kept before the oldest are removed.
+const CAProviderStateHistoryLimit = 10
+
+// CAProviderStateSnapshot records the provider state that was in use with a
+// root before it was rotated out.
+type CAProviderStateSnapshot struct {
+	// RootID is the ID of the root that was active with this state.
+	RootID string
+
+	// Provider is the name of the CA provider that produced State.
+	Provider string
+
+	// State is the provider state from the CA configuration at the time the
+	// root was rotated out.
+	State map[string]string
+
+	RaftIndex
+}
+
+// IndexedCAProviderStateHistory is the response for ConnectCA.StateHistory.
+type IndexedCAProviderStateHistory struct {
+	// History is ordered from oldest to newest.
+	History []*CAProviderStateSnapshot
+
+	QueryMeta
+}
+
+// CABundle is the response for ConnectCA.CABundle.
+type CABundle struct {
+	// ActiveRootID is the ID of the root the bundle was built from.
+	ActiveRootID string
+
+	// PEM holds the intermediates of the active root, newest first, followed
+	// by the root certificate, so it can be appended directly to a leaf
+	// certificate to form a chain.
+	PEM string
+
+	QueryMeta
+}
+
+// CASpiffeBundle is the response for ConnectCA.SpiffeBundle.
+type CASpiffeBundle struct {
+	// TrustDomain is the trust domain the bundle is for, the same as
+	// IndexedCARoots.TrustDomain.
+	TrustDomain string
+
+	// Bundle is the SPIFFE trust bundle JSON holding one key per root.
+	Bundle string
+
+	QueryMeta
+}
+
+// CADescribeRootRequest is the request for ConnectCA.DescribeRoot.
+type CADescribeRootRequest struct {
+	// Datacenter is the target for this request.
+	Datacenter string
+
+	// RootID is the ID of the root to describe, as returned in CARoot.ID.
+	RootID string
+
+	QueryOptions
+}
+
+// RequestDatacenter returns the datacenter for a given request.
+func (q *CADescribeRootRequest) RequestDatacenter() string {
+	return q.Datacenter
+}
+
+// CARootDescription is the response for ConnectCA.DescribeRoot. It holds the
+// fields of a root certificate decoded from its PEM.
+type CARootDescription struct {
+	// ID is the ID of the described root.
+	ID string
+
+	// SerialNumber is the colon-hex encoded serial number of the root cert.
+	// Unlike CARoot.SerialNumber it is not truncated to 64 bits.
+	SerialNumber string
+
+	// Subject and Issuer are the distinguished names of the root cert.
+	Subject string
+	Issuer  string
+
+	NotBefore time.Time
+	NotAfter  time.Time
+
+	// KeyType and KeyBits describe the root's public key.
+	KeyType string
+	KeyBits int
+
+	// SubjectKeyID and AuthorityKeyID are colon-hex encoded.
+	SubjectKeyID   string
+	AuthorityKeyID string
+
+	// TrustDomain is the host of the root's SPIFFE URI SAN, if it has one.
+	TrustDomain string
+
+	QueryMeta
+}
+
+// CARevocationList is the response for ConnectCA.CRL.
+type CARevocationList struct {
+	// CRL is the DER encoded certificate revocation list signed by the
+	// current signing certificate.
+	CRL []byte
+
+	// CRLPEM is CRL in PEM format.
+	CRLPEM string
+
+	// RevokedCerts are the entries included in CRL.
+	RevokedCerts []*CARevokedCert
+
+	QueryMeta
+}
+
+// CAOCSPRequest is the request for ConnectCA.OCSP.
+type CAOCSPRequest struct {
+	// Datacenter is the target for this request.
+	Datacenter string
+
+	// SerialNumber is the colon-hex encoded serial number of the leaf to
+	// check, as returned in IssuedCert.SerialNumber.
+	SerialNumber string
+
+	QueryOptions
+}
+
+// RequestDatacenter returns the datacenter for a given request.
+func (q *CAOCSPRequest) RequestDatacenter() string {
+	return q.Datacenter
+}
+
+// CAOCSPStatus is the revocation status of a leaf reported by ConnectCA.OCSP.
+type CAOCSPStatus string
+
+const (
+	// CAOCSPStatusGood means the leaf is not on the revocation list.
+	CAOCSPStatusGood CAOCSPStatus = "good"
+
+	// CAOCSPStatusRevoked means the leaf has been revoked.
+	CAOCSPStatusRevoked CAOCSPStatus = "revoked"
+)
+
+// CAOCSPResponse is the response for ConnectCA.OCSP.
+type CAOCSPResponse struct {
+	// SerialNumber is the normalized serial number of the leaf that was
+	// checked.
+	SerialNumber string
+
+	// Status is the revocation status of the leaf.
+	Status CAOCSPStatus
+
+	// RevokedAt is when the leaf was revoked. It is only set when Status is
+	// CAOCSPStatusRevoked.
+	RevokedAt time.Time
+
+	QueryMeta
+}
+
+// CAValidateChainRequest is the request for ConnectCA.ValidateChain.
+type CAValidateChainRequest struct {
+	// Datacenter is the target for this request.
+	Datacenter string
+
+	// LeafCert is the PEM encoded leaf certificate to validate. Any further
+	// certificates in it are used as intermediates, as they would be when a
+	// proxy presents its certificate chain.
+	LeafCert string
+
+	// IntermediateCerts are additional PEM encoded intermediates to use when
+	// building the chain.
+	IntermediateCerts []string
+
+	// ExpectedURI is the optional SPIFFE ID the leaf must have. As in the
+	// mesh, the trust domain is not compared.
+	ExpectedURI string
+
+	QueryOptions
+}
+
+// RequestDatacenter returns the datacenter for a given request.
+func (q *CAValidateChainRequest) RequestDatacenter() string {
+	return q.Datacenter
+}
+
+// CAChainErrorKind classifies why ConnectCA.ValidateChain rejected a leaf.
+type CAChainErrorKind string
+
+const (
+	// CAChainErrorExpired means a certificate in the chain is expired or not
+	// yet valid.
+	CAChainErrorExpired CAChainErrorKind = "expired"
+
+	// CAChainErrorUnknownAuthority means the leaf doesn't chain to any of
+	// the current roots.
+	CAChainErrorUnknownAuthority CAChainErrorKind = "unknown-authority"
+
+	// CAChainErrorSANMismatch means the leaf's URI SAN doesn't match the
+	// expected SPIFFE ID.
+	CAChainErrorSANMismatch CAChainErrorKind = "san-mismatch"
+
+	// CAChainErrorInvalid is any other verification failure.
+	CAChainErrorInvalid CAChainErrorKind = "invalid"
+)
+
+// CAValidateChainResponse is the response for ConnectCA.ValidateChain.
+type CAValidateChainResponse struct {
+	// Valid is true if the leaf verified against the current roots.
+	Valid bool
+
+	// RootID is the ID of the root the leaf chains to. It is only set when
+	// Valid is true.
+	RootID string
+
+	// ErrorKind and Error describe the verification failure when Valid is
+	// false.
+	ErrorKind CAChainErrorKind `json:",omitempty"`
+	Error     string           `json:",omitempty"`
+
+	QueryMeta
+}
+
+// CAHealthStatus is the overall state reported by ConnectCA.Health.
+type CAHealthStatus string
+
+const (
+	// CAHealthHealthy means the CA is initialized and the provider can sign.
+	CAHealthHealthy CAHealthStatus = "healthy"
+
+	// CAHealthDegraded means the CA is initialized but the provider's health
+	// check is currently failing.
+	CAHealthDegraded CAHealthStatus = "degraded"
+
+	// CAHealthUninitialized means the leader hasn't been able to initialize
+	// the CA yet.
+	CAHealthUninitialized CAHealthStatus = "uninitialized"
+)
+
+// CARootChangeReason describes why the leader swapped the active CA root.
+type CARootChangeReason string
+
+const (
+	// CARootChangeRotation means the root was rotated by a CA configuration
+	// change in the primary, or a secondary picked up such a rotation.
+	CARootChangeRotation CARootChangeReason = "rotation"
+
+	// CARootChangeSecondaryTransition means a datacenter that used to be its
+	// own primary switched to the root of the primary datacenter.
+	CARootChangeSecondaryTransition CARootChangeReason = "secondary-transition"
+
+	// CARootChangeFixSigningKey means the stored SigningKeyID of the active
+	// root was corrected to match its intermediate.
+	CARootChangeFixSigningKey CARootChangeReason = "fix-signing-key"
+)
+
+// CAHealth is the response for ConnectCA.Health.
+type CAHealth struct {
+	// Status is the overall health of the CA.
+	Status CAHealthStatus
+
+	// Provider is the name of the configured CA provider.
+	Provider string
+
+	// LastError is the error from the provider health check, or from the last
+	// attempt to initialize the CA when Status is uninitialized.
+	LastError string `json:",omitempty"`
+
+	// LastInitAt is when the leader last finished initializing or
+	// reconfiguring the provider, including generating or fetching a signed
+	// intermediate. It is zero if that hasn't happened since it became leader.
+	LastInitAt time.Time
+
+	// LastInitDuration is how long that initialization took.
+	LastInitDuration time.Duration
+
+	QueryMeta
+}
+
+// CASecondary is a secondary datacenter the primary has signed an
+// intermediate for.
+type CASecondary struct {
+	// Datacenter is the name of the secondary datacenter.
+	Datacenter string
+
+	// LastSeen is when the secondary last requested an intermediate.
+	LastSeen time.Time
+
+	// RootID is the ID of the primary's active root that the secondary's
+	// latest intermediate was signed by.
+	RootID string
+}
+
+// IndexedCASecondaries is the response for ConnectCA.Secondaries.
+type IndexedCASecondaries struct {
+	// Secondaries are the secondaries seen by the current leader, sorted by
+	// datacenter. The list starts empty when a new leader is elected.
+	Secondaries []CASecondary
+
+	QueryMeta
+}
+
+// CAFederationStatus is the response for ConnectCA.FederationStatus.
+type CAFederationStatus struct {
+	// ActiveRootID is the ID of the primary datacenter's active root.
+	ActiveRootID string
+
+	// Datacenters holds the status of each secondary datacenter, sorted by
+	// name.
+	Datacenters []CADatacenterRootStatus
+
+	// Consistent is true when every secondary was reached and agrees with
+	// the primary on the active root.
+	Consistent bool
+}
+
+// CADatacenterRootStatus is how one secondary datacenter's CA roots compare
+// to the primary's.
+type CADatacenterRootStatus struct {
+	// Datacenter is the name of the secondary datacenter.
+	Datacenter string
+
+	// ActiveRootID is the ID of the secondary's active root.
+	ActiveRootID string
+
+	// MissingRoot is true when the secondary doesn't have the primary's
+	// active root.
+	MissingRoot bool
+
+	// StaleActive is true when the secondary's active root is not the
+	// primary's active root.
+	StaleActive bool
+
+	// Error is set when the secondary's roots couldn't be fetched, in which
+	// case the other fields are unset.
+	Error string `json:",omitempty"`
+}
+
+// CARotateRootRequest is the request for rotating the active CA root using the
+// current provider configuration and a newly generated private key. It is also
+// the request for ConnectCA.RollbackRoot.
+type CARotateRootRequest struct {
+	// Datacenter is the target for this request.
+	Datacenter string
+
+	// ForceWithoutCrossSigning indicates that the rotation should go ahead even
+	// if the current CA is unable to cross sign the new root. See the field of
+	// the same name on CAConfiguration.
+	ForceWithoutCrossSigning bool
+
+	// WriteRequest is a common struct containing ACL tokens and other
+	// write-related common elements for requests.
+	WriteRequest
+}
+
+// RequestDatacenter returns the datacenter for a given request.
+func (q *CARotateRootRequest) RequestDatacenter() string {
+	return q.Datacenter
+}
+
 // IssuedCert is a certificate that has been issued by a Connect CA.
 type IssuedCert struct {
 	// SerialNumber is the unique serial number for this certificate.
@@ -197,6 +712,9 @@ const (
 	CAOpDeleteProviderState           CAOp = "delete-provider-state"
 	CAOpSetRootsAndConfig             CAOp = "set-roots-config"
 	CAOpIncrementProviderSerialNumber CAOp = "increment-provider-serial"
+	CAOpRevokeCert                    CAOp = "revoke-cert"
+	CAOpPruneRevokedCerts             CAOp = "prune-revoked-certs"
+	CAOpAddProviderStateSnapshot      CAOp = "add-provider-state-snapshot"
 )
 
 // CARequest is used to modify connect CA data. This is used by the
@@ -222,20 +740,72 @@ type CARequest struct {
 	// ProviderState is the state for the builtin CA provider.
 	ProviderState *CAConsulProviderState
 
+	// RevokedCert is the leaf certificate to revoke. This is used for
+	// CAOpRevokeCert.
+	RevokedCert *CARevokedCert
+
+	// RevokedCertsExpiredBefore removes the revoked leaf certs that expired
+	// before this time. This is used for CAOpPruneRevokedCerts.
+	RevokedCertsExpiredBefore time.Time
+
+	// ProviderStateSnapshot is the provider state to add to the history
+	// after its root was rotated out. This is used for
+	// CAOpAddProviderStateSnapshot.
+	ProviderStateSnapshot *CAProviderStateSnapshot
+
+	// DryRun, when set on a ConnectCA.ConfigurationSet request, validates Config
+	// and initializes the provider it describes without persisting anything or
+	// rotating the active root. The reply is a CADryRunResult.
+	DryRun bool
+
+	// WaitForPropagation, when set on a ConnectCA.ConfigurationSet request in
+	// the primary datacenter, makes the RPC return only once every known
+	// secondary datacenter reports the resulting active root, or return an
+	// error once PropagationTimeout elapses. The configuration is applied
+	// either way.
+	WaitForPropagation bool
+
+	// PropagationTimeout bounds how long WaitForPropagation waits. It
+	// defaults to DefaultCAPropagationTimeout.
+	PropagationTimeout time.Duration
+
 	// WriteRequest is a common struct containing ACL tokens and other
 	// write-related common elements for requests.
 	WriteRequest
 }
 
+// DefaultCAPropagationTimeout is how long CARequest.WaitForPropagation waits
+// when CARequest.PropagationTimeout isn't set.
+const DefaultCAPropagationTimeout = time.Minute
+
 // RequestDatacenter returns the datacenter for a given request.
 func (q *CARequest) RequestDatacenter() string {
 	return q.Datacenter
 }
 
+// CADryRunResult describes the outcome of applying a CA configuration with
+// CARequest.DryRun set.
+type CADryRunResult struct {
+	// Root is the root that would be active after applying the configuration,
+	// including its intermediates. It is nil in secondary datacenters since
+	// they don't generate their own root.
+	Root *CARoot
+
+	// RootChanged is true if applying the configuration would rotate the
+	// active root.
+	RootChanged bool
+
+	// CanCrossSign is true if the current root can cross-sign the new root.
+	// It is only meaningful when RootChanged is true.
+	CanCrossSign bool
+}
+
 const (
-	ConsulCAProvider = "consul"
-	VaultCAProvider  = "vault"
-	AWSCAProvider    = "aws-pca"
+	ConsulCAProvider        = "consul"
+	VaultCAProvider         = "vault"
+	AWSCAProvider           = "aws-pca"
+	AzureKeyVaultCAProvider = "azure-keyvault"
+	GRPCCAProvider          = "grpc"
 )
 
 // CAConfiguration is the configuration for the current CA plugin.
@@ -273,6 +843,77 @@ type CAConfiguration struct {
 	// reconfigured or mirated away from.
 	ForceWithoutCrossSigning bool
 
+	// RootPruneInterval is how often the leader checks for roots that have been
+	// rotated out and expired so they can be removed. Zero means the default of
+	// DefaultRootPruneInterval is used.
+	RootPruneInterval time.Duration
+
+	// IntermediateGracePeriod is how long an intermediate that has been replaced
+	// is kept in the active root's IntermediateCerts so that leaves it signed
+	// continue to validate. Replaced intermediates are kept for at least the
+	// leaf cert TTL regardless of this value. Zero disables pruning of replaced
+	// intermediates entirely.
+	IntermediateGracePeriod time.Duration
+
+	// IntermediateRenewFraction is the fraction of the active intermediate's
+	// lifetime that must pass before the leader renews it. Zero means the
+	// default of DefaultIntermediateRenewFraction is used.
+	IntermediateRenewFraction float64
+
+	// IntermediateRenewJitter spreads out intermediate renewals across
+	// datacenters. Instead of renewing once IntermediateRenewFraction of the
+	// intermediate's lifetime has passed, each check picks a random renewal
+	// point up to this fraction of the lifetime later. Zero disables the
+	// jitter. It must be between 0 and MaxIntermediateRenewJitter.
+	IntermediateRenewJitter float64
+
+	// OCSPResponderURL is embedded as the OCSP server in the Authority
+	// Information Access extension of leaf certs signed by the provider. Leaf
+	// certs have no OCSP server when it is empty.
+	OCSPResponderURL string
+
+	// MaxRetainedRoots caps how many roots, including the active one, are
+	// kept. When there are more, the prune loop removes the oldest inactive
+	// roots even if leaves they signed may still be valid. It must be at least
+	// 2 so that the most recently rotated out root, whose leaves are still in
+	// use right after a rotation, is always kept. Zero means roots are only
+	// pruned once they have been rotated out for long enough that every leaf
+	// they signed has expired.
+	MaxRetainedRoots int
+
+	// LeafDNSSANAllowlist lists the DNS names service leaf certs may carry
+	// alongside their SPIFFE ID. An entry is either an exact name or a
+	// "*.example.com" pattern that allows any name below example.com. When
+	// empty, DNS SANs aren't restricted.
+	LeafDNSSANAllowlist []string
+
+	// AutoRenewRoot makes the primary's leader rotate the active root once
+	// RootRenewFraction of its lifetime has passed, the same way a manual
+	// ConnectCA.RotateRoot would. It is only supported by providers that hold
+	// the root's private key, which is the built-in provider without an
+	// ExternalRootCert.
+	AutoRenewRoot bool
+
+	// RootRenewFraction is the fraction of the active root's lifetime that
+	// must pass before it is renewed when AutoRenewRoot is set. Zero means
+	// the default of DefaultRootRenewFraction is used.
+	RootRenewFraction float64
+
+	// IntermediateCertCommonName and IntermediateCertOrganization override the
+	// subject of intermediate certs generated by the provider. In the primary
+	// they also apply to intermediates signed for secondary datacenters,
+	// replacing whatever the secondary asked for. Intermediates keep the
+	// provider's default subject when they are empty.
+	IntermediateCertCommonName   string
+	IntermediateCertOrganization string
+
+	// SignatureAlgorithm picks the hash leaf certs are signed with when the
+	// CA key is RSA. It must be one of the SignatureAlgorithm* constants and
+	// can only be set when PrivateKeyType is "rsa". EC and Ed25519 keys always
+	// use the algorithm that suits the key. When empty, RSA keys sign with
+	// SHA-256.
+	SignatureAlgorithm string
+
 	RaftIndex
 }
 
@@ -280,7 +921,23 @@ func (c *CAConfiguration) UnmarshalJSON(data []byte) (err error) {
 	type Alias CAConfiguration
 
 	aux := &struct {
-		ForceWithoutCrossSigningSnake bool `json:"force_without_cross_signing"`
+		RootPruneInterval       interface{}
+		IntermediateGracePeriod interface{}
+
+		ForceWithoutCrossSigningSnake  bool        `json:"force_without_cross_signing"`
+		RootPruneIntervalSnake         interface{} `json:"root_prune_interval"`
+		IntermediateGracePeriodSnake   interface{} `json:"intermediate_grace_period"`
+		IntermediateRenewFractionSnake float64     `json:"intermediate_renew_fraction"`
+		IntermediateRenewJitterSnake   float64     `json:"intermediate_renew_jitter"`
+		OCSPResponderURLSnake          string      `json:"ocsp_responder_url"`
+		MaxRetainedRootsSnake          int         `json:"max_retained_roots"`
+		LeafDNSSANAllowlistSnake       []string    `json:"leaf_dns_san_allowlist"`
+		AutoRenewRootSnake             bool        `json:"auto_renew_root"`
+		RootRenewFractionSnake         float64     `json:"root_renew_fraction"`
+
+		IntermediateCertCommonNameSnake   string `json:"intermediate_cert_common_name"`
+		IntermediateCertOrganizationSnake string `json:"intermediate_cert_organization"`
+		SignatureAlgorithmSnake           string `json:"signature_algorithm"`
 
 		*Alias
 	}{
@@ -292,10 +949,218 @@ func (c *CAConfiguration) UnmarshalJSON(data []byte) (err error) {
 	if aux.ForceWithoutCrossSigningSnake {
 		c.ForceWithoutCrossSigning = aux.ForceWithoutCrossSigningSnake
 	}
+	if aux.IntermediateRenewFractionSnake != 0 {
+		c.IntermediateRenewFraction = aux.IntermediateRenewFractionSnake
+	}
+	if aux.IntermediateRenewJitterSnake != 0 {
+		c.IntermediateRenewJitter = aux.IntermediateRenewJitterSnake
+	}
+	if aux.OCSPResponderURLSnake != "" {
+		c.OCSPResponderURL = aux.OCSPResponderURLSnake
+	}
+	if aux.MaxRetainedRootsSnake != 0 {
+		c.MaxRetainedRoots = aux.MaxRetainedRootsSnake
+	}
+	if len(aux.LeafDNSSANAllowlistSnake) != 0 {
+		c.LeafDNSSANAllowlist = aux.LeafDNSSANAllowlistSnake
+	}
+	if aux.AutoRenewRootSnake {
+		c.AutoRenewRoot = aux.AutoRenewRootSnake
+	}
+	if aux.RootRenewFractionSnake != 0 {
+		c.RootRenewFraction = aux.RootRenewFractionSnake
+	}
+	if aux.IntermediateCertCommonNameSnake != "" {
+		c.IntermediateCertCommonName = aux.IntermediateCertCommonNameSnake
+	}
+	if aux.IntermediateCertOrganizationSnake != "" {
+		c.IntermediateCertOrganization = aux.IntermediateCertOrganizationSnake
+	}
+	if aux.SignatureAlgorithmSnake != "" {
+		c.SignatureAlgorithm = aux.SignatureAlgorithmSnake
+	}
+	if aux.RootPruneInterval == nil {
+		aux.RootPruneInterval = aux.RootPruneIntervalSnake
+	}
+	if aux.RootPruneInterval != nil {
+		switch v := aux.RootPruneInterval.(type) {
+		case string:
+			if c.RootPruneInterval, err = time.ParseDuration(v); err != nil {
+				return err
+			}
+		case float64:
+			c.RootPruneInterval = time.Duration(v)
+		}
+	}
+	if aux.IntermediateGracePeriod == nil {
+		aux.IntermediateGracePeriod = aux.IntermediateGracePeriodSnake
+	}
+	if aux.IntermediateGracePeriod != nil {
+		switch v := aux.IntermediateGracePeriod.(type) {
+		case string:
+			if c.IntermediateGracePeriod, err = time.ParseDuration(v); err != nil {
+				return err
+			}
+		case float64:
+			c.IntermediateGracePeriod = time.Duration(v)
+		}
+	}
+
+	return nil
+}
+
+// Clone returns a copy of c whose Config, State and LeafDNSSANAllowlist can be
+// modified without affecting c.
+func (c *CAConfiguration) Clone() *CAConfiguration {
+	clone := *c
+	if c.Config != nil {
+		clone.Config = make(map[string]interface{}, len(c.Config))
+		for k, v := range c.Config {
+			clone.Config[k] = v
+		}
+	}
+	if c.State != nil {
+		clone.State = make(map[string]string, len(c.State))
+		for k, v := range c.State {
+			clone.State[k] = v
+		}
+	}
+	clone.LeafDNSSANAllowlist = CloneStringSlice(c.LeafDNSSANAllowlist)
+	return &clone
+}
+
+// SettingsEqual reports whether c and other configure the CA the same way. It
+// ignores ClusterID, State, ForceWithoutCrossSigning and the raft indexes,
+// which aren't settings of the CA, so that a new field is compared without
+// having to remember to add it here.
+func (c *CAConfiguration) SettingsEqual(other *CAConfiguration) bool {
+	a, b := *c, *other
+	a.ClusterID, b.ClusterID = "", ""
+	a.State, b.State = nil, nil
+	a.ForceWithoutCrossSigning, b.ForceWithoutCrossSigning = false, false
+	a.RaftIndex, b.RaftIndex = RaftIndex{}, RaftIndex{}
+	return reflect.DeepEqual(a, b)
+}
+
+// GetRootPruneInterval returns the configured root prune interval or the
+// default if one hasn't been set.
+func (c *CAConfiguration) GetRootPruneInterval() time.Duration {
+	if c == nil || c.RootPruneInterval == 0 {
+		return DefaultRootPruneInterval
+	}
+	return c.RootPruneInterval
+}
+
+// GetIntermediateRenewFraction returns the configured intermediate renew
+// fraction or the default if one hasn't been set.
+func (c *CAConfiguration) GetIntermediateRenewFraction() float64 {
+	if c == nil || c.IntermediateRenewFraction == 0 {
+		return DefaultIntermediateRenewFraction
+	}
+	return c.IntermediateRenewFraction
+}
+
+// GetRootRenewFraction returns the configured root renew fraction or the
+// default if one hasn't been set.
+func (c *CAConfiguration) GetRootRenewFraction() float64 {
+	if c == nil || c.RootRenewFraction == 0 {
+		return DefaultRootRenewFraction
+	}
+	return c.RootRenewFraction
+}
 
+// Validate checks the fields of the CA configuration that aren't specific to
+// any provider.
+func (c *CAConfiguration) Validate() error {
+	if c.RootPruneInterval != 0 && c.RootPruneInterval < MinRootPruneInterval {
+		return fmt.Errorf("root prune interval must be greater or equal than %s", MinRootPruneInterval)
+	}
+	if c.IntermediateGracePeriod < 0 {
+		return fmt.Errorf("intermediate grace period must not be negative")
+	}
+	if c.IntermediateRenewFraction < 0 || c.IntermediateRenewFraction >= 1 {
+		return fmt.Errorf("intermediate renew fraction must be between 0 and 1")
+	}
+	if c.IntermediateRenewJitter < 0 || c.IntermediateRenewJitter > MaxIntermediateRenewJitter {
+		return fmt.Errorf("intermediate renew jitter must be between 0 and %g", MaxIntermediateRenewJitter)
+	}
+	if c.GetIntermediateRenewFraction()+c.IntermediateRenewJitter >= 1 {
+		return fmt.Errorf("intermediate renew fraction plus jitter must be less than 1")
+	}
+	if c.MaxRetainedRoots < 0 {
+		return fmt.Errorf("max retained roots must not be negative")
+	}
+	if c.MaxRetainedRoots == 1 {
+		return fmt.Errorf("max retained roots must be at least 2 so the previous root is kept while its leaf certs are still valid")
+	}
+	if c.RootRenewFraction < 0 || c.RootRenewFraction >= 1 {
+		return fmt.Errorf("root renew fraction must be between 0 and 1")
+	}
+	for _, pattern := range c.LeafDNSSANAllowlist {
+		if !validDNSSANPattern(pattern) {
+			return fmt.Errorf("leaf DNS SAN allowlist entry %q must be a DNS name or a *.domain pattern", pattern)
+		}
+	}
+	if len(c.IntermediateCertCommonName) > MaxCertSubjectFieldLength {
+		return fmt.Errorf("intermediate cert common name must be at most %d characters", MaxCertSubjectFieldLength)
+	}
+	if len(c.IntermediateCertOrganization) > MaxCertSubjectFieldLength {
+		return fmt.Errorf("intermediate cert organization must be at most %d characters", MaxCertSubjectFieldLength)
+	}
+	if err := c.validateSignatureAlgorithm(); err != nil {
+		return err
+	}
+	if c.OCSPResponderURL != "" {
+		u, err := url.Parse(c.OCSPResponderURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("OCSP responder URL must be an absolute http or https URL")
+		}
+	}
 	return nil
 }
 
+// validateSignatureAlgorithm checks that SignatureAlgorithm is known and
+// suits the configured private key type.
+func (c *CAConfiguration) validateSignatureAlgorithm() error {
+	if c.SignatureAlgorithm == "" {
+		return nil
+	}
+	switch c.SignatureAlgorithm {
+	case SignatureAlgorithmSHA256WithRSA, SignatureAlgorithmSHA384WithRSA, SignatureAlgorithmSHA512WithRSA:
+	default:
+		return fmt.Errorf("signature algorithm must be one of %q, %q or %q",
+			SignatureAlgorithmSHA256WithRSA, SignatureAlgorithmSHA384WithRSA, SignatureAlgorithmSHA512WithRSA)
+	}
+
+	common, err := c.GetCommonConfig()
+	if err != nil {
+		return err
+	}
+	if common.PrivateKeyType != "rsa" {
+		keyType := common.PrivateKeyType
+		if keyType == "" {
+			keyType = "ec"
+		}
+		return fmt.Errorf("signature algorithm %q can't be used with a %q private key", c.SignatureAlgorithm, keyType)
+	}
+	return nil
+}
+
+// validDNSSANPattern reports whether pattern is a DNS name, optionally with a
+// leading "*." wildcard label.
+func validDNSSANPattern(pattern string) bool {
+	name := strings.TrimPrefix(pattern, "*.")
+	if name == "" || strings.ContainsAny(name, "* ") {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *CAConfiguration) GetCommonConfig() (*CommonCAProviderConfig, error) {
 	if c == nil {
 		return nil, fmt.Errorf("config map was nil")
@@ -358,25 +1223,110 @@ type CommonCAProviderConfig struct {
 	// PrivateKeyType specifies which type of key the CA should generate. It only
 	// applies when the provider is generating its own key and is ignored if the
 	// provider already has a key or an external key is provided. Supported values
-	// are "ec" or "rsa". "ec" is the default and will generate a NIST P-256
-	// Elliptic key.
+	// are "ec", "rsa" or "ed25519". "ec" is the default and will generate a NIST
+	// P-256 Elliptic key.
 	PrivateKeyType string
 
 	// PrivateKeyBits specifies the number of bits the CA's private key should
 	// use. For RSA, supported values are 2048 and 4096. For EC, supported values
 	// are 224, 256, 384 and 521 and correspond to the NIST P-* curve of the same
-	// name. As with PrivateKeyType this is only relevant whan the provier is
+	// name. Ed25519 keys have a fixed size so only 256 is accepted. As with
+	// PrivateKeyType this is only relevant whan the provier is
 	// generating new CA keys (root or intermediate).
 	PrivateKeyBits int
+
+	// CertificateTimeDriftBuffer is how far in the past the NotBefore of newly
+	// signed certificates is set to account for clock skew between servers and
+	// clients. Zero uses the provider default. It must not be negative or
+	// exceed MaxCertificateTimeDriftBuffer.
+	CertificateTimeDriftBuffer time.Duration
+
+	// LeafNotBeforeBackdate is how far in the past the NotBefore of leaf
+	// certificates is set. Unset uses CertificateTimeDriftBuffer, while zero
+	// disables backdating of leaf certificates.
+	LeafNotBeforeBackdate *time.Duration
+
+	// IntermediateNotBeforeBackdate is how far in the past the NotBefore of
+	// intermediate and cross-signed certificates is set. Unset uses
+	// CertificateTimeDriftBuffer, while zero disables backdating.
+	IntermediateNotBeforeBackdate *time.Duration
 }
 
+// DefaultRootPruneInterval is how often we check for stale CARoots to remove
+// when CAConfiguration.RootPruneInterval isn't set.
+const DefaultRootPruneInterval = time.Hour
+
+// MinRootPruneInterval is the smallest allowed CAConfiguration.RootPruneInterval.
+const MinRootPruneInterval = 5 * time.Second
+
+// DefaultIntermediateRenewFraction is the fraction of an intermediate's
+// lifetime after which it is renewed when
+// CAConfiguration.IntermediateRenewFraction isn't set.
+const DefaultIntermediateRenewFraction = 0.5
+
+// DefaultRootRenewFraction is the fraction of the active root's lifetime
+// after which it is renewed when CAConfiguration.AutoRenewRoot is set and
+// CAConfiguration.RootRenewFraction isn't.
+const DefaultRootRenewFraction = 0.5
+
+// MaxIntermediateRenewJitter is the largest allowed
+// CAConfiguration.IntermediateRenewJitter. Renewing any later leaves too
+// little of the intermediate's lifetime to retry a failed renewal.
+const MaxIntermediateRenewJitter = 0.4
+
+// The values CAConfiguration.SignatureAlgorithm may be set to.
+const (
+	SignatureAlgorithmSHA256WithRSA = "SHA256WithRSA"
+	SignatureAlgorithmSHA384WithRSA = "SHA384WithRSA"
+	SignatureAlgorithmSHA512WithRSA = "SHA512WithRSA"
+)
+
+// MaxCertSubjectFieldLength is the longest allowed
+// CAConfiguration.IntermediateCertCommonName and
+// CAConfiguration.IntermediateCertOrganization, which is the upper bound
+// X.509 puts on both attributes.
+const MaxCertSubjectFieldLength = 64
+
 var MinLeafCertTTL = time.Hour
 var MaxLeafCertTTL = 365 * 24 * time.Hour
 
+// MaxCertificateTimeDriftBuffer is the largest allowed
+// CommonCAProviderConfig.CertificateTimeDriftBuffer.
+const MaxCertificateTimeDriftBuffer = time.Hour
+
+// ClampLeafCertTTL returns the lifetime to use for a leaf certificate when ttl
+// was requested and leafCertTTL is the configured maximum. A zero ttl means
+// no preference and yields leafCertTTL; otherwise ttl is clamped to
+// [MinLeafCertTTL, leafCertTTL].
+func ClampLeafCertTTL(ttl, leafCertTTL time.Duration) time.Duration {
+	return ClampLeafCertTTLWithMin(ttl, 0, leafCertTTL)
+}
+
+// ClampLeafCertTTLWithMin is like ClampLeafCertTTL but clamps to minTTL
+// instead of MinLeafCertTTL. A zero minTTL uses MinLeafCertTTL.
+func ClampLeafCertTTLWithMin(ttl, minTTL, leafCertTTL time.Duration) time.Duration {
+	if minTTL == 0 {
+		minTTL = MinLeafCertTTL
+	}
+	switch {
+	case ttl == 0 || ttl > leafCertTTL:
+		return leafCertTTL
+	case ttl < minTTL:
+		return minTTL
+	default:
+		return ttl
+	}
+}
+
 // intermediateCertRenewInterval is the interval at which the expiration
 // of the intermediate cert is checked and renewed if necessary.
 var IntermediateCertRenewInterval = time.Hour
 
+// RootCertRenewInterval is the interval at which the primary checks whether
+// the active root needs to be renewed when CAConfiguration.AutoRenewRoot is
+// set.
+var RootCertRenewInterval = time.Hour
+
 func (c CommonCAProviderConfig) Validate() error {
 	if c.SkipValidate {
 		return nil
@@ -423,28 +1373,147 @@ func (c CommonCAProviderConfig) Validate() error {
 		return fmt.Errorf("Intermediate Cert TTL must be greater or equal than 3 * LeafCertTTL (>=%s).", 3*c.LeafCertTTL)
 	}
 
-	switch c.PrivateKeyType {
+	if c.CertificateTimeDriftBuffer < 0 {
+		return fmt.Errorf("certificate time drift buffer must not be negative")
+	}
+	if c.CertificateTimeDriftBuffer > MaxCertificateTimeDriftBuffer {
+		return fmt.Errorf("certificate time drift buffer must be less than or equal to %s", MaxCertificateTimeDriftBuffer)
+	}
+	if err := validateNotBeforeBackdate("leaf", c.LeafNotBeforeBackdate); err != nil {
+		return err
+	}
+	if err := validateNotBeforeBackdate("intermediate", c.IntermediateNotBeforeBackdate); err != nil {
+		return err
+	}
+
+	return ValidateCAKeyType(c.PrivateKeyType, c.PrivateKeyBits)
+}
+
+func validateNotBeforeBackdate(kind string, backdate *time.Duration) error {
+	switch {
+	case backdate == nil:
+		return nil
+	case *backdate < 0:
+		return fmt.Errorf("%s NotBefore backdate must not be negative", kind)
+	case *backdate > MaxCertificateTimeDriftBuffer:
+		return fmt.Errorf("%s NotBefore backdate must be less than or equal to %s", kind, MaxCertificateTimeDriftBuffer)
+	}
+	return nil
+}
+
+// ValidateCAKeyType returns an error if keyType and keyBits are not a
+// combination supported for CA and leaf private keys.
+func ValidateCAKeyType(keyType string, keyBits int) error {
+	switch keyType {
 	case "ec":
-		if c.PrivateKeyBits != 224 && c.PrivateKeyBits != 256 && c.PrivateKeyBits != 384 && c.PrivateKeyBits != 521 {
+		if keyBits != 224 && keyBits != 256 && keyBits != 384 && keyBits != 521 {
 			return fmt.Errorf("EC key length must be one of (224, 256, 384, 521) bits")
 		}
 	case "rsa":
-		if c.PrivateKeyBits != 2048 && c.PrivateKeyBits != 4096 {
+		if keyBits != 2048 && keyBits != 4096 {
 			return fmt.Errorf("RSA key length must be 2048 or 4096 bits")
 		}
+	case "ed25519":
+		if keyBits != 256 {
+			return fmt.Errorf("Ed25519 key length must be 256 bits")
+		}
 	default:
-		return fmt.Errorf("private key type must be either 'ec' or 'rsa'")
+		return fmt.Errorf("private key type must be one of 'ec', 'rsa' or 'ed25519'")
 	}
 
 	return nil
 }
 
+// CAKeyType is a private key type and length that CA providers may be
+// configured with, as listed in the connect.allowed_ca_key_types agent config.
+type CAKeyType struct {
+	Type string
+	Bits int
+}
+
+// ParseCAKeyType parses a "<type>:<bits>" value such as "rsa:4096". Only
+// combinations accepted for PrivateKeyType and PrivateKeyBits are valid.
+func ParseCAKeyType(s string) (CAKeyType, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 {
+		return CAKeyType{}, fmt.Errorf("invalid CA key type %q: must be of the form <type>:<bits>", s)
+	}
+	bits, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return CAKeyType{}, fmt.Errorf("invalid CA key type %q: %v", s, err)
+	}
+	if err := ValidateCAKeyType(parts[0], bits); err != nil {
+		return CAKeyType{}, fmt.Errorf("invalid CA key type %q: %v", s, err)
+	}
+	return CAKeyType{Type: parts[0], Bits: bits}, nil
+}
+
+func (k CAKeyType) String() string {
+	return fmt.Sprintf("%s:%d", k.Type, k.Bits)
+}
+
+// CheckCAKeyTypeAllowed returns an error if keyType and keyBits are not one of
+// the allowed combinations. An empty allowed list permits everything.
+func CheckCAKeyTypeAllowed(allowed []CAKeyType, keyType string, keyBits int) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+	requested := CAKeyType{Type: keyType, Bits: keyBits}
+	names := make([]string, 0, len(allowed))
+	for _, k := range allowed {
+		if k == requested {
+			return nil
+		}
+		names = append(names, k.String())
+	}
+	return fmt.Errorf("CA private key type %s is not allowed by this cluster, must be one of: %s",
+		requested, strings.Join(names, ", "))
+}
+
+// CheckCAKeyStrength returns an error if keyType isn't listed in minimums or
+// keyBits is less than the bits listed for it. An empty minimums list accepts
+// every key.
+func CheckCAKeyStrength(minimums []CAKeyType, keyType string, keyBits int) error {
+	if len(minimums) == 0 {
+		return nil
+	}
+	key := CAKeyType{Type: keyType, Bits: keyBits}
+	names := make([]string, 0, len(minimums))
+	for _, min := range minimums {
+		if min.Type == keyType {
+			if keyBits < min.Bits {
+				return fmt.Errorf("CA key %s is weaker than the required minimum of %s", key, min)
+			}
+			return nil
+		}
+		names = append(names, min.String())
+	}
+	return fmt.Errorf("CA key type %s is not allowed, must be at least one of: %s",
+		key, strings.Join(names, ", "))
+}
+
 type ConsulCAProviderConfig struct {
 	CommonCAProviderConfig `mapstructure:",squash"`
 
 	PrivateKey string
 	RootCert   string
 
+	// CABundle imports an existing CA as a single PEM bundle holding the root
+	// cert, its private key and optionally one intermediate cert. When an
+	// intermediate is included the key must belong to it, since it becomes
+	// the signing cert. It can't be combined with PrivateKey or RootCert.
+	CABundle string
+
+	// ExternalRootCert runs the provider as an intermediate signer for a root
+	// whose private key is kept offline. It holds the root cert, which is
+	// published as the active root. IntermediateCert must be signed by it and
+	// PrivateKey must belong to IntermediateCert, which signs leaf certs.
+	// Operations that need the root key, such as rotating or cross-signing
+	// the root and signing intermediates for secondary datacenters, are
+	// refused. It can't be combined with RootCert.
+	ExternalRootCert string
+	IntermediateCert string
+
 	// DisableCrossSigning is really only useful in test code to use the built in
 	// provider while exercising logic that depends on the CA provider ability to
 	// cross sign. We don't document this config field publicly or make any
@@ -471,9 +1540,19 @@ type VaultCAProviderConfig struct {
 
 	Address             string
 	Token               string
+	SigningToken        string
 	RootPKIPath         string
 	IntermediatePKIPath string
 
+	// AuthMethod, when set, is used to log in to Vault to obtain a token
+	// instead of configuring Token directly.
+	AuthMethod *VaultAuthMethod
+
+	// LeafRole, when set, tunes the PKI roles the provider issues leaf
+	// certificates with. The roles are then updated every time the provider
+	// is configured rather than only being created when missing.
+	LeafRole *VaultLeafRoleConfig
+
 	CAFile        string
 	CAPath        string
 	CertFile      string
@@ -482,6 +1561,40 @@ type VaultCAProviderConfig struct {
 	TLSSkipVerify bool
 }
 
+// VaultAuthMethod configures the Vault auth method the Vault CA provider logs
+// in with to obtain its token.
+type VaultAuthMethod struct {
+	// Type is the type of the auth method, such as "kubernetes" or "approle".
+	Type string
+
+	// MountPath is the path the auth method is mounted at. It defaults to
+	// Type.
+	MountPath string
+
+	// Params are the parameters sent to the auth method's login endpoint.
+	Params map[string]interface{}
+}
+
+// VaultLeafRoleConfig holds the Vault PKI role parameters the Vault CA
+// provider applies to the roles it issues leaf certificates with.
+type VaultLeafRoleConfig struct {
+	// AllowedDomains limits the DNS SANs leaf certificates may contain to
+	// these domains. Each domain is allowed as-is, and its subdomains are
+	// allowed too if AllowSubdomains is set.
+	AllowedDomains []string
+
+	// AllowSubdomains allows DNS SANs for subdomains of AllowedDomains.
+	AllowSubdomains bool
+
+	// AllowAnyName allows any DNS SAN. It defaults to true unless
+	// AllowedDomains is set.
+	AllowAnyName *bool
+
+	// MaxTTL is the longest TTL the roles issue certificates for. It
+	// defaults to LeafCertTTL and must not be shorter than it.
+	MaxTTL time.Duration
+}
+
 type AWSCAProviderConfig struct {
 	CommonCAProviderConfig `mapstructure:",squash"`
 
@@ -489,6 +1602,50 @@ type AWSCAProviderConfig struct {
 	DeleteOnExit bool
 }
 
+// AzureKeyVaultCAProviderConfig is the configuration for the Azure Key Vault
+// CA provider. The CA private keys are created in and never leave the vault.
+type AzureKeyVaultCAProviderConfig struct {
+	CommonCAProviderConfig `mapstructure:",squash"`
+
+	// VaultURL is the base URL of the key vault, such as
+	// "https://example.vault.azure.net".
+	VaultURL string
+
+	// HSM creates the CA keys as HSM-protected keys, which requires a
+	// premium tier vault.
+	HSM bool
+
+	// DeleteOnExit deletes the keys the provider created when it is
+	// replaced.
+	DeleteOnExit bool
+}
+
+// GRPCCAProviderConfig is the configuration for the gRPC CA provider, which
+// proxies to an external CA provider process. The whole raw config is also
+// passed on to the external process.
+type GRPCCAProviderConfig struct {
+	CommonCAProviderConfig `mapstructure:",squash"`
+
+	// Address is the address of the external process, either "host:port" or
+	// "unix:///path/to/socket".
+	Address string
+
+	// CAFile, CertFile and KeyFile configure TLS for the connection. CAFile
+	// verifies the external process in place of the system roots, and
+	// CertFile and KeyFile are the client cert Consul presents to it.
+	CAFile   string
+	CertFile string
+	KeyFile  string
+
+	// TLSServerName overrides the server name verified with CAFile.
+	TLSServerName string
+
+	// Insecure connects to a TCP Address without TLS. It's only meant for
+	// testing; unix sockets connect without TLS unless CAFile or CertFile is
+	// set.
+	Insecure bool
+}
+
 // CALeafOp is the operation for a request related to leaf certificates.
 type CALeafOp string
 
@@ -1,6 +1,8 @@
 package structs
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -49,6 +51,24 @@ func TestCAConfiguration_GetCommonConfig(t *testing.T) {
 				CSRMaxPerSecond:     50, // The default value
 			},
 		},
+		{
+			name: "not before backdates",
+			cfg: &CAConfiguration{
+				Config: map[string]interface{}{
+					"LeafCertTTL":                   "72h",
+					"IntermediateCertTTL":           "4320h",
+					"LeafNotBeforeBackdate":         "0s",
+					"IntermediateNotBeforeBackdate": []uint8("10m"),
+				},
+			},
+			want: &CommonCAProviderConfig{
+				LeafCertTTL:                   72 * time.Hour,
+				IntermediateCertTTL:           4320 * time.Hour,
+				CSRMaxPerSecond:               50,
+				LeafNotBeforeBackdate:         durationPtr(0),
+				IntermediateNotBeforeBackdate: durationPtr(10 * time.Minute),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -103,7 +123,7 @@ func TestCAProviderConfig_Validate(t *testing.T) {
 				RootCertTTL:         5 * time.Hour,
 			},
 			wantErr: true,
-			wantMsg: "private key type must be either 'ec' or 'rsa'",
+			wantMsg: "private key type must be one of 'ec', 'rsa' or 'ed25519'",
 		},
 		{
 			name: "good intermediate/leaf cert TTL/key type, missing bits",
@@ -116,6 +136,18 @@ func TestCAProviderConfig_Validate(t *testing.T) {
 			wantErr: true,
 			wantMsg: "EC key length must be one of (224, 256, 384, 521) bits",
 		},
+		{
+			name: "ed25519 key type with bad bits",
+			cfg: &CommonCAProviderConfig{
+				LeafCertTTL:         1 * time.Hour,
+				IntermediateCertTTL: 4 * time.Hour,
+				RootCertTTL:         5 * time.Hour,
+				PrivateKeyType:      "ed25519",
+				PrivateKeyBits:      384,
+			},
+			wantErr: true,
+			wantMsg: "Ed25519 key length must be 256 bits",
+		},
 		{
 			name: "good intermediate/leaf cert TTL/key type/bits",
 			cfg: &CommonCAProviderConfig{
@@ -151,6 +183,63 @@ func TestCAProviderConfig_Validate(t *testing.T) {
 			wantErr: true,
 			wantMsg: "root cert TTL is set and is not greater than intermediate cert ttl. root cert ttl: 3h0m0s, intermediate cert ttl: 4h0m0s",
 		},
+		{
+			name: "negative time drift buffer",
+			cfg: &CommonCAProviderConfig{
+				LeafCertTTL:                1 * time.Hour,
+				IntermediateCertTTL:        4 * time.Hour,
+				RootCertTTL:                5 * time.Hour,
+				CertificateTimeDriftBuffer: -time.Second,
+			},
+			wantErr: true,
+			wantMsg: "certificate time drift buffer must not be negative",
+		},
+		{
+			name: "time drift buffer too large",
+			cfg: &CommonCAProviderConfig{
+				LeafCertTTL:                1 * time.Hour,
+				IntermediateCertTTL:        4 * time.Hour,
+				RootCertTTL:                5 * time.Hour,
+				CertificateTimeDriftBuffer: 2 * time.Hour,
+			},
+			wantErr: true,
+			wantMsg: "certificate time drift buffer must be less than or equal to 1h0m0s",
+		},
+		{
+			name: "zero leaf backdate",
+			cfg: &CommonCAProviderConfig{
+				LeafCertTTL:           1 * time.Hour,
+				IntermediateCertTTL:   4 * time.Hour,
+				RootCertTTL:           5 * time.Hour,
+				PrivateKeyType:        "ec",
+				PrivateKeyBits:        256,
+				LeafNotBeforeBackdate: durationPtr(0),
+			},
+			wantErr: false,
+			wantMsg: "",
+		},
+		{
+			name: "negative leaf backdate",
+			cfg: &CommonCAProviderConfig{
+				LeafCertTTL:           1 * time.Hour,
+				IntermediateCertTTL:   4 * time.Hour,
+				RootCertTTL:           5 * time.Hour,
+				LeafNotBeforeBackdate: durationPtr(-time.Second),
+			},
+			wantErr: true,
+			wantMsg: "leaf NotBefore backdate must not be negative",
+		},
+		{
+			name: "intermediate backdate too large",
+			cfg: &CommonCAProviderConfig{
+				LeafCertTTL:                   1 * time.Hour,
+				IntermediateCertTTL:           4 * time.Hour,
+				RootCertTTL:                   5 * time.Hour,
+				IntermediateNotBeforeBackdate: durationPtr(2 * time.Hour),
+			},
+			wantErr: true,
+			wantMsg: "intermediate NotBefore backdate must be less than or equal to 1h0m0s",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -163,3 +252,312 @@ func TestCAProviderConfig_Validate(t *testing.T) {
 		})
 	}
 }
+
+func durationPtr(d time.Duration) *time.Duration {
+	return &d
+}
+
+func TestCAConfiguration_UnmarshalJSON_RootPruneInterval(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  time.Duration
+	}{
+		"string":     {input: `{"RootPruneInterval": "30s"}`, want: 30 * time.Second},
+		"snake case": {input: `{"root_prune_interval": "2m"}`, want: 2 * time.Minute},
+		"nanoseconds": {
+			input: `{"RootPruneInterval": 60000000000}`,
+			want:  time.Minute,
+		},
+		"unset": {input: `{}`, want: 0},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var conf CAConfiguration
+			require.NoError(t, conf.UnmarshalJSON([]byte(tc.input)))
+			require.Equal(t, tc.want, conf.RootPruneInterval)
+		})
+	}
+}
+
+func TestCAConfiguration_UnmarshalJSON_IntermediateGracePeriod(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  time.Duration
+	}{
+		"string":      {input: `{"IntermediateGracePeriod": "72h"}`, want: 72 * time.Hour},
+		"snake case":  {input: `{"intermediate_grace_period": "1h"}`, want: time.Hour},
+		"nanoseconds": {input: `{"IntermediateGracePeriod": 60000000000}`, want: time.Minute},
+		"unset":       {input: `{}`, want: 0},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var conf CAConfiguration
+			require.NoError(t, conf.UnmarshalJSON([]byte(tc.input)))
+			require.Equal(t, tc.want, conf.IntermediateGracePeriod)
+		})
+	}
+}
+
+func TestCAConfiguration_UnmarshalJSON_IntermediateRenewJitter(t *testing.T) {
+	var conf CAConfiguration
+	require.NoError(t, conf.UnmarshalJSON([]byte(`{"IntermediateRenewJitter": 0.2}`)))
+	require.Equal(t, 0.2, conf.IntermediateRenewJitter)
+
+	conf = CAConfiguration{}
+	require.NoError(t, conf.UnmarshalJSON([]byte(`{"intermediate_renew_jitter": 0.3}`)))
+	require.Equal(t, 0.3, conf.IntermediateRenewJitter)
+}
+
+func TestCAConfiguration_UnmarshalJSON_IntermediateRenewFraction(t *testing.T) {
+	var conf CAConfiguration
+	require.NoError(t, conf.UnmarshalJSON([]byte(`{"IntermediateRenewFraction": 0.33}`)))
+	require.Equal(t, 0.33, conf.IntermediateRenewFraction)
+
+	conf = CAConfiguration{}
+	require.NoError(t, conf.UnmarshalJSON([]byte(`{"intermediate_renew_fraction": 0.75}`)))
+	require.Equal(t, 0.75, conf.IntermediateRenewFraction)
+}
+
+func TestCAConfiguration_UnmarshalJSON_OCSPResponderURL(t *testing.T) {
+	var conf CAConfiguration
+	require.NoError(t, conf.UnmarshalJSON([]byte(`{"OCSPResponderURL": "http://ocsp.example.com"}`)))
+	require.Equal(t, "http://ocsp.example.com", conf.OCSPResponderURL)
+
+	conf = CAConfiguration{}
+	require.NoError(t, conf.UnmarshalJSON([]byte(`{"ocsp_responder_url": "https://ocsp.example.com/check"}`)))
+	require.Equal(t, "https://ocsp.example.com/check", conf.OCSPResponderURL)
+}
+
+func TestCAConfiguration_UnmarshalJSON_MaxRetainedRoots(t *testing.T) {
+	var conf CAConfiguration
+	require.NoError(t, conf.UnmarshalJSON([]byte(`{"MaxRetainedRoots": 3}`)))
+	require.Equal(t, 3, conf.MaxRetainedRoots)
+
+	conf = CAConfiguration{}
+	require.NoError(t, conf.UnmarshalJSON([]byte(`{"max_retained_roots": 5}`)))
+	require.Equal(t, 5, conf.MaxRetainedRoots)
+}
+
+func TestCAConfiguration_UnmarshalJSON_LeafDNSSANAllowlist(t *testing.T) {
+	var conf CAConfiguration
+	require.NoError(t, conf.UnmarshalJSON([]byte(`{"LeafDNSSANAllowlist": ["*.ingress.consul"]}`)))
+	require.Equal(t, []string{"*.ingress.consul"}, conf.LeafDNSSANAllowlist)
+
+	conf = CAConfiguration{}
+	require.NoError(t, conf.UnmarshalJSON([]byte(`{"leaf_dns_san_allowlist": ["web.example.com"]}`)))
+	require.Equal(t, []string{"web.example.com"}, conf.LeafDNSSANAllowlist)
+}
+
+func TestCAConfiguration_UnmarshalJSON_AutoRenewRoot(t *testing.T) {
+	var conf CAConfiguration
+	require.NoError(t, conf.UnmarshalJSON([]byte(`{"AutoRenewRoot": true, "RootRenewFraction": 0.8}`)))
+	require.True(t, conf.AutoRenewRoot)
+	require.Equal(t, 0.8, conf.RootRenewFraction)
+
+	conf = CAConfiguration{}
+	require.NoError(t, conf.UnmarshalJSON([]byte(`{"auto_renew_root": true, "root_renew_fraction": 0.7}`)))
+	require.True(t, conf.AutoRenewRoot)
+	require.Equal(t, 0.7, conf.RootRenewFraction)
+}
+
+func TestCAConfiguration_UnmarshalJSON_IntermediateCertSubject(t *testing.T) {
+	var conf CAConfiguration
+	require.NoError(t, conf.UnmarshalJSON([]byte(`{"IntermediateCertCommonName": "Mesh CA", "IntermediateCertOrganization": "Example"}`)))
+	require.Equal(t, "Mesh CA", conf.IntermediateCertCommonName)
+	require.Equal(t, "Example", conf.IntermediateCertOrganization)
+
+	conf = CAConfiguration{}
+	require.NoError(t, conf.UnmarshalJSON([]byte(`{"intermediate_cert_common_name": "Mesh CA", "intermediate_cert_organization": "Example"}`)))
+	require.Equal(t, "Mesh CA", conf.IntermediateCertCommonName)
+	require.Equal(t, "Example", conf.IntermediateCertOrganization)
+}
+
+func TestCAConfiguration_UnmarshalJSON_SignatureAlgorithm(t *testing.T) {
+	var conf CAConfiguration
+	require.NoError(t, conf.UnmarshalJSON([]byte(`{"SignatureAlgorithm": "SHA384WithRSA"}`)))
+	require.Equal(t, SignatureAlgorithmSHA384WithRSA, conf.SignatureAlgorithm)
+
+	conf = CAConfiguration{}
+	require.NoError(t, conf.UnmarshalJSON([]byte(`{"signature_algorithm": "SHA512WithRSA"}`)))
+	require.Equal(t, SignatureAlgorithmSHA512WithRSA, conf.SignatureAlgorithm)
+}
+
+func TestCAConfiguration_Validate_SignatureAlgorithm(t *testing.T) {
+	rsaConfig := map[string]interface{}{"PrivateKeyType": "rsa", "PrivateKeyBits": 2048}
+	for _, alg := range []string{SignatureAlgorithmSHA256WithRSA, SignatureAlgorithmSHA384WithRSA, SignatureAlgorithmSHA512WithRSA} {
+		require.NoError(t, (&CAConfiguration{Config: rsaConfig, SignatureAlgorithm: alg}).Validate(), alg)
+	}
+
+	err := (&CAConfiguration{Config: rsaConfig, SignatureAlgorithm: "SHA1WithRSA"}).Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "signature algorithm must be one of")
+
+	// EC keys, including the default key type, pick their own hash.
+	err = (&CAConfiguration{
+		Config:             map[string]interface{}{"PrivateKeyType": "ec", "PrivateKeyBits": 384},
+		SignatureAlgorithm: SignatureAlgorithmSHA384WithRSA,
+	}).Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `can't be used with a "ec" private key`)
+
+	err = (&CAConfiguration{SignatureAlgorithm: SignatureAlgorithmSHA256WithRSA}).Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `can't be used with a "ec" private key`)
+}
+
+func TestCAConfiguration_Validate(t *testing.T) {
+	require.NoError(t, (&CAConfiguration{}).Validate())
+	require.NoError(t, (&CAConfiguration{RootPruneInterval: MinRootPruneInterval}).Validate())
+	require.Error(t, (&CAConfiguration{RootPruneInterval: time.Second}).Validate())
+	require.NoError(t, (&CAConfiguration{IntermediateGracePeriod: time.Hour}).Validate())
+	require.Error(t, (&CAConfiguration{IntermediateGracePeriod: -time.Hour}).Validate())
+	require.NoError(t, (&CAConfiguration{IntermediateRenewJitter: MaxIntermediateRenewJitter}).Validate())
+	require.Error(t, (&CAConfiguration{IntermediateRenewJitter: 0.5}).Validate())
+	require.Error(t, (&CAConfiguration{IntermediateRenewJitter: -0.1}).Validate())
+	require.NoError(t, (&CAConfiguration{IntermediateRenewFraction: 0.33}).Validate())
+	require.Error(t, (&CAConfiguration{IntermediateRenewFraction: 1}).Validate())
+	require.Error(t, (&CAConfiguration{IntermediateRenewFraction: -0.5}).Validate())
+	require.Error(t, (&CAConfiguration{IntermediateRenewFraction: 0.75, IntermediateRenewJitter: 0.3}).Validate())
+	require.NoError(t, (&CAConfiguration{OCSPResponderURL: "http://ocsp.example.com:8080/ocsp"}).Validate())
+	require.Error(t, (&CAConfiguration{OCSPResponderURL: "ocsp.example.com"}).Validate())
+	require.Error(t, (&CAConfiguration{OCSPResponderURL: "ldap://ocsp.example.com"}).Validate())
+	require.NoError(t, (&CAConfiguration{MaxRetainedRoots: 2}).Validate())
+	require.Error(t, (&CAConfiguration{MaxRetainedRoots: -1}).Validate())
+	require.Error(t, (&CAConfiguration{MaxRetainedRoots: 1}).Validate())
+	require.NoError(t, (&CAConfiguration{Provider: ConsulCAProvider, AutoRenewRoot: true, RootRenewFraction: 0.8}).Validate())
+	require.Error(t, (&CAConfiguration{RootRenewFraction: 1}).Validate())
+	require.Error(t, (&CAConfiguration{RootRenewFraction: -0.1}).Validate())
+	require.NoError(t, (&CAConfiguration{LeafDNSSANAllowlist: []string{"*.ingress.consul", "web.example.com"}}).Validate())
+	require.NoError(t, (&CAConfiguration{IntermediateCertCommonName: "Mesh CA", IntermediateCertOrganization: "Example"}).Validate())
+	require.Error(t, (&CAConfiguration{IntermediateCertCommonName: strings.Repeat("a", MaxCertSubjectFieldLength+1)}).Validate())
+	require.Error(t, (&CAConfiguration{IntermediateCertOrganization: strings.Repeat("a", MaxCertSubjectFieldLength+1)}).Validate())
+	for _, pattern := range []string{"", "*", "*.", "web.*.consul", "web..consul", "web example.com"} {
+		require.Error(t, (&CAConfiguration{LeafDNSSANAllowlist: []string{pattern}}).Validate(), pattern)
+	}
+
+	require.Equal(t, DefaultRootPruneInterval, (&CAConfiguration{}).GetRootPruneInterval())
+	require.Equal(t, DefaultIntermediateRenewFraction, (&CAConfiguration{}).GetIntermediateRenewFraction())
+	require.Equal(t, 0.33, (&CAConfiguration{IntermediateRenewFraction: 0.33}).GetIntermediateRenewFraction())
+	require.Equal(t, DefaultRootRenewFraction, (&CAConfiguration{}).GetRootRenewFraction())
+	require.Equal(t, 0.8, (&CAConfiguration{RootRenewFraction: 0.8}).GetRootRenewFraction())
+}
+
+func TestParseCAKeyType(t *testing.T) {
+	k, err := ParseCAKeyType("rsa:4096")
+	require.NoError(t, err)
+	require.Equal(t, CAKeyType{Type: "rsa", Bits: 4096}, k)
+	require.Equal(t, "rsa:4096", k.String())
+
+	for _, s := range []string{"rsa", "rsa:big", "rsa:1024", "dsa:2048", ""} {
+		_, err := ParseCAKeyType(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestCheckCAKeyTypeAllowed(t *testing.T) {
+	require.NoError(t, CheckCAKeyTypeAllowed(nil, "rsa", 2048))
+
+	allowed := []CAKeyType{{Type: "rsa", Bits: 4096}, {Type: "ec", Bits: 384}}
+	require.NoError(t, CheckCAKeyTypeAllowed(allowed, "rsa", 4096))
+	require.NoError(t, CheckCAKeyTypeAllowed(allowed, "ec", 384))
+
+	err := CheckCAKeyTypeAllowed(allowed, "rsa", 2048)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "rsa:2048 is not allowed")
+	require.Contains(t, err.Error(), "rsa:4096, ec:384")
+}
+
+func TestCheckCAKeyStrength(t *testing.T) {
+	require.NoError(t, CheckCAKeyStrength(nil, "rsa", 2048))
+
+	minimums := []CAKeyType{{Type: "rsa", Bits: 4096}, {Type: "ec", Bits: 256}}
+	require.NoError(t, CheckCAKeyStrength(minimums, "rsa", 4096))
+	require.NoError(t, CheckCAKeyStrength(minimums, "ec", 256))
+	require.NoError(t, CheckCAKeyStrength(minimums, "ec", 384))
+
+	err := CheckCAKeyStrength(minimums, "rsa", 2048)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "CA key rsa:2048 is weaker than the required minimum of rsa:4096")
+
+	err = CheckCAKeyStrength(minimums, "ed25519", 256)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "CA key type ed25519:256 is not allowed, must be at least one of: rsa:4096, ec:256")
+}
+
+func TestClampLeafCertTTL(t *testing.T) {
+	max := 72 * time.Hour
+	require.Equal(t, max, ClampLeafCertTTL(0, max))
+	require.Equal(t, 2*time.Hour, ClampLeafCertTTL(2*time.Hour, max))
+	require.Equal(t, MinLeafCertTTL, ClampLeafCertTTL(time.Second, max))
+	require.Equal(t, max, ClampLeafCertTTL(1000*time.Hour, max))
+}
+
+func TestClampLeafCertTTLWithMin(t *testing.T) {
+	max := 72 * time.Hour
+	require.Equal(t, MinLeafCertTTL, ClampLeafCertTTLWithMin(time.Second, 0, max))
+	require.Equal(t, 10*time.Minute, ClampLeafCertTTLWithMin(time.Second, 10*time.Minute, max))
+	require.Equal(t, 20*time.Minute, ClampLeafCertTTLWithMin(20*time.Minute, 10*time.Minute, max))
+	require.Equal(t, 4*time.Hour, ClampLeafCertTTLWithMin(2*time.Hour, 4*time.Hour, max))
+	require.Equal(t, max, ClampLeafCertTTLWithMin(1000*time.Hour, 4*time.Hour, max))
+}
+
+func TestCAConfiguration_SettingsEqual(t *testing.T) {
+	base := &CAConfiguration{
+		ClusterID: "abc",
+		Provider:  "consul",
+		Config:    map[string]interface{}{"LeafCertTTL": "72h"},
+	}
+	require.True(t, base.SettingsEqual(base.Clone()))
+
+	ignored := map[string]bool{
+		"ClusterID":                true,
+		"State":                    true,
+		"ForceWithoutCrossSigning": true,
+		"RaftIndex":                true,
+	}
+
+	// Every other field must be compared, so that new fields can't be
+	// forgotten when deciding whether a config change is a no-op.
+	typ := reflect.TypeOf(*base)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		t.Run(field.Name, func(t *testing.T) {
+			other := base.Clone()
+			v := reflect.ValueOf(other).Elem().Field(i)
+			switch v.Kind() {
+			case reflect.String:
+				v.SetString("changed")
+			case reflect.Bool:
+				v.SetBool(true)
+			case reflect.Int, reflect.Int64:
+				v.SetInt(v.Int() + 1)
+			case reflect.Float64:
+				v.SetFloat(v.Float() + 0.1)
+			case reflect.Map:
+				v.Set(reflect.MakeMap(v.Type()))
+			case reflect.Slice:
+				v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+			case reflect.Struct:
+				other.RaftIndex.ModifyIndex++
+			default:
+				t.Fatalf("unhandled kind %s", v.Kind())
+			}
+			require.Equal(t, ignored[field.Name], base.SettingsEqual(other))
+		})
+	}
+}
+
+func TestCAConfiguration_Clone(t *testing.T) {
+	orig := &CAConfiguration{
+		Config:              map[string]interface{}{"a": "b"},
+		State:               map[string]string{"c": "d"},
+		LeafDNSSANAllowlist: []string{"example.com"},
+	}
+	clone := orig.Clone()
+	clone.Config["a"] = "x"
+	clone.State["c"] = "x"
+	clone.LeafDNSSANAllowlist[0] = "x"
+	require.Equal(t, "b", orig.Config["a"])
+	require.Equal(t, "d", orig.State["c"])
+	require.Equal(t, "example.com", orig.LeafDNSSANAllowlist[0])
+}
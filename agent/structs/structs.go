@@ -70,6 +70,8 @@ const (
 	ChunkingStateType                           = 29
 	FederationStateRequestType                  = 30
 	SystemMetadataRequestType                   = 31
+	ConnectCARevokedCertType                    = 32 // FSM snapshots only.
+	ConnectCAStateHistoryType                   = 33 // FSM snapshots only.
 )
 
 // if a new request type is added above it must be
@@ -110,6 +112,8 @@ var requestTypeStrings = map[MessageType]string{
 	ChunkingStateType:               "ChunkingState",
 	FederationStateRequestType:      "FederationState",
 	SystemMetadataRequestType:       "SystemMetadata",
+	ConnectCARevokedCertType:        "ConnectCARevokedCert",  // FSM snapshots only.
+	ConnectCAStateHistoryType:       "ConnectCAStateHistory", // FSM snapshots only.
 }
 
 const (
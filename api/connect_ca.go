@@ -50,6 +50,7 @@ type ConsulCAProviderConfig struct {
 
 	PrivateKey          string
 	RootCert            string
+	CABundle            string
 	IntermediateCertTTL time.Duration
 }
 
@@ -77,9 +78,11 @@ func ParseConsulCAConfig(raw map[string]interface{}) (*ConsulCAProviderConfig, e
 
 // CARootList is the structure for the results of listing roots.
 type CARootList struct {
-	ActiveRootID string
-	TrustDomain  string
-	Roots        []*CARoot
+	ActiveRootID           string
+	TrustDomain            string
+	AdditionalTrustDomains []string `json:",omitempty"`
+	SupportsCrossSigning   bool
+	Roots                  []*CARoot
 }
 
 // CARoot represents a root CA certificate that is trusted.
@@ -0,0 +1,4643 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: proto/pbcaprovider/caprovider.proto
+
+package pbcaprovider
+
+import (
+	context "context"
+	fmt "fmt"
+	types "github.com/gogo/protobuf/types"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type ConfigureRequest struct {
+	// ClusterID is the Consul cluster ID, which the trust domain is derived
+	// from.
+	ClusterID string `protobuf:"bytes,1,opt,name=ClusterID,proto3" json:"ClusterID,omitempty"`
+	// Datacenter is the datacenter of the Consul server making the request.
+	Datacenter string `protobuf:"bytes,2,opt,name=Datacenter,proto3" json:"Datacenter,omitempty"`
+	// IsPrimary is true in the primary datacenter, where the provider acts as
+	// the root CA. Secondaries use an intermediate signed by the primary.
+	IsPrimary bool `protobuf:"varint,3,opt,name=IsPrimary,proto3" json:"IsPrimary,omitempty"`
+	// ConfigJSON is the JSON encoded CA provider configuration.
+	ConfigJSON []byte `protobuf:"bytes,4,opt,name=ConfigJSON,proto3" json:"ConfigJSON,omitempty"`
+	// State is the state the provider last returned from State, if any.
+	State map[string]string `protobuf:"bytes,5,rep,name=State,proto3" json:"State,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// OCSPResponderURL is the OCSP server to embed in leaf certs, if any.
+	OCSPResponderURL     string   `protobuf:"bytes,6,opt,name=OCSPResponderURL,proto3" json:"OCSPResponderURL,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfigureRequest) Reset()         { *m = ConfigureRequest{} }
+func (m *ConfigureRequest) String() string { return proto.CompactTextString(m) }
+func (*ConfigureRequest) ProtoMessage()    {}
+func (*ConfigureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{0}
+}
+func (m *ConfigureRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConfigureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConfigureRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ConfigureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigureRequest.Merge(m, src)
+}
+func (m *ConfigureRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConfigureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigureRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigureRequest proto.InternalMessageInfo
+
+func (m *ConfigureRequest) GetClusterID() string {
+	if m != nil {
+		return m.ClusterID
+	}
+	return ""
+}
+
+func (m *ConfigureRequest) GetDatacenter() string {
+	if m != nil {
+		return m.Datacenter
+	}
+	return ""
+}
+
+func (m *ConfigureRequest) GetIsPrimary() bool {
+	if m != nil {
+		return m.IsPrimary
+	}
+	return false
+}
+
+func (m *ConfigureRequest) GetConfigJSON() []byte {
+	if m != nil {
+		return m.ConfigJSON
+	}
+	return nil
+}
+
+func (m *ConfigureRequest) GetState() map[string]string {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
+func (m *ConfigureRequest) GetOCSPResponderURL() string {
+	if m != nil {
+		return m.OCSPResponderURL
+	}
+	return ""
+}
+
+type ConfigureResponse struct {
+	// SupportsCrossSigning is true if CrossSignCA is implemented.
+	SupportsCrossSigning bool     `protobuf:"varint,1,opt,name=SupportsCrossSigning,proto3" json:"SupportsCrossSigning,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConfigureResponse) Reset()         { *m = ConfigureResponse{} }
+func (m *ConfigureResponse) String() string { return proto.CompactTextString(m) }
+func (*ConfigureResponse) ProtoMessage()    {}
+func (*ConfigureResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{1}
+}
+func (m *ConfigureResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ConfigureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ConfigureResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ConfigureResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfigureResponse.Merge(m, src)
+}
+func (m *ConfigureResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ConfigureResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfigureResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfigureResponse proto.InternalMessageInfo
+
+func (m *ConfigureResponse) GetSupportsCrossSigning() bool {
+	if m != nil {
+		return m.SupportsCrossSigning
+	}
+	return false
+}
+
+type StateRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StateRequest) Reset()         { *m = StateRequest{} }
+func (m *StateRequest) String() string { return proto.CompactTextString(m) }
+func (*StateRequest) ProtoMessage()    {}
+func (*StateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{2}
+}
+func (m *StateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateRequest.Merge(m, src)
+}
+func (m *StateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *StateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateRequest proto.InternalMessageInfo
+
+type StateResponse struct {
+	// State must not contain secrets since it is visible to operators.
+	State                map[string]string `protobuf:"bytes,1,rep,name=State,proto3" json:"State,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *StateResponse) Reset()         { *m = StateResponse{} }
+func (m *StateResponse) String() string { return proto.CompactTextString(m) }
+func (*StateResponse) ProtoMessage()    {}
+func (*StateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{3}
+}
+func (m *StateResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StateResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StateResponse.Merge(m, src)
+}
+func (m *StateResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *StateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StateResponse proto.InternalMessageInfo
+
+func (m *StateResponse) GetState() map[string]string {
+	if m != nil {
+		return m.State
+	}
+	return nil
+}
+
+type GenerateRootRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GenerateRootRequest) Reset()         { *m = GenerateRootRequest{} }
+func (m *GenerateRootRequest) String() string { return proto.CompactTextString(m) }
+func (*GenerateRootRequest) ProtoMessage()    {}
+func (*GenerateRootRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{4}
+}
+func (m *GenerateRootRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GenerateRootRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GenerateRootRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GenerateRootRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenerateRootRequest.Merge(m, src)
+}
+func (m *GenerateRootRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GenerateRootRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenerateRootRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenerateRootRequest proto.InternalMessageInfo
+
+type GenerateRootResponse struct {
+	RootPEM              string   `protobuf:"bytes,1,opt,name=RootPEM,proto3" json:"RootPEM,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GenerateRootResponse) Reset()         { *m = GenerateRootResponse{} }
+func (m *GenerateRootResponse) String() string { return proto.CompactTextString(m) }
+func (*GenerateRootResponse) ProtoMessage()    {}
+func (*GenerateRootResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{5}
+}
+func (m *GenerateRootResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GenerateRootResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GenerateRootResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GenerateRootResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenerateRootResponse.Merge(m, src)
+}
+func (m *GenerateRootResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GenerateRootResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenerateRootResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenerateRootResponse proto.InternalMessageInfo
+
+func (m *GenerateRootResponse) GetRootPEM() string {
+	if m != nil {
+		return m.RootPEM
+	}
+	return ""
+}
+
+type ActiveIntermediateRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ActiveIntermediateRequest) Reset()         { *m = ActiveIntermediateRequest{} }
+func (m *ActiveIntermediateRequest) String() string { return proto.CompactTextString(m) }
+func (*ActiveIntermediateRequest) ProtoMessage()    {}
+func (*ActiveIntermediateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{6}
+}
+func (m *ActiveIntermediateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ActiveIntermediateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ActiveIntermediateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ActiveIntermediateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActiveIntermediateRequest.Merge(m, src)
+}
+func (m *ActiveIntermediateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ActiveIntermediateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActiveIntermediateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActiveIntermediateRequest proto.InternalMessageInfo
+
+type ActiveIntermediateResponse struct {
+	// IntermediatePEM is the signing cert. In the primary datacenter this is
+	// the root unless the provider uses a separate intermediate.
+	IntermediatePEM      string   `protobuf:"bytes,1,opt,name=IntermediatePEM,proto3" json:"IntermediatePEM,omitempty"`
+	RootPEM              string   `protobuf:"bytes,2,opt,name=RootPEM,proto3" json:"RootPEM,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ActiveIntermediateResponse) Reset()         { *m = ActiveIntermediateResponse{} }
+func (m *ActiveIntermediateResponse) String() string { return proto.CompactTextString(m) }
+func (*ActiveIntermediateResponse) ProtoMessage()    {}
+func (*ActiveIntermediateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{7}
+}
+func (m *ActiveIntermediateResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ActiveIntermediateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ActiveIntermediateResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ActiveIntermediateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActiveIntermediateResponse.Merge(m, src)
+}
+func (m *ActiveIntermediateResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ActiveIntermediateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActiveIntermediateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActiveIntermediateResponse proto.InternalMessageInfo
+
+func (m *ActiveIntermediateResponse) GetIntermediatePEM() string {
+	if m != nil {
+		return m.IntermediatePEM
+	}
+	return ""
+}
+
+func (m *ActiveIntermediateResponse) GetRootPEM() string {
+	if m != nil {
+		return m.RootPEM
+	}
+	return ""
+}
+
+type GenerateIntermediateCSRRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GenerateIntermediateCSRRequest) Reset()         { *m = GenerateIntermediateCSRRequest{} }
+func (m *GenerateIntermediateCSRRequest) String() string { return proto.CompactTextString(m) }
+func (*GenerateIntermediateCSRRequest) ProtoMessage()    {}
+func (*GenerateIntermediateCSRRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{8}
+}
+func (m *GenerateIntermediateCSRRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GenerateIntermediateCSRRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GenerateIntermediateCSRRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GenerateIntermediateCSRRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenerateIntermediateCSRRequest.Merge(m, src)
+}
+func (m *GenerateIntermediateCSRRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GenerateIntermediateCSRRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenerateIntermediateCSRRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenerateIntermediateCSRRequest proto.InternalMessageInfo
+
+type GenerateIntermediateCSRResponse struct {
+	CSRPEM               string   `protobuf:"bytes,1,opt,name=CSRPEM,proto3" json:"CSRPEM,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GenerateIntermediateCSRResponse) Reset()         { *m = GenerateIntermediateCSRResponse{} }
+func (m *GenerateIntermediateCSRResponse) String() string { return proto.CompactTextString(m) }
+func (*GenerateIntermediateCSRResponse) ProtoMessage()    {}
+func (*GenerateIntermediateCSRResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{9}
+}
+func (m *GenerateIntermediateCSRResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GenerateIntermediateCSRResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GenerateIntermediateCSRResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *GenerateIntermediateCSRResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GenerateIntermediateCSRResponse.Merge(m, src)
+}
+func (m *GenerateIntermediateCSRResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *GenerateIntermediateCSRResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GenerateIntermediateCSRResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GenerateIntermediateCSRResponse proto.InternalMessageInfo
+
+func (m *GenerateIntermediateCSRResponse) GetCSRPEM() string {
+	if m != nil {
+		return m.CSRPEM
+	}
+	return ""
+}
+
+type SetIntermediateRequest struct {
+	IntermediatePEM      string   `protobuf:"bytes,1,opt,name=IntermediatePEM,proto3" json:"IntermediatePEM,omitempty"`
+	RootPEM              string   `protobuf:"bytes,2,opt,name=RootPEM,proto3" json:"RootPEM,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetIntermediateRequest) Reset()         { *m = SetIntermediateRequest{} }
+func (m *SetIntermediateRequest) String() string { return proto.CompactTextString(m) }
+func (*SetIntermediateRequest) ProtoMessage()    {}
+func (*SetIntermediateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{10}
+}
+func (m *SetIntermediateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetIntermediateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetIntermediateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetIntermediateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetIntermediateRequest.Merge(m, src)
+}
+func (m *SetIntermediateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetIntermediateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetIntermediateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetIntermediateRequest proto.InternalMessageInfo
+
+func (m *SetIntermediateRequest) GetIntermediatePEM() string {
+	if m != nil {
+		return m.IntermediatePEM
+	}
+	return ""
+}
+
+func (m *SetIntermediateRequest) GetRootPEM() string {
+	if m != nil {
+		return m.RootPEM
+	}
+	return ""
+}
+
+type SetIntermediateResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetIntermediateResponse) Reset()         { *m = SetIntermediateResponse{} }
+func (m *SetIntermediateResponse) String() string { return proto.CompactTextString(m) }
+func (*SetIntermediateResponse) ProtoMessage()    {}
+func (*SetIntermediateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{11}
+}
+func (m *SetIntermediateResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetIntermediateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetIntermediateResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetIntermediateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetIntermediateResponse.Merge(m, src)
+}
+func (m *SetIntermediateResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetIntermediateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetIntermediateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetIntermediateResponse proto.InternalMessageInfo
+
+type SignLeafRequest struct {
+	// CSR is the DER encoded certificate signing request.
+	CSR []byte `protobuf:"bytes,1,opt,name=CSR,proto3" json:"CSR,omitempty"`
+	// TTL is the requested lifetime of the cert. Zero means the configured
+	// leaf cert TTL. Providers must not issue certs that outlive the
+	// configured TTL.
+	TTL                  *types.Duration `protobuf:"bytes,2,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *SignLeafRequest) Reset()         { *m = SignLeafRequest{} }
+func (m *SignLeafRequest) String() string { return proto.CompactTextString(m) }
+func (*SignLeafRequest) ProtoMessage()    {}
+func (*SignLeafRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{12}
+}
+func (m *SignLeafRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignLeafRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignLeafRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignLeafRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignLeafRequest.Merge(m, src)
+}
+func (m *SignLeafRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignLeafRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignLeafRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignLeafRequest proto.InternalMessageInfo
+
+func (m *SignLeafRequest) GetCSR() []byte {
+	if m != nil {
+		return m.CSR
+	}
+	return nil
+}
+
+func (m *SignLeafRequest) GetTTL() *types.Duration {
+	if m != nil {
+		return m.TTL
+	}
+	return nil
+}
+
+type SignLeafResponse struct {
+	CertPEM              string   `protobuf:"bytes,1,opt,name=CertPEM,proto3" json:"CertPEM,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignLeafResponse) Reset()         { *m = SignLeafResponse{} }
+func (m *SignLeafResponse) String() string { return proto.CompactTextString(m) }
+func (*SignLeafResponse) ProtoMessage()    {}
+func (*SignLeafResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{13}
+}
+func (m *SignLeafResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignLeafResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignLeafResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignLeafResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignLeafResponse.Merge(m, src)
+}
+func (m *SignLeafResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignLeafResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignLeafResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignLeafResponse proto.InternalMessageInfo
+
+func (m *SignLeafResponse) GetCertPEM() string {
+	if m != nil {
+		return m.CertPEM
+	}
+	return ""
+}
+
+type SignIntermediateRequest struct {
+	// CSR is the DER encoded certificate signing request.
+	CSR                  []byte   `protobuf:"bytes,1,opt,name=CSR,proto3" json:"CSR,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignIntermediateRequest) Reset()         { *m = SignIntermediateRequest{} }
+func (m *SignIntermediateRequest) String() string { return proto.CompactTextString(m) }
+func (*SignIntermediateRequest) ProtoMessage()    {}
+func (*SignIntermediateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{14}
+}
+func (m *SignIntermediateRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignIntermediateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignIntermediateRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignIntermediateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignIntermediateRequest.Merge(m, src)
+}
+func (m *SignIntermediateRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignIntermediateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignIntermediateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignIntermediateRequest proto.InternalMessageInfo
+
+func (m *SignIntermediateRequest) GetCSR() []byte {
+	if m != nil {
+		return m.CSR
+	}
+	return nil
+}
+
+type SignIntermediateResponse struct {
+	CertPEM              string   `protobuf:"bytes,1,opt,name=CertPEM,proto3" json:"CertPEM,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SignIntermediateResponse) Reset()         { *m = SignIntermediateResponse{} }
+func (m *SignIntermediateResponse) String() string { return proto.CompactTextString(m) }
+func (*SignIntermediateResponse) ProtoMessage()    {}
+func (*SignIntermediateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{15}
+}
+func (m *SignIntermediateResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SignIntermediateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SignIntermediateResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SignIntermediateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SignIntermediateResponse.Merge(m, src)
+}
+func (m *SignIntermediateResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SignIntermediateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SignIntermediateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SignIntermediateResponse proto.InternalMessageInfo
+
+func (m *SignIntermediateResponse) GetCertPEM() string {
+	if m != nil {
+		return m.CertPEM
+	}
+	return ""
+}
+
+type CrossSignCARequest struct {
+	// Cert is the DER encoded CA cert to cross-sign.
+	Cert                 []byte   `protobuf:"bytes,1,opt,name=Cert,proto3" json:"Cert,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CrossSignCARequest) Reset()         { *m = CrossSignCARequest{} }
+func (m *CrossSignCARequest) String() string { return proto.CompactTextString(m) }
+func (*CrossSignCARequest) ProtoMessage()    {}
+func (*CrossSignCARequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{16}
+}
+func (m *CrossSignCARequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CrossSignCARequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CrossSignCARequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CrossSignCARequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CrossSignCARequest.Merge(m, src)
+}
+func (m *CrossSignCARequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CrossSignCARequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CrossSignCARequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CrossSignCARequest proto.InternalMessageInfo
+
+func (m *CrossSignCARequest) GetCert() []byte {
+	if m != nil {
+		return m.Cert
+	}
+	return nil
+}
+
+type CrossSignCAResponse struct {
+	CertPEM              string   `protobuf:"bytes,1,opt,name=CertPEM,proto3" json:"CertPEM,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CrossSignCAResponse) Reset()         { *m = CrossSignCAResponse{} }
+func (m *CrossSignCAResponse) String() string { return proto.CompactTextString(m) }
+func (*CrossSignCAResponse) ProtoMessage()    {}
+func (*CrossSignCAResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{17}
+}
+func (m *CrossSignCAResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CrossSignCAResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CrossSignCAResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CrossSignCAResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CrossSignCAResponse.Merge(m, src)
+}
+func (m *CrossSignCAResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CrossSignCAResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CrossSignCAResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CrossSignCAResponse proto.InternalMessageInfo
+
+func (m *CrossSignCAResponse) GetCertPEM() string {
+	if m != nil {
+		return m.CertPEM
+	}
+	return ""
+}
+
+type CleanupRequest struct {
+	// ProviderTypeChange is true if the provider is being replaced by a
+	// different type of provider.
+	ProviderTypeChange bool `protobuf:"varint,1,opt,name=ProviderTypeChange,proto3" json:"ProviderTypeChange,omitempty"`
+	// OtherConfigJSON is the JSON encoded configuration of the provider
+	// replacing this one.
+	OtherConfigJSON      []byte   `protobuf:"bytes,2,opt,name=OtherConfigJSON,proto3" json:"OtherConfigJSON,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CleanupRequest) Reset()         { *m = CleanupRequest{} }
+func (m *CleanupRequest) String() string { return proto.CompactTextString(m) }
+func (*CleanupRequest) ProtoMessage()    {}
+func (*CleanupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{18}
+}
+func (m *CleanupRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CleanupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CleanupRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CleanupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CleanupRequest.Merge(m, src)
+}
+func (m *CleanupRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CleanupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CleanupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CleanupRequest proto.InternalMessageInfo
+
+func (m *CleanupRequest) GetProviderTypeChange() bool {
+	if m != nil {
+		return m.ProviderTypeChange
+	}
+	return false
+}
+
+func (m *CleanupRequest) GetOtherConfigJSON() []byte {
+	if m != nil {
+		return m.OtherConfigJSON
+	}
+	return nil
+}
+
+type CleanupResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CleanupResponse) Reset()         { *m = CleanupResponse{} }
+func (m *CleanupResponse) String() string { return proto.CompactTextString(m) }
+func (*CleanupResponse) ProtoMessage()    {}
+func (*CleanupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c81eeeca68bf642c, []int{19}
+}
+func (m *CleanupResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CleanupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CleanupResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CleanupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CleanupResponse.Merge(m, src)
+}
+func (m *CleanupResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CleanupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CleanupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CleanupResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*ConfigureRequest)(nil), "caprovider.ConfigureRequest")
+	proto.RegisterMapType((map[string]string)(nil), "caprovider.ConfigureRequest.StateEntry")
+	proto.RegisterType((*ConfigureResponse)(nil), "caprovider.ConfigureResponse")
+	proto.RegisterType((*StateRequest)(nil), "caprovider.StateRequest")
+	proto.RegisterType((*StateResponse)(nil), "caprovider.StateResponse")
+	proto.RegisterMapType((map[string]string)(nil), "caprovider.StateResponse.StateEntry")
+	proto.RegisterType((*GenerateRootRequest)(nil), "caprovider.GenerateRootRequest")
+	proto.RegisterType((*GenerateRootResponse)(nil), "caprovider.GenerateRootResponse")
+	proto.RegisterType((*ActiveIntermediateRequest)(nil), "caprovider.ActiveIntermediateRequest")
+	proto.RegisterType((*ActiveIntermediateResponse)(nil), "caprovider.ActiveIntermediateResponse")
+	proto.RegisterType((*GenerateIntermediateCSRRequest)(nil), "caprovider.GenerateIntermediateCSRRequest")
+	proto.RegisterType((*GenerateIntermediateCSRResponse)(nil), "caprovider.GenerateIntermediateCSRResponse")
+	proto.RegisterType((*SetIntermediateRequest)(nil), "caprovider.SetIntermediateRequest")
+	proto.RegisterType((*SetIntermediateResponse)(nil), "caprovider.SetIntermediateResponse")
+	proto.RegisterType((*SignLeafRequest)(nil), "caprovider.SignLeafRequest")
+	proto.RegisterType((*SignLeafResponse)(nil), "caprovider.SignLeafResponse")
+	proto.RegisterType((*SignIntermediateRequest)(nil), "caprovider.SignIntermediateRequest")
+	proto.RegisterType((*SignIntermediateResponse)(nil), "caprovider.SignIntermediateResponse")
+	proto.RegisterType((*CrossSignCARequest)(nil), "caprovider.CrossSignCARequest")
+	proto.RegisterType((*CrossSignCAResponse)(nil), "caprovider.CrossSignCAResponse")
+	proto.RegisterType((*CleanupRequest)(nil), "caprovider.CleanupRequest")
+	proto.RegisterType((*CleanupResponse)(nil), "caprovider.CleanupResponse")
+}
+
+func init() {
+	proto.RegisterFile("proto/pbcaprovider/caprovider.proto", fileDescriptor_c81eeeca68bf642c)
+}
+
+var fileDescriptor_c81eeeca68bf642c = []byte{
+	// 831 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xdd, 0x4e, 0xe3, 0x46,
+	0x14, 0xc6, 0x09, 0x04, 0x38, 0xa4, 0x24, 0x0c, 0x14, 0x1c, 0x43, 0x4d, 0x64, 0x68, 0x1b, 0x41,
+	0x65, 0x57, 0x69, 0x2f, 0x28, 0x52, 0xab, 0x52, 0x07, 0x21, 0x10, 0x2d, 0x91, 0x4d, 0x6f, 0x2a,
+	0xa4, 0xae, 0x93, 0x0c, 0x89, 0x77, 0x83, 0xed, 0x1d, 0x8f, 0x91, 0xf2, 0x00, 0xfb, 0x0e, 0xfb,
+	0x1e, 0xfb, 0x12, 0x7b, 0xb9, 0x8f, 0xb0, 0x62, 0xef, 0xf6, 0x29, 0x56, 0xb6, 0xc7, 0x7f, 0xb1,
+	0x03, 0x2b, 0xed, 0xde, 0xcd, 0x9c, 0xf3, 0xcd, 0x77, 0xbe, 0x33, 0x9e, 0xef, 0xc8, 0xb0, 0xe7,
+	0x10, 0x9b, 0xda, 0x8a, 0xd3, 0xeb, 0x1b, 0x0e, 0xb1, 0xef, 0xcd, 0x01, 0x26, 0x4a, 0xb2, 0x94,
+	0x83, 0x2c, 0x82, 0x24, 0x22, 0x88, 0x43, 0xdb, 0x1e, 0x8e, 0xb1, 0x12, 0x64, 0x7a, 0xde, 0xad,
+	0x32, 0xf0, 0x88, 0x41, 0x4d, 0xdb, 0x0a, 0xb1, 0xd2, 0x9b, 0x12, 0xd4, 0x55, 0xdb, 0xba, 0x35,
+	0x87, 0x1e, 0xc1, 0x1a, 0x7e, 0xe9, 0x61, 0x97, 0xa2, 0x1d, 0x58, 0x56, 0xc7, 0x9e, 0x4b, 0x31,
+	0x39, 0xef, 0xf0, 0x5c, 0x93, 0x6b, 0x2d, 0x6b, 0x49, 0x00, 0x89, 0x00, 0x1d, 0x83, 0x1a, 0x7d,
+	0x6c, 0x51, 0x4c, 0xf8, 0x52, 0x90, 0x4e, 0x45, 0xfc, 0xd3, 0xe7, 0x6e, 0x97, 0x98, 0x77, 0x06,
+	0x99, 0xf0, 0xe5, 0x26, 0xd7, 0x5a, 0xd2, 0x92, 0x80, 0x7f, 0x3a, 0xac, 0x77, 0xa1, 0x5f, 0xfd,
+	0xc3, 0xcf, 0x37, 0xb9, 0x56, 0x55, 0x4b, 0x45, 0xd0, 0xef, 0xb0, 0xa0, 0x53, 0x83, 0x62, 0x7e,
+	0xa1, 0x59, 0x6e, 0xad, 0xb4, 0x7f, 0x94, 0x53, 0xed, 0x4d, 0x0b, 0x95, 0x03, 0xe4, 0xa9, 0x45,
+	0xc9, 0x44, 0x0b, 0x4f, 0xa1, 0x03, 0xa8, 0x5f, 0xa9, 0x7a, 0x57, 0xc3, 0xae, 0x63, 0x5b, 0x03,
+	0x4c, 0xfe, 0xd5, 0x2e, 0xf9, 0x4a, 0x20, 0x31, 0x17, 0x17, 0x8e, 0x00, 0x12, 0x02, 0x54, 0x87,
+	0xf2, 0x0b, 0x3c, 0x61, 0xed, 0xfa, 0x4b, 0xb4, 0x01, 0x0b, 0xf7, 0xc6, 0xd8, 0xc3, 0xac, 0xc7,
+	0x70, 0x73, 0x5c, 0x3a, 0xe2, 0xa4, 0x33, 0x58, 0x4b, 0x69, 0xf1, 0x29, 0x5d, 0x8c, 0xda, 0xb0,
+	0xa1, 0x7b, 0x8e, 0x63, 0x13, 0xea, 0xaa, 0xc4, 0x76, 0x5d, 0xdd, 0x1c, 0x5a, 0xa6, 0x35, 0x0c,
+	0x18, 0x97, 0xb4, 0xc2, 0x9c, 0xb4, 0x0a, 0xd5, 0x40, 0x02, 0x6b, 0x48, 0x7a, 0xc5, 0xc1, 0x37,
+	0x2c, 0xc0, 0x58, 0x8f, 0xa3, 0xfb, 0xe0, 0x82, 0xfb, 0xd8, 0x4f, 0xdf, 0x47, 0x06, 0x99, 0xbf,
+	0x8c, 0x2f, 0x68, 0xf0, 0x5b, 0x58, 0x3f, 0xc3, 0x16, 0x26, 0x3e, 0xbf, 0x6d, 0xd3, 0x48, 0xde,
+	0xcf, 0xb0, 0x91, 0x0d, 0x33, 0x91, 0x3c, 0x2c, 0xfa, 0xfb, 0xee, 0xe9, 0xdf, 0x8c, 0x3e, 0xda,
+	0x4a, 0xdb, 0xd0, 0x38, 0xe9, 0x53, 0xf3, 0x1e, 0x9f, 0xfb, 0x6f, 0xe3, 0x0e, 0x0f, 0xcc, 0x54,
+	0xb7, 0xcf, 0x40, 0x28, 0x4a, 0x32, 0xd2, 0x16, 0xd4, 0xd2, 0xf1, 0x84, 0x7c, 0x3a, 0x9c, 0x2e,
+	0x5f, 0xca, 0x96, 0x6f, 0x82, 0x18, 0x09, 0x4e, 0x1f, 0x52, 0x75, 0x2d, 0xd2, 0xf0, 0x1b, 0xec,
+	0xce, 0x44, 0x30, 0x21, 0x9b, 0x50, 0x51, 0x75, 0x2d, 0xa9, 0xcf, 0x76, 0xd2, 0x0d, 0x6c, 0xea,
+	0x98, 0x16, 0x34, 0xf6, 0x55, 0xa4, 0x37, 0x60, 0x2b, 0xc7, 0x1e, 0x0a, 0x92, 0xba, 0x50, 0xf3,
+	0x1f, 0xd0, 0x25, 0x36, 0x6e, 0xa3, 0x8a, 0x75, 0x28, 0xab, 0xba, 0x16, 0x54, 0xa9, 0x6a, 0xfe,
+	0x12, 0x1d, 0x42, 0xf9, 0xfa, 0xfa, 0x32, 0x60, 0x5d, 0x69, 0x37, 0xe4, 0x70, 0x0e, 0xc8, 0xd1,
+	0x1c, 0x90, 0x3b, 0x6c, 0x0e, 0x68, 0x3e, 0x4a, 0xfa, 0x09, 0xea, 0x09, 0x63, 0xf2, 0x51, 0x55,
+	0x4c, 0xd2, 0x1f, 0x95, 0x6d, 0xa5, 0x43, 0xd8, 0xf2, 0xd1, 0x45, 0x9d, 0xe7, 0x74, 0x48, 0xbf,
+	0x02, 0x9f, 0x07, 0x3f, 0x59, 0xa2, 0x05, 0x28, 0x36, 0x8a, 0x7a, 0x12, 0xb1, 0x23, 0x98, 0xf7,
+	0x01, 0x8c, 0x3e, 0x58, 0x4b, 0x0a, 0xac, 0x67, 0x90, 0x4f, 0x52, 0x3f, 0x87, 0x55, 0x75, 0x8c,
+	0x0d, 0xcb, 0x73, 0x22, 0x5a, 0x19, 0x50, 0x97, 0x79, 0xea, 0x7a, 0xe2, 0x60, 0x75, 0x64, 0x58,
+	0x43, 0xcc, 0x7c, 0x5b, 0x90, 0xf1, 0x3f, 0xef, 0x15, 0x1d, 0x61, 0x92, 0x1a, 0x64, 0xa5, 0x40,
+	0xd1, 0x74, 0x58, 0x5a, 0x83, 0x5a, 0x5c, 0x2b, 0x14, 0xd6, 0xfe, 0x58, 0x01, 0x50, 0x4f, 0x22,
+	0x56, 0x74, 0x01, 0xcb, 0xf1, 0x28, 0x41, 0x3b, 0x8f, 0x4d, 0x3b, 0xe1, 0xbb, 0x19, 0x59, 0xf6,
+	0x2a, 0xe6, 0xd0, 0x1f, 0x6c, 0x56, 0x20, 0xbe, 0x60, 0x4a, 0x84, 0x1c, 0x8d, 0x99, 0xf3, 0x43,
+	0x9a, 0x43, 0x3a, 0x54, 0xd3, 0xf6, 0x46, 0xbb, 0x69, 0x70, 0xc1, 0x3c, 0x10, 0x9a, 0xb3, 0x01,
+	0x31, 0x29, 0x06, 0x94, 0x37, 0x39, 0xfa, 0x3e, 0x7d, 0x72, 0xe6, 0x84, 0x10, 0x7e, 0x78, 0x0a,
+	0x16, 0x97, 0xa1, 0xb0, 0x35, 0xc3, 0xc7, 0xe8, 0xa0, 0x48, 0x65, 0xf1, 0x38, 0x10, 0x0e, 0x3f,
+	0x0b, 0x1b, 0x57, 0xbd, 0x81, 0xda, 0x94, 0x49, 0x91, 0x94, 0xb9, 0xe1, 0xc2, 0xf9, 0x20, 0xec,
+	0x3d, 0x8a, 0x89, 0xd9, 0xcf, 0x60, 0x29, 0x72, 0x25, 0xda, 0xce, 0x1c, 0xc9, 0xba, 0x5f, 0xd8,
+	0x29, 0x4e, 0xc6, 0x44, 0xff, 0x87, 0xf6, 0xce, 0xe8, 0xdc, 0x9b, 0x3e, 0x53, 0x24, 0x74, 0xff,
+	0x71, 0x50, 0x5c, 0xa0, 0x0b, 0x2b, 0x29, 0x13, 0x22, 0x31, 0xf3, 0x52, 0x73, 0x3e, 0x16, 0x76,
+	0x67, 0xe6, 0x63, 0xc6, 0x0e, 0x2c, 0x32, 0xe7, 0x20, 0x21, 0x83, 0xce, 0x58, 0x57, 0xd8, 0x2e,
+	0xcc, 0x45, 0x2c, 0x7f, 0xfd, 0xf9, 0xf6, 0x41, 0xe4, 0xde, 0x3d, 0x88, 0xdc, 0xfb, 0x07, 0x91,
+	0x7b, 0xfd, 0x41, 0x9c, 0xfb, 0x4f, 0x1e, 0x9a, 0x74, 0xe4, 0xf5, 0xe4, 0xbe, 0x7d, 0xa7, 0x8c,
+	0x0c, 0x77, 0x64, 0xf6, 0x6d, 0xe2, 0x28, 0x7d, 0xdb, 0x72, 0xbd, 0xb1, 0x92, 0xff, 0xbb, 0xea,
+	0x55, 0x82, 0xd8, 0x2f, 0x9f, 0x06, 0x00, 0x77, 0xaf, 0xe4, 0x46, 0x7a, 0x09, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// CAProviderClient is the client API for CAProvider service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type CAProviderClient interface {
+	// Configure is called before any other method each time Consul creates a
+	// provider instance, such as on leader election or a CA config change.
+	Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*ConfigureResponse, error)
+	// State returns the provider state for Consul to persist and pass back
+	// to Configure.
+	State(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*StateResponse, error)
+	// GenerateRoot makes sure a root exists in the primary datacenter,
+	// creating one if needed, and returns it.
+	GenerateRoot(ctx context.Context, in *GenerateRootRequest, opts ...grpc.CallOption) (*GenerateRootResponse, error)
+	// ActiveIntermediate returns the cert leaf certs are signed with along
+	// with the root it chains to.
+	ActiveIntermediate(ctx context.Context, in *ActiveIntermediateRequest, opts ...grpc.CallOption) (*ActiveIntermediateResponse, error)
+	// GenerateIntermediateCSR returns a CSR for a new intermediate in a
+	// secondary datacenter, to be signed by the primary datacenter's root.
+	GenerateIntermediateCSR(ctx context.Context, in *GenerateIntermediateCSRRequest, opts ...grpc.CallOption) (*GenerateIntermediateCSRResponse, error)
+	// SetIntermediate installs an intermediate signed for the last CSR
+	// generated, making it the signing cert.
+	SetIntermediate(ctx context.Context, in *SetIntermediateRequest, opts ...grpc.CallOption) (*SetIntermediateResponse, error)
+	// SignLeaf signs a leaf cert for a service or agent.
+	SignLeaf(ctx context.Context, in *SignLeafRequest, opts ...grpc.CallOption) (*SignLeafResponse, error)
+	// SignIntermediate signs a secondary datacenter's intermediate CSR with
+	// the root.
+	SignIntermediate(ctx context.Context, in *SignIntermediateRequest, opts ...grpc.CallOption) (*SignIntermediateResponse, error)
+	// CrossSignCA signs another CA's root with the root so that leaf certs
+	// keep validating during a root rotation.
+	CrossSignCA(ctx context.Context, in *CrossSignCARequest, opts ...grpc.CallOption) (*CrossSignCAResponse, error)
+	// Cleanup is called when the provider is replaced and should release any
+	// resources it created.
+	Cleanup(ctx context.Context, in *CleanupRequest, opts ...grpc.CallOption) (*CleanupResponse, error)
+}
+
+type cAProviderClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewCAProviderClient(cc *grpc.ClientConn) CAProviderClient {
+	return &cAProviderClient{cc}
+}
+
+func (c *cAProviderClient) Configure(ctx context.Context, in *ConfigureRequest, opts ...grpc.CallOption) (*ConfigureResponse, error) {
+	out := new(ConfigureResponse)
+	err := c.cc.Invoke(ctx, "/caprovider.CAProvider/Configure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cAProviderClient) State(ctx context.Context, in *StateRequest, opts ...grpc.CallOption) (*StateResponse, error) {
+	out := new(StateResponse)
+	err := c.cc.Invoke(ctx, "/caprovider.CAProvider/State", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cAProviderClient) GenerateRoot(ctx context.Context, in *GenerateRootRequest, opts ...grpc.CallOption) (*GenerateRootResponse, error) {
+	out := new(GenerateRootResponse)
+	err := c.cc.Invoke(ctx, "/caprovider.CAProvider/GenerateRoot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cAProviderClient) ActiveIntermediate(ctx context.Context, in *ActiveIntermediateRequest, opts ...grpc.CallOption) (*ActiveIntermediateResponse, error) {
+	out := new(ActiveIntermediateResponse)
+	err := c.cc.Invoke(ctx, "/caprovider.CAProvider/ActiveIntermediate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cAProviderClient) GenerateIntermediateCSR(ctx context.Context, in *GenerateIntermediateCSRRequest, opts ...grpc.CallOption) (*GenerateIntermediateCSRResponse, error) {
+	out := new(GenerateIntermediateCSRResponse)
+	err := c.cc.Invoke(ctx, "/caprovider.CAProvider/GenerateIntermediateCSR", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cAProviderClient) SetIntermediate(ctx context.Context, in *SetIntermediateRequest, opts ...grpc.CallOption) (*SetIntermediateResponse, error) {
+	out := new(SetIntermediateResponse)
+	err := c.cc.Invoke(ctx, "/caprovider.CAProvider/SetIntermediate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cAProviderClient) SignLeaf(ctx context.Context, in *SignLeafRequest, opts ...grpc.CallOption) (*SignLeafResponse, error) {
+	out := new(SignLeafResponse)
+	err := c.cc.Invoke(ctx, "/caprovider.CAProvider/SignLeaf", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cAProviderClient) SignIntermediate(ctx context.Context, in *SignIntermediateRequest, opts ...grpc.CallOption) (*SignIntermediateResponse, error) {
+	out := new(SignIntermediateResponse)
+	err := c.cc.Invoke(ctx, "/caprovider.CAProvider/SignIntermediate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cAProviderClient) CrossSignCA(ctx context.Context, in *CrossSignCARequest, opts ...grpc.CallOption) (*CrossSignCAResponse, error) {
+	out := new(CrossSignCAResponse)
+	err := c.cc.Invoke(ctx, "/caprovider.CAProvider/CrossSignCA", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cAProviderClient) Cleanup(ctx context.Context, in *CleanupRequest, opts ...grpc.CallOption) (*CleanupResponse, error) {
+	out := new(CleanupResponse)
+	err := c.cc.Invoke(ctx, "/caprovider.CAProvider/Cleanup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CAProviderServer is the server API for CAProvider service.
+type CAProviderServer interface {
+	// Configure is called before any other method each time Consul creates a
+	// provider instance, such as on leader election or a CA config change.
+	Configure(context.Context, *ConfigureRequest) (*ConfigureResponse, error)
+	// State returns the provider state for Consul to persist and pass back
+	// to Configure.
+	State(context.Context, *StateRequest) (*StateResponse, error)
+	// GenerateRoot makes sure a root exists in the primary datacenter,
+	// creating one if needed, and returns it.
+	GenerateRoot(context.Context, *GenerateRootRequest) (*GenerateRootResponse, error)
+	// ActiveIntermediate returns the cert leaf certs are signed with along
+	// with the root it chains to.
+	ActiveIntermediate(context.Context, *ActiveIntermediateRequest) (*ActiveIntermediateResponse, error)
+	// GenerateIntermediateCSR returns a CSR for a new intermediate in a
+	// secondary datacenter, to be signed by the primary datacenter's root.
+	GenerateIntermediateCSR(context.Context, *GenerateIntermediateCSRRequest) (*GenerateIntermediateCSRResponse, error)
+	// SetIntermediate installs an intermediate signed for the last CSR
+	// generated, making it the signing cert.
+	SetIntermediate(context.Context, *SetIntermediateRequest) (*SetIntermediateResponse, error)
+	// SignLeaf signs a leaf cert for a service or agent.
+	SignLeaf(context.Context, *SignLeafRequest) (*SignLeafResponse, error)
+	// SignIntermediate signs a secondary datacenter's intermediate CSR with
+	// the root.
+	SignIntermediate(context.Context, *SignIntermediateRequest) (*SignIntermediateResponse, error)
+	// CrossSignCA signs another CA's root with the root so that leaf certs
+	// keep validating during a root rotation.
+	CrossSignCA(context.Context, *CrossSignCARequest) (*CrossSignCAResponse, error)
+	// Cleanup is called when the provider is replaced and should release any
+	// resources it created.
+	Cleanup(context.Context, *CleanupRequest) (*CleanupResponse, error)
+}
+
+// UnimplementedCAProviderServer can be embedded to have forward compatible implementations.
+type UnimplementedCAProviderServer struct {
+}
+
+func (*UnimplementedCAProviderServer) Configure(ctx context.Context, req *ConfigureRequest) (*ConfigureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Configure not implemented")
+}
+func (*UnimplementedCAProviderServer) State(ctx context.Context, req *StateRequest) (*StateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method State not implemented")
+}
+func (*UnimplementedCAProviderServer) GenerateRoot(ctx context.Context, req *GenerateRootRequest) (*GenerateRootResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateRoot not implemented")
+}
+func (*UnimplementedCAProviderServer) ActiveIntermediate(ctx context.Context, req *ActiveIntermediateRequest) (*ActiveIntermediateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ActiveIntermediate not implemented")
+}
+func (*UnimplementedCAProviderServer) GenerateIntermediateCSR(ctx context.Context, req *GenerateIntermediateCSRRequest) (*GenerateIntermediateCSRResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateIntermediateCSR not implemented")
+}
+func (*UnimplementedCAProviderServer) SetIntermediate(ctx context.Context, req *SetIntermediateRequest) (*SetIntermediateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetIntermediate not implemented")
+}
+func (*UnimplementedCAProviderServer) SignLeaf(ctx context.Context, req *SignLeafRequest) (*SignLeafResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignLeaf not implemented")
+}
+func (*UnimplementedCAProviderServer) SignIntermediate(ctx context.Context, req *SignIntermediateRequest) (*SignIntermediateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignIntermediate not implemented")
+}
+func (*UnimplementedCAProviderServer) CrossSignCA(ctx context.Context, req *CrossSignCARequest) (*CrossSignCAResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CrossSignCA not implemented")
+}
+func (*UnimplementedCAProviderServer) Cleanup(ctx context.Context, req *CleanupRequest) (*CleanupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cleanup not implemented")
+}
+
+func RegisterCAProviderServer(s *grpc.Server, srv CAProviderServer) {
+	s.RegisterService(&_CAProvider_serviceDesc, srv)
+}
+
+func _CAProvider_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CAProviderServer).Configure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/caprovider.CAProvider/Configure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CAProviderServer).Configure(ctx, req.(*ConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CAProvider_State_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CAProviderServer).State(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/caprovider.CAProvider/State",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CAProviderServer).State(ctx, req.(*StateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CAProvider_GenerateRoot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateRootRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CAProviderServer).GenerateRoot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/caprovider.CAProvider/GenerateRoot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CAProviderServer).GenerateRoot(ctx, req.(*GenerateRootRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CAProvider_ActiveIntermediate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActiveIntermediateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CAProviderServer).ActiveIntermediate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/caprovider.CAProvider/ActiveIntermediate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CAProviderServer).ActiveIntermediate(ctx, req.(*ActiveIntermediateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CAProvider_GenerateIntermediateCSR_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateIntermediateCSRRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CAProviderServer).GenerateIntermediateCSR(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/caprovider.CAProvider/GenerateIntermediateCSR",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CAProviderServer).GenerateIntermediateCSR(ctx, req.(*GenerateIntermediateCSRRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CAProvider_SetIntermediate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetIntermediateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CAProviderServer).SetIntermediate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/caprovider.CAProvider/SetIntermediate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CAProviderServer).SetIntermediate(ctx, req.(*SetIntermediateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CAProvider_SignLeaf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignLeafRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CAProviderServer).SignLeaf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/caprovider.CAProvider/SignLeaf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CAProviderServer).SignLeaf(ctx, req.(*SignLeafRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CAProvider_SignIntermediate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignIntermediateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CAProviderServer).SignIntermediate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/caprovider.CAProvider/SignIntermediate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CAProviderServer).SignIntermediate(ctx, req.(*SignIntermediateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CAProvider_CrossSignCA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CrossSignCARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CAProviderServer).CrossSignCA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/caprovider.CAProvider/CrossSignCA",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CAProviderServer).CrossSignCA(ctx, req.(*CrossSignCARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CAProvider_Cleanup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CleanupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CAProviderServer).Cleanup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/caprovider.CAProvider/Cleanup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CAProviderServer).Cleanup(ctx, req.(*CleanupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _CAProvider_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "caprovider.CAProvider",
+	HandlerType: (*CAProviderServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Configure",
+			Handler:    _CAProvider_Configure_Handler,
+		},
+		{
+			MethodName: "State",
+			Handler:    _CAProvider_State_Handler,
+		},
+		{
+			MethodName: "GenerateRoot",
+			Handler:    _CAProvider_GenerateRoot_Handler,
+		},
+		{
+			MethodName: "ActiveIntermediate",
+			Handler:    _CAProvider_ActiveIntermediate_Handler,
+		},
+		{
+			MethodName: "GenerateIntermediateCSR",
+			Handler:    _CAProvider_GenerateIntermediateCSR_Handler,
+		},
+		{
+			MethodName: "SetIntermediate",
+			Handler:    _CAProvider_SetIntermediate_Handler,
+		},
+		{
+			MethodName: "SignLeaf",
+			Handler:    _CAProvider_SignLeaf_Handler,
+		},
+		{
+			MethodName: "SignIntermediate",
+			Handler:    _CAProvider_SignIntermediate_Handler,
+		},
+		{
+			MethodName: "CrossSignCA",
+			Handler:    _CAProvider_CrossSignCA_Handler,
+		},
+		{
+			MethodName: "Cleanup",
+			Handler:    _CAProvider_Cleanup_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/pbcaprovider/caprovider.proto",
+}
+
+func (m *ConfigureRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfigureRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConfigureRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.OCSPResponderURL) > 0 {
+		i -= len(m.OCSPResponderURL)
+		copy(dAtA[i:], m.OCSPResponderURL)
+		i = encodeVarintCaprovider(dAtA, i, uint64(len(m.OCSPResponderURL)))
+		i--
+		dAtA[i] = 0x32
+	}
+	if len(m.State) > 0 {
+		for k := range m.State {
+			v := m.State[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintCaprovider(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintCaprovider(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintCaprovider(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.ConfigJSON) > 0 {
+		i -= len(m.ConfigJSON)
+		copy(dAtA[i:], m.ConfigJSON)
+		i = encodeVarintCaprovider(dAtA, i, uint64(len(m.ConfigJSON)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.IsPrimary {
+		i--
+		if m.IsPrimary {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Datacenter) > 0 {
+		i -= len(m.Datacenter)
+		copy(dAtA[i:], m.Datacenter)
+		i = encodeVarintCaprovider(dAtA, i, uint64(len(m.Datacenter)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.ClusterID) > 0 {
+		i -= len(m.ClusterID)
+		copy(dAtA[i:], m.ClusterID)
+		i = encodeVarintCaprovider(dAtA, i, uint64(len(m.ClusterID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ConfigureResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ConfigureResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ConfigureResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.SupportsCrossSigning {
+		i--
+		if m.SupportsCrossSigning {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.State) > 0 {
+		for k := range m.State {
+			v := m.State[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintCaprovider(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintCaprovider(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintCaprovider(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GenerateRootRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GenerateRootRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GenerateRootRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GenerateRootResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GenerateRootResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GenerateRootResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.RootPEM) > 0 {
+		i -= len(m.RootPEM)
+		copy(dAtA[i:], m.RootPEM)
+		i = encodeVarintCaprovider(dAtA, i, uint64(len(m.RootPEM)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ActiveIntermediateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActiveIntermediateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ActiveIntermediateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ActiveIntermediateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ActiveIntermediateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ActiveIntermediateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.RootPEM) > 0 {
+		i -= len(m.RootPEM)
+		copy(dAtA[i:], m.RootPEM)
+		i = encodeVarintCaprovider(dAtA, i, uint64(len(m.RootPEM)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.IntermediatePEM) > 0 {
+		i -= len(m.IntermediatePEM)
+		copy(dAtA[i:], m.IntermediatePEM)
+		i = encodeVarintCaprovider(dAtA, i, uint64(len(m.IntermediatePEM)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GenerateIntermediateCSRRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GenerateIntermediateCSRRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GenerateIntermediateCSRRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *GenerateIntermediateCSRResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GenerateIntermediateCSRResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *GenerateIntermediateCSRResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.CSRPEM) > 0 {
+		i -= len(m.CSRPEM)
+		copy(dAtA[i:], m.CSRPEM)
+		i = encodeVarintCaprovider(dAtA, i, uint64(len(m.CSRPEM)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetIntermediateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetIntermediateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetIntermediateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.RootPEM) > 0 {
+		i -= len(m.RootPEM)
+		copy(dAtA[i:], m.RootPEM)
+		i = encodeVarintCaprovider(dAtA, i, uint64(len(m.RootPEM)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.IntermediatePEM) > 0 {
+		i -= len(m.IntermediatePEM)
+		copy(dAtA[i:], m.IntermediatePEM)
+		i = encodeVarintCaprovider(dAtA, i, uint64(len(m.IntermediatePEM)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SetIntermediateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetIntermediateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SetIntermediateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SignLeafRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignLeafRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignLeafRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.TTL != nil {
+		{
+			size, err := m.TTL.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCaprovider(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.CSR) > 0 {
+		i -= len(m.CSR)
+		copy(dAtA[i:], m.CSR)
+		i = encodeVarintCaprovider(dAtA, i, uint64(len(m.CSR)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SignLeafResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignLeafResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignLeafResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.CertPEM) > 0 {
+		i -= len(m.CertPEM)
+		copy(dAtA[i:], m.CertPEM)
+		i = encodeVarintCaprovider(dAtA, i, uint64(len(m.CertPEM)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SignIntermediateRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignIntermediateRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignIntermediateRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.CSR) > 0 {
+		i -= len(m.CSR)
+		copy(dAtA[i:], m.CSR)
+		i = encodeVarintCaprovider(dAtA, i, uint64(len(m.CSR)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SignIntermediateResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SignIntermediateResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SignIntermediateResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.CertPEM) > 0 {
+		i -= len(m.CertPEM)
+		copy(dAtA[i:], m.CertPEM)
+		i = encodeVarintCaprovider(dAtA, i, uint64(len(m.CertPEM)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CrossSignCARequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CrossSignCARequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CrossSignCARequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Cert) > 0 {
+		i -= len(m.Cert)
+		copy(dAtA[i:], m.Cert)
+		i = encodeVarintCaprovider(dAtA, i, uint64(len(m.Cert)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CrossSignCAResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CrossSignCAResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CrossSignCAResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.CertPEM) > 0 {
+		i -= len(m.CertPEM)
+		copy(dAtA[i:], m.CertPEM)
+		i = encodeVarintCaprovider(dAtA, i, uint64(len(m.CertPEM)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CleanupRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CleanupRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CleanupRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.OtherConfigJSON) > 0 {
+		i -= len(m.OtherConfigJSON)
+		copy(dAtA[i:], m.OtherConfigJSON)
+		i = encodeVarintCaprovider(dAtA, i, uint64(len(m.OtherConfigJSON)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ProviderTypeChange {
+		i--
+		if m.ProviderTypeChange {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CleanupResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CleanupResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CleanupResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintCaprovider(dAtA []byte, offset int, v uint64) int {
+	offset -= sovCaprovider(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ConfigureRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ClusterID)
+	if l > 0 {
+		n += 1 + l + sovCaprovider(uint64(l))
+	}
+	l = len(m.Datacenter)
+	if l > 0 {
+		n += 1 + l + sovCaprovider(uint64(l))
+	}
+	if m.IsPrimary {
+		n += 2
+	}
+	l = len(m.ConfigJSON)
+	if l > 0 {
+		n += 1 + l + sovCaprovider(uint64(l))
+	}
+	if len(m.State) > 0 {
+		for k, v := range m.State {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovCaprovider(uint64(len(k))) + 1 + len(v) + sovCaprovider(uint64(len(v)))
+			n += mapEntrySize + 1 + sovCaprovider(uint64(mapEntrySize))
+		}
+	}
+	l = len(m.OCSPResponderURL)
+	if l > 0 {
+		n += 1 + l + sovCaprovider(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ConfigureResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.SupportsCrossSigning {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.State) > 0 {
+		for k, v := range m.State {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovCaprovider(uint64(len(k))) + 1 + len(v) + sovCaprovider(uint64(len(v)))
+			n += mapEntrySize + 1 + sovCaprovider(uint64(mapEntrySize))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GenerateRootRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GenerateRootResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.RootPEM)
+	if l > 0 {
+		n += 1 + l + sovCaprovider(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ActiveIntermediateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ActiveIntermediateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.IntermediatePEM)
+	if l > 0 {
+		n += 1 + l + sovCaprovider(uint64(l))
+	}
+	l = len(m.RootPEM)
+	if l > 0 {
+		n += 1 + l + sovCaprovider(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GenerateIntermediateCSRRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *GenerateIntermediateCSRResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.CSRPEM)
+	if l > 0 {
+		n += 1 + l + sovCaprovider(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SetIntermediateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.IntermediatePEM)
+	if l > 0 {
+		n += 1 + l + sovCaprovider(uint64(l))
+	}
+	l = len(m.RootPEM)
+	if l > 0 {
+		n += 1 + l + sovCaprovider(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SetIntermediateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SignLeafRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.CSR)
+	if l > 0 {
+		n += 1 + l + sovCaprovider(uint64(l))
+	}
+	if m.TTL != nil {
+		l = m.TTL.Size()
+		n += 1 + l + sovCaprovider(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SignLeafResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.CertPEM)
+	if l > 0 {
+		n += 1 + l + sovCaprovider(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SignIntermediateRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.CSR)
+	if l > 0 {
+		n += 1 + l + sovCaprovider(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SignIntermediateResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.CertPEM)
+	if l > 0 {
+		n += 1 + l + sovCaprovider(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CrossSignCARequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Cert)
+	if l > 0 {
+		n += 1 + l + sovCaprovider(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CrossSignCAResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.CertPEM)
+	if l > 0 {
+		n += 1 + l + sovCaprovider(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CleanupRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ProviderTypeChange {
+		n += 2
+	}
+	l = len(m.OtherConfigJSON)
+	if l > 0 {
+		n += 1 + l + sovCaprovider(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CleanupResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovCaprovider(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozCaprovider(x uint64) (n int) {
+	return sovCaprovider(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ConfigureRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigureRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigureRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClusterID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ClusterID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Datacenter", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Datacenter = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IsPrimary", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IsPrimary = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigJSON", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ConfigJSON = append(m.ConfigJSON[:0], dAtA[iNdEx:postIndex]...)
+			if m.ConfigJSON == nil {
+				m.ConfigJSON = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.State == nil {
+				m.State = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCaprovider
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCaprovider
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthCaprovider
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthCaprovider
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCaprovider
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthCaprovider
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthCaprovider
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipCaprovider(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthCaprovider
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.State[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OCSPResponderURL", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OCSPResponderURL = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ConfigureResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ConfigureResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ConfigureResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SupportsCrossSigning", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SupportsCrossSigning = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.State == nil {
+				m.State = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowCaprovider
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCaprovider
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthCaprovider
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthCaprovider
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowCaprovider
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthCaprovider
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthCaprovider
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipCaprovider(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthCaprovider
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.State[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GenerateRootRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GenerateRootRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GenerateRootRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GenerateRootResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GenerateRootResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GenerateRootResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RootPEM", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RootPEM = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActiveIntermediateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActiveIntermediateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActiveIntermediateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ActiveIntermediateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ActiveIntermediateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ActiveIntermediateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntermediatePEM", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IntermediatePEM = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RootPEM", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RootPEM = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GenerateIntermediateCSRRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GenerateIntermediateCSRRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GenerateIntermediateCSRRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GenerateIntermediateCSRResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GenerateIntermediateCSRResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GenerateIntermediateCSRResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CSRPEM", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CSRPEM = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetIntermediateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetIntermediateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetIntermediateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntermediatePEM", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IntermediatePEM = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RootPEM", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RootPEM = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SetIntermediateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SetIntermediateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SetIntermediateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignLeafRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignLeafRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignLeafRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CSR", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CSR = append(m.CSR[:0], dAtA[iNdEx:postIndex]...)
+			if m.CSR == nil {
+				m.CSR = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TTL", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TTL == nil {
+				m.TTL = &types.Duration{}
+			}
+			if err := m.TTL.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignLeafResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignLeafResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignLeafResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CertPEM", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CertPEM = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignIntermediateRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignIntermediateRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignIntermediateRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CSR", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CSR = append(m.CSR[:0], dAtA[iNdEx:postIndex]...)
+			if m.CSR == nil {
+				m.CSR = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignIntermediateResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignIntermediateResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignIntermediateResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CertPEM", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CertPEM = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CrossSignCARequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CrossSignCARequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CrossSignCARequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cert", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cert = append(m.Cert[:0], dAtA[iNdEx:postIndex]...)
+			if m.Cert == nil {
+				m.Cert = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CrossSignCAResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CrossSignCAResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CrossSignCAResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CertPEM", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CertPEM = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CleanupRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CleanupRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CleanupRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProviderTypeChange", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ProviderTypeChange = bool(v != 0)
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OtherConfigJSON", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OtherConfigJSON = append(m.OtherConfigJSON[:0], dAtA[iNdEx:postIndex]...)
+			if m.OtherConfigJSON == nil {
+				m.OtherConfigJSON = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CleanupResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CleanupResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CleanupResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCaprovider(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthCaprovider
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipCaprovider(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowCaprovider
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowCaprovider
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthCaprovider
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupCaprovider
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthCaprovider
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthCaprovider        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowCaprovider          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupCaprovider = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,55 @@
+package pbconnect
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/hashicorp/consul/agent/structs"
+	"github.com/hashicorp/consul/proto/pbcommon"
+)
+
+// NewCARootsFromStructs converts roots to their protobuf form. Private key
+// material (SigningCert and SigningKey) is never included.
+func NewCARootsFromStructs(s *structs.IndexedCARoots) *CARoots {
+	if s == nil {
+		return nil
+	}
+	t := &CARoots{
+		ActiveRootID: s.ActiveRootID,
+		TrustDomain:  s.TrustDomain,
+		Roots:        make([]*CARoot, 0, len(s.Roots)),
+		QueryMeta:    &pbcommon.QueryMeta{Index: s.Index},
+	}
+	for _, r := range s.Roots {
+		t.Roots = append(t.Roots, &CARoot{
+			ID:                  r.ID,
+			Name:                r.Name,
+			SerialNumber:        r.SerialNumber,
+			SigningKeyID:        r.SigningKeyID,
+			ExternalTrustDomain: r.ExternalTrustDomain,
+			NotBefore:           newTimestampFromTime(r.NotBefore),
+			NotAfter:            newTimestampFromTime(r.NotAfter),
+			RootCert:            r.RootCert,
+			IntermediateCerts:   r.IntermediateCerts,
+			Active:              r.Active,
+			RotatedOutAt:        newTimestampFromTime(r.RotatedOutAt),
+			PrivateKeyType:      r.PrivateKeyType,
+			PrivateKeyBits:      int32(r.PrivateKeyBits),
+			RaftIndex: &pbcommon.RaftIndex{
+				CreateIndex: r.CreateIndex,
+				ModifyIndex: r.ModifyIndex,
+			},
+		})
+	}
+	return t
+}
+
+// newTimestampFromTime returns nil for the zero time, which protobuf
+// timestamps cannot represent.
+func newTimestampFromTime(t time.Time) *types.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return &types.Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+}
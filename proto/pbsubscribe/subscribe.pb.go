@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	pbconnect "github.com/hashicorp/consul/proto/pbconnect"
 	pbservice "github.com/hashicorp/consul/proto/pbservice"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -37,18 +38,24 @@ const (
 	// ServiceHealthConnect topic contains events for any changes to service
 	// health for connect-enabled services.
 	Topic_ServiceHealthConnect Topic = 2
+	// CARoots topic contains events for any changes to the set of trusted
+	// Connect CA roots, including rotations and pruning. Every event contains
+	// the full set of roots.
+	Topic_CARoots Topic = 3
 )
 
 var Topic_name = map[int32]string{
 	0: "Unknown",
 	1: "ServiceHealth",
 	2: "ServiceHealthConnect",
+	3: "CARoots",
 }
 
 var Topic_value = map[string]int32{
 	"Unknown":              0,
 	"ServiceHealth":        1,
 	"ServiceHealthConnect": 2,
+	"CARoots":              3,
 }
 
 func (x Topic) String() string {
@@ -223,6 +230,7 @@ type Event struct {
 	//	*Event_NewSnapshotToFollow
 	//	*Event_EventBatch
 	//	*Event_ServiceHealth
+	//	*Event_CARoots
 	Payload              isEvent_Payload `protobuf_oneof:"Payload"`
 	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
 	XXX_unrecognized     []byte          `json:"-"`
@@ -280,11 +288,15 @@ type Event_EventBatch struct {
 type Event_ServiceHealth struct {
 	ServiceHealth *ServiceHealthUpdate `protobuf:"bytes,10,opt,name=ServiceHealth,proto3,oneof" json:"ServiceHealth,omitempty"`
 }
+type Event_CARoots struct {
+	CARoots *pbconnect.CARoots `protobuf:"bytes,11,opt,name=CARoots,proto3,oneof" json:"CARoots,omitempty"`
+}
 
 func (*Event_EndOfSnapshot) isEvent_Payload()       {}
 func (*Event_NewSnapshotToFollow) isEvent_Payload() {}
 func (*Event_EventBatch) isEvent_Payload()          {}
 func (*Event_ServiceHealth) isEvent_Payload()       {}
+func (*Event_CARoots) isEvent_Payload()             {}
 
 func (m *Event) GetPayload() isEvent_Payload {
 	if m != nil {
@@ -328,6 +340,13 @@ func (m *Event) GetServiceHealth() *ServiceHealthUpdate {
 	return nil
 }
 
+func (m *Event) GetCARoots() *pbconnect.CARoots {
+	if x, ok := m.GetPayload().(*Event_CARoots); ok {
+		return x.CARoots
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Event) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -335,6 +354,7 @@ func (*Event) XXX_OneofWrappers() []interface{} {
 		(*Event_NewSnapshotToFollow)(nil),
 		(*Event_EventBatch)(nil),
 		(*Event_ServiceHealth)(nil),
+		(*Event_CARoots)(nil),
 	}
 }
 
@@ -452,42 +472,44 @@ func init() {
 func init() { proto.RegisterFile("proto/pbsubscribe/subscribe.proto", fileDescriptor_ab3eb8c810e315fb) }
 
 var fileDescriptor_ab3eb8c810e315fb = []byte{
-	// 550 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x53, 0xcb, 0x6e, 0xd3, 0x4c,
-	0x14, 0xf6, 0xa4, 0x57, 0x9f, 0xfc, 0xad, 0xfc, 0x4f, 0x83, 0xb0, 0x52, 0x64, 0x85, 0x08, 0x55,
-	0xa1, 0x12, 0x31, 0x0a, 0x12, 0xec, 0x40, 0x24, 0x6d, 0x09, 0x42, 0x4a, 0x2a, 0xa7, 0x5d, 0xc0,
-	0x6e, 0x62, 0x1f, 0x62, 0x2b, 0xee, 0x8c, 0xb1, 0x27, 0x0d, 0xdd, 0xc3, 0x3b, 0xf0, 0x48, 0x2c,
-	0x59, 0xf0, 0x00, 0x28, 0xbc, 0x08, 0xf2, 0xc4, 0x71, 0x9c, 0xa4, 0xbb, 0x39, 0xdf, 0x65, 0xce,
-	0xcc, 0xb9, 0xc0, 0xe3, 0x28, 0x16, 0x52, 0xd8, 0xd1, 0x30, 0x99, 0x0c, 0x13, 0x37, 0x0e, 0x86,
-	0x68, 0xe7, 0xa7, 0xa6, 0xe2, 0xa8, 0x9e, 0x03, 0xd5, 0x6a, 0xae, 0xc6, 0xf8, 0x36, 0x70, 0xd1,
-	0xe6, 0xc2, 0xcb, 0x64, 0xf5, 0xdf, 0x04, 0x8c, 0xc1, 0x42, 0xe9, 0xe0, 0x97, 0x09, 0x26, 0x92,
-	0x9e, 0xc0, 0xce, 0x95, 0x88, 0x02, 0xd7, 0x24, 0x35, 0xd2, 0x38, 0x6c, 0x19, 0xcd, 0xe5, 0xe5,
-	0x0a, 0x77, 0xe6, 0x34, 0x35, 0x60, 0xeb, 0x03, 0xde, 0x99, 0xa5, 0x1a, 0x69, 0xe8, 0x4e, 0x7a,
-	0xa4, 0x95, 0xd4, 0x39, 0x46, 0x6e, 0x6e, 0x29, 0x6c, 0x1e, 0xa4, 0xe8, 0x7b, 0xee, 0xe1, 0x57,
-	0x73, 0xbb, 0x46, 0x1a, 0xdb, 0xce, 0x3c, 0xa0, 0x16, 0xc0, 0x19, 0x93, 0xcc, 0x45, 0x2e, 0x31,
-	0x36, 0x77, 0x94, 0xa1, 0x80, 0xd0, 0x47, 0xa0, 0xf7, 0xd8, 0x0d, 0x26, 0x11, 0x73, 0xd1, 0xdc,
-	0x55, 0xf4, 0x12, 0x48, 0xd9, 0x4b, 0x16, 0xcb, 0x40, 0x06, 0x82, 0x9b, 0x7b, 0x73, 0x36, 0x07,
-	0xea, 0xdf, 0x4b, 0xb0, 0x73, 0x7e, 0x8b, 0x5c, 0x2e, 0x73, 0x93, 0x62, 0xee, 0x13, 0x38, 0x38,
-	0xe7, 0x5e, 0xff, 0xf3, 0x80, 0xb3, 0x28, 0xf1, 0x85, 0x54, 0x7f, 0xd8, 0xef, 0x6a, 0xce, 0x2a,
-	0x4c, 0x5b, 0x70, 0xd4, 0xc3, 0xe9, 0x22, 0xbc, 0x12, 0x17, 0x22, 0x0c, 0xc5, 0x54, 0xfd, 0x2e,
-	0x55, 0xdf, 0x47, 0xd2, 0x57, 0x00, 0x2a, 0x75, 0x9b, 0x49, 0xd7, 0x57, 0x5f, 0x2e, 0xb7, 0x1e,
-	0x14, 0x4a, 0xb8, 0x24, 0xbb, 0x9a, 0x53, 0x90, 0xd2, 0x0b, 0x38, 0x18, 0xcc, 0x3b, 0xd4, 0x45,
-	0x16, 0x4a, 0xdf, 0x04, 0xe5, 0xb5, 0x0a, 0xde, 0x15, 0xfe, 0x3a, 0xf2, 0x98, 0xc4, 0xf4, 0xd1,
-	0x2b, 0x70, 0x5b, 0x87, 0xbd, 0x4b, 0x76, 0x17, 0x0a, 0xe6, 0xd5, 0x5f, 0x16, 0xdf, 0x42, 0x1b,
-	0xb0, 0xab, 0xa2, 0xc4, 0x24, 0xb5, 0xad, 0x46, 0x79, 0xa5, 0xb1, 0x8a, 0x70, 0x32, 0xbe, 0xfe,
-	0x8d, 0xc0, 0xd1, 0x3d, 0xb9, 0xe8, 0x13, 0x28, 0xf5, 0xa3, 0x6c, 0x2c, 0x2a, 0x05, 0x77, 0x87,
-	0x49, 0x16, 0x8a, 0x51, 0x3f, 0x72, 0x4a, 0xfd, 0x88, 0xbe, 0x03, 0xa3, 0xe3, 0xa3, 0x3b, 0xce,
-	0x6e, 0xe8, 0x09, 0x0f, 0x55, 0x81, 0xcb, 0xad, 0xe3, 0x66, 0x3e, 0x85, 0xcd, 0x75, 0x89, 0xb3,
-	0x61, 0x3a, 0x7d, 0x9b, 0x0d, 0x22, 0x2d, 0xc3, 0xde, 0x35, 0x1f, 0x73, 0x31, 0xe5, 0x86, 0x46,
-	0xff, 0x5f, 0xab, 0x93, 0x41, 0xa8, 0x09, 0x95, 0x15, 0xa8, 0x23, 0x38, 0x47, 0x57, 0x1a, 0xa5,
-	0xd3, 0xa7, 0xa0, 0xe7, 0x8f, 0xa3, 0xff, 0xc1, 0xbe, 0x83, 0xa3, 0x20, 0x91, 0x18, 0x1b, 0x1a,
-	0x3d, 0x04, 0x38, 0xc3, 0x78, 0x11, 0x93, 0xd6, 0x47, 0x78, 0x38, 0x90, 0x4c, 0x62, 0xc7, 0x67,
-	0x7c, 0x84, 0xd9, 0x56, 0x44, 0xe9, 0x3c, 0xd1, 0xd7, 0xa0, 0xe7, 0x5b, 0x42, 0x8f, 0x8b, 0x0d,
-	0x59, 0xdb, 0x9d, 0xea, 0x46, 0x4d, 0xeb, 0xda, 0x73, 0xd2, 0x7e, 0xf3, 0x73, 0x66, 0x91, 0x5f,
-	0x33, 0x8b, 0xfc, 0x99, 0x59, 0xe4, 0xc7, 0x5f, 0x4b, 0xfb, 0xf4, 0x6c, 0x14, 0x48, 0x7f, 0x32,
-	0x6c, 0xba, 0xe2, 0xc6, 0xf6, 0x59, 0xe2, 0x07, 0xae, 0x88, 0x23, 0xdb, 0x15, 0x3c, 0x99, 0x84,
-	0xf6, 0xc6, 0x7a, 0x0f, 0x77, 0x15, 0xf4, 0xe2, 0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xc9, 0xb6,
-	0x48, 0xa0, 0xfa, 0x03, 0x00, 0x00,
+	// 588 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x54, 0xcf, 0x6e, 0xd3, 0x4e,
+	0x10, 0xf6, 0x26, 0x6d, 0xd3, 0x4c, 0x7e, 0xad, 0xfc, 0xdb, 0x16, 0xb1, 0x4a, 0x21, 0x0a, 0x11,
+	0xaa, 0x42, 0x05, 0x09, 0x0a, 0x12, 0xdc, 0x40, 0x34, 0x6d, 0x09, 0x42, 0x4a, 0xaa, 0x4d, 0x7b,
+	0x80, 0xdb, 0xc6, 0x19, 0x62, 0xab, 0xe9, 0xae, 0xb1, 0x37, 0x2d, 0xbd, 0xf3, 0x10, 0xbc, 0x07,
+	0x2f, 0xc1, 0x91, 0x03, 0x0f, 0x80, 0xca, 0x8b, 0x20, 0xaf, 0xff, 0xc4, 0x49, 0x7a, 0xf2, 0xce,
+	0xf7, 0xcd, 0x37, 0x33, 0x3b, 0x3b, 0x63, 0x78, 0xe4, 0x07, 0x4a, 0xab, 0xb6, 0x3f, 0x0a, 0x67,
+	0xa3, 0xd0, 0x09, 0xbc, 0x11, 0xb6, 0xb3, 0x53, 0xcb, 0x70, 0xb4, 0x9c, 0x01, 0xd5, 0x87, 0xa9,
+	0xb7, 0xa3, 0xa4, 0x44, 0x47, 0xb7, 0x93, 0x6f, 0xec, 0x59, 0xad, 0x66, 0xc1, 0x30, 0xb8, 0xf2,
+	0x1c, 0x6c, 0x4b, 0x35, 0x4e, 0xa2, 0x34, 0x7e, 0x13, 0xb0, 0x87, 0x69, 0x20, 0x8e, 0x5f, 0x66,
+	0x18, 0x6a, 0xba, 0x0f, 0xeb, 0x67, 0xca, 0xf7, 0x1c, 0x46, 0xea, 0xa4, 0xb9, 0xdd, 0xb1, 0x5b,
+	0xf3, 0xdc, 0x06, 0xe7, 0x31, 0x4d, 0x6d, 0x28, 0x7e, 0xc0, 0x1b, 0x56, 0xa8, 0x93, 0x66, 0x99,
+	0x47, 0x47, 0xba, 0x1b, 0x29, 0x2f, 0x50, 0xb2, 0xa2, 0xc1, 0x62, 0x23, 0x42, 0xdf, 0xcb, 0x31,
+	0x7e, 0x65, 0x6b, 0x75, 0xd2, 0x5c, 0xe3, 0xb1, 0x41, 0x6b, 0x00, 0x47, 0x42, 0x0b, 0x07, 0xa5,
+	0xc6, 0x80, 0xad, 0x1b, 0x41, 0x0e, 0xa1, 0x0f, 0xa0, 0xdc, 0x17, 0x97, 0x18, 0xfa, 0xc2, 0x41,
+	0xb6, 0x61, 0xe8, 0x39, 0x10, 0xb1, 0xa7, 0x22, 0xd0, 0x9e, 0xf6, 0x94, 0x64, 0xa5, 0x98, 0xcd,
+	0x80, 0xc6, 0x8f, 0x02, 0xac, 0x1f, 0x5f, 0xa1, 0xd4, 0xf3, 0xdc, 0x24, 0x9f, 0x7b, 0x1f, 0xb6,
+	0x8e, 0xe5, 0x78, 0xf0, 0x79, 0x28, 0x85, 0x1f, 0xba, 0x4a, 0x9b, 0x3b, 0x6c, 0xf6, 0x2c, 0xbe,
+	0x08, 0xd3, 0x0e, 0xec, 0xf4, 0xf1, 0x3a, 0x35, 0xcf, 0xd4, 0x89, 0x9a, 0x4e, 0xd5, 0x35, 0x2b,
+	0x26, 0xde, 0x77, 0x91, 0xf4, 0x15, 0x80, 0x49, 0x7d, 0x28, 0xb4, 0xe3, 0x9a, 0x2b, 0x57, 0x3a,
+	0xf7, 0x72, 0x2d, 0x9c, 0x93, 0x3d, 0x8b, 0xe7, 0x5c, 0xe9, 0x09, 0x6c, 0x0d, 0xe3, 0x17, 0xea,
+	0xa1, 0x98, 0x6a, 0x97, 0x81, 0xd1, 0xd6, 0x72, 0xda, 0x05, 0xfe, 0xdc, 0x1f, 0x0b, 0x8d, 0x51,
+	0xd1, 0x0b, 0x30, 0x7d, 0x0a, 0xa5, 0xee, 0x5b, 0xae, 0x94, 0x0e, 0x59, 0xc5, 0x44, 0xb0, 0x5b,
+	0xe9, 0x40, 0x24, 0x78, 0xcf, 0xe2, 0xa9, 0xcb, 0x61, 0x19, 0x4a, 0xa7, 0xe2, 0x66, 0xaa, 0xc4,
+	0xb8, 0xf1, 0x32, 0x5f, 0x39, 0x6d, 0xc2, 0x86, 0xb1, 0x42, 0x46, 0xea, 0x45, 0x13, 0x65, 0xe9,
+	0x0e, 0x3c, 0xe1, 0x1b, 0xdf, 0x08, 0xec, 0xdc, 0x51, 0x19, 0x7d, 0x0c, 0x85, 0x81, 0x9f, 0x0c,
+	0xd1, 0x6e, 0x4e, 0xdd, 0x15, 0x5a, 0x4c, 0xd5, 0x64, 0xe0, 0xf3, 0xc2, 0xc0, 0xa7, 0xef, 0xc0,
+	0xee, 0xba, 0xe8, 0x5c, 0x24, 0x11, 0xfa, 0x6a, 0x8c, 0xe6, 0x39, 0x2a, 0x9d, 0xbd, 0x56, 0x36,
+	0xb3, 0xad, 0x65, 0x17, 0xbe, 0x22, 0x3a, 0xe8, 0x27, 0x63, 0x4b, 0x2b, 0x50, 0x3a, 0x97, 0x17,
+	0x52, 0x5d, 0x4b, 0xdb, 0xa2, 0xff, 0x2f, 0x75, 0xd5, 0x26, 0x94, 0xc1, 0xee, 0x02, 0xd4, 0x8d,
+	0xbb, 0x63, 0x17, 0x22, 0x65, 0xd2, 0x17, 0xbb, 0x78, 0xf0, 0x04, 0xca, 0x59, 0xa5, 0xf4, 0x3f,
+	0xd8, 0xe4, 0x38, 0xf1, 0x42, 0x8d, 0x81, 0x6d, 0xd1, 0x6d, 0x80, 0x23, 0x0c, 0x52, 0x9b, 0x74,
+	0x3e, 0xc2, 0xfd, 0xa1, 0x16, 0x1a, 0xbb, 0xae, 0x90, 0x13, 0x4c, 0x16, 0xca, 0x8f, 0x46, 0x91,
+	0xbe, 0x86, 0x72, 0xb6, 0x60, 0x74, 0x2f, 0xff, 0x96, 0x4b, 0x6b, 0x57, 0x5d, 0x69, 0x70, 0xc3,
+	0x7a, 0x4e, 0x0e, 0xdf, 0xfc, 0xbc, 0xad, 0x91, 0x5f, 0xb7, 0x35, 0xf2, 0xe7, 0xb6, 0x46, 0xbe,
+	0xff, 0xad, 0x59, 0x9f, 0x9e, 0x4d, 0x3c, 0xed, 0xce, 0x46, 0x2d, 0x47, 0x5d, 0xb6, 0x5d, 0x11,
+	0xba, 0x9e, 0xa3, 0x02, 0x3f, 0xda, 0xf9, 0x70, 0x36, 0x6d, 0xaf, 0xfc, 0x38, 0x46, 0x1b, 0x06,
+	0x7a, 0xf1, 0x6f, 0x00, 0x30, 0x6f, 0x2e, 0x61, 0x54, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -824,6 +846,27 @@ func (m *Event_ServiceHealth) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	}
 	return len(dAtA) - i, nil
 }
+func (m *Event_CARoots) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Event_CARoots) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CARoots != nil {
+		{
+			size, err := m.CARoots.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintSubscribe(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	return len(dAtA) - i, nil
+}
 func (m *EventBatch) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1018,6 +1061,18 @@ func (m *Event_ServiceHealth) Size() (n int) {
 	}
 	return n
 }
+func (m *Event_CARoots) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CARoots != nil {
+		l = m.CARoots.Size()
+		n += 1 + l + sovSubscribe(uint64(l))
+	}
+	return n
+}
 func (m *EventBatch) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1470,6 +1525,41 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 			}
 			m.Payload = &Event_ServiceHealth{v}
 			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CARoots", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSubscribe
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSubscribe
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthSubscribe
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &pbconnect.CARoots{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Payload = &Event_CARoots{v}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSubscribe(dAtA[iNdEx:])